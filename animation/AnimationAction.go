@@ -0,0 +1,350 @@
+package animation
+
+import (
+	"math"
+
+	three "github.com/uzudil/three.go"
+	"github.com/uzudil/three.go/core"
+)
+
+/**
+ * Action provided by AnimationMixer for scheduling clip playback on specific
+ * objects.
+ *
+ * @author Ben Houston / http://clara.io/
+ * @author David Sarno / http://lighthaus.us/
+ * @author tschw
+ */
+
+// AnimationAction plays a clip on a root object. Get one from
+// AnimationMixer.ClipAction, then Play it; the mixer advances it in Update.
+type AnimationAction struct {
+	mixer *AnimationMixer
+	clip *AnimationClip
+	localRoot *core.Object3D
+
+	interpolants []*Interpolant
+	propertyMixers []*PropertyMixer
+
+	// Loop is three.LoopOnce, LoopRepeat or LoopPingPong. With LoopRepeat and
+	// LoopPingPong the clip is played Repetitions times, +Inf for no end.
+	Loop int
+	Repetitions float64
+
+	// Time is the local time in the clip, in seconds. TimeScale scales how
+	// fast it runs, negative values play the clip backwards.
+	Time float64
+	TimeScale float64
+
+	// Weight is how much the action contributes to the properties it animates,
+	// from 0 to 1, relative to the other actions of the mixer.
+	Weight float64
+
+	// ClampWhenFinished pauses the action at its end instead of disabling it,
+	// so the last frame stays in place.
+	ClampWhenFinished bool
+
+	Enabled bool
+	Paused bool
+
+	loopCount int // -1 until the action starts to run
+	startTime float64
+	hasStartTime bool
+	effectiveWeight float64
+
+	// set while fading, maps the time of the mixer to a weight factor
+	weightInterpolant *Interpolant
+}
+
+func newAnimationAction(mixer *AnimationMixer, clip *AnimationClip, localRoot *core.Object3D) (*AnimationAction) {
+	a := &AnimationAction{
+		mixer: mixer,
+		clip: clip,
+		localRoot: localRoot,
+		interpolants: make([]*Interpolant, len(clip.Tracks)),
+		propertyMixers: make([]*PropertyMixer, len(clip.Tracks)),
+		Loop: three.LoopRepeat,
+		Repetitions: math.Inf( 1 ),
+		TimeScale: 1,
+		Weight: 1,
+		Enabled: true,
+		loopCount: -1,
+		effectiveWeight: 1,
+	}
+	return a
+}
+
+// Play schedules the action to run from the next update of the mixer.
+func (a *AnimationAction) Play() (*AnimationAction) {
+	a.mixer.activateAction( a )
+	return a
+}
+
+// Stop unschedules the action and resets it.
+func (a *AnimationAction) Stop() (*AnimationAction) {
+	a.mixer.deactivateAction( a )
+	return a.Reset()
+}
+
+// Reset rewinds the action, and enables and unpauses it.
+func (a *AnimationAction) Reset() (*AnimationAction) {
+	a.Paused = false
+	a.Enabled = true
+
+	a.Time = 0 // restart clip
+	a.loopCount = -1 // forget previous loops
+	a.hasStartTime = false // forget scheduling
+
+	return a.StopFading()
+}
+
+// IsRunning reports whether the action is currently animating its properties.
+func (a *AnimationAction) IsRunning() bool {
+	return a.Enabled && !a.Paused && a.TimeScale != 0 && !a.hasStartTime && a.mixer.isActiveAction( a )
+}
+
+// IsScheduled reports whether Play has been called and Stop has not.
+func (a *AnimationAction) IsScheduled() bool {
+	return a.mixer.isActiveAction( a )
+}
+
+// StartAt delays the start of the action until the mixer reaches time.
+func (a *AnimationAction) StartAt(time float64) (*AnimationAction) {
+	a.startTime = time
+	a.hasStartTime = true
+	return a
+}
+
+func (a *AnimationAction) SetLoop(mode int, repetitions float64) (*AnimationAction) {
+	a.Loop = mode
+	a.Repetitions = repetitions
+	return a
+}
+
+// SetEffectiveWeight sets Weight and stops any fading.
+func (a *AnimationAction) SetEffectiveWeight(weight float64) (*AnimationAction) {
+	a.Weight = weight
+
+	// note: same logic as when updated at runtime
+	a.effectiveWeight = 0
+	if a.Enabled {
+		a.effectiveWeight = weight
+	}
+
+	return a.StopFading()
+}
+
+// GetEffectiveWeight returns the weight the action was last applied with,
+// including fading.
+func (a *AnimationAction) GetEffectiveWeight() float64 {
+	return a.effectiveWeight
+}
+
+// FadeIn raises the weight from 0 to Weight over duration seconds.
+func (a *AnimationAction) FadeIn(duration float64) (*AnimationAction) {
+	return a.scheduleFading( duration, 0, 1 )
+}
+
+// FadeOut lowers the weight from Weight to 0 over duration seconds, then
+// disables the action.
+func (a *AnimationAction) FadeOut(duration float64) (*AnimationAction) {
+	return a.scheduleFading( duration, 1, 0 )
+}
+
+// CrossFadeFrom fades fadeOutAction out while this action fades in.
+func (a *AnimationAction) CrossFadeFrom(fadeOutAction *AnimationAction, duration float64) (*AnimationAction) {
+	fadeOutAction.FadeOut( duration )
+	a.FadeIn( duration )
+	return a
+}
+
+// CrossFadeTo fades this action out while fadeInAction fades in.
+func (a *AnimationAction) CrossFadeTo(fadeInAction *AnimationAction, duration float64) (*AnimationAction) {
+	fadeInAction.CrossFadeFrom( a, duration )
+	return a
+}
+
+func (a *AnimationAction) StopFading() (*AnimationAction) {
+	a.weightInterpolant = nil
+	return a
+}
+
+func (a *AnimationAction) GetMixer() (*AnimationMixer) {
+	return a.mixer
+}
+
+func (a *AnimationAction) GetClip() (*AnimationClip) {
+	return a.clip
+}
+
+func (a *AnimationAction) GetRoot() (*core.Object3D) {
+	return a.localRoot
+}
+
+func (a *AnimationAction) scheduleFading(duration, weightNow, weightThen float64) (*AnimationAction) {
+	now := a.mixer.Time
+
+	a.weightInterpolant = NewLinearInterpolant(
+		[]float64{ now, now + duration },
+		[]float64{ weightNow, weightThen },
+		1, nil,
+	)
+
+	return a
+}
+
+// update advances the action to the time of the mixer and blends its values
+// into the property mixers.
+func (a *AnimationAction) update(time, deltaTime, timeDirection float64) {
+
+	// called by the mixer
+
+	if !a.Enabled {
+		// call updateWeight() to update effectiveWeight
+		a.updateWeight( time )
+		return
+	}
+
+	if a.hasStartTime {
+		timeRunning := ( time - a.startTime ) * timeDirection
+
+		if timeRunning < 0 || timeDirection == 0 {
+			return // yet to come / don't decide when delta = 0
+		}
+
+		// start
+
+		a.hasStartTime = false // unschedule
+		deltaTime = timeDirection * timeRunning
+	}
+
+	// apply time scale and advance time
+
+	clipTime := a.updateTime( deltaTime * a.TimeScale )
+
+	// note: updateTime may disable the action resulting in
+	// an effective weight of 0
+
+	weight := a.updateWeight( time )
+
+	if weight > 0 {
+		for j, interpolant := range a.interpolants {
+			interpolant.Evaluate( clipTime )
+			a.propertyMixers[ j ].Accumulate( weight )
+		}
+	}
+}
+
+func (a *AnimationAction) updateWeight(time float64) float64 {
+	weight := 0.0
+
+	if a.Enabled {
+		weight = a.Weight
+
+		if interpolant := a.weightInterpolant; interpolant != nil {
+			interpolantValue := interpolant.Evaluate( time )[ 0 ]
+
+			weight *= interpolantValue
+
+			if time > interpolant.ParameterPositions[ 1 ] {
+				a.StopFading()
+
+				if interpolantValue == 0 {
+					// faded out, disable
+					a.Enabled = false
+				}
+			}
+		}
+	}
+
+	a.effectiveWeight = weight
+	return weight
+}
+
+// updateTime advances Time by deltaTime, looping or stopping at the ends of
+// the clip, and returns the time in the clip to sample.
+func (a *AnimationAction) updateTime(deltaTime float64) float64 {
+	if !a.Paused && deltaTime != 0 {
+		a.advanceTime( deltaTime )
+	}
+
+	if a.Loop == three.LoopPingPong && a.loopCount % 2 == 1 {
+		// invert time for the "pong round"
+		return a.clip.Duration - a.Time
+	}
+
+	return a.Time
+}
+
+func (a *AnimationAction) advanceTime(deltaTime float64) {
+	time := a.Time + deltaTime
+	duration := a.clip.Duration
+
+	if a.Loop == three.LoopOnce {
+
+		a.loopCount = 0
+
+		if time >= duration || time < 0 {
+			time = math.Max( 0, math.Min( time, duration ) )
+			a.stopAtEnd()
+			a.Time = time
+			a.mixer.finished( a, deltaTime )
+			return
+		}
+
+		a.Time = time
+		return
+	}
+
+	// repetitive Repeat or PingPong
+
+	if a.loopCount == -1 && deltaTime >= 0 {
+		// just started; when looping in reverse direction, the initial
+		// transition through zero counts as a repetition, so leave it at -1
+		a.loopCount = 0
+	}
+
+	if duration > 0 && ( time >= duration || time < 0 ) {
+
+		// wrap around
+
+		loopDelta := math.Floor( time / duration ) // signed
+		time -= duration * loopDelta
+
+		loopCount := a.loopCount + int( math.Abs( loopDelta ) )
+
+		if float64( loopCount ) >= a.Repetitions {
+
+			// have to stop (switch state, clamp time, fire event)
+
+			a.stopAtEnd()
+
+			if deltaTime > 0 {
+				a.Time = duration
+			} else {
+				a.Time = 0
+			}
+
+			a.mixer.finished( a, deltaTime )
+			return
+		}
+
+		// keep running
+
+		a.loopCount = loopCount
+		a.Time = time
+		a.mixer.looped( a, int( loopDelta ) )
+		return
+	}
+
+	a.Time = time
+}
+
+// stopAtEnd pauses or disables the action when it has played to its end.
+func (a *AnimationAction) stopAtEnd() {
+	if a.ClampWhenFinished {
+		a.Paused = true
+	} else {
+		a.Enabled = false
+	}
+}
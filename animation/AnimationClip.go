@@ -0,0 +1,88 @@
+package animation
+
+import (
+	"fmt"
+	"math"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**
+ * Reusable set of Tracks that represent an animation.
+ *
+ * @author Ben Houston / http://clara.io/
+ * @author David Sarno / http://lighthaus.us/
+ */
+
+// AnimationClip is a named set of tracks played together, e.g. "walk". An
+// AnimationMixer plays clips on objects through actions.
+type AnimationClip struct {
+	Name string
+	Uuid string
+	Tracks []*KeyframeTrack
+
+	// Duration is the length of the clip in seconds.
+	Duration float64
+}
+
+// NewAnimationClip returns a clip of tracks. A negative duration is set to the
+// time of the last keyframe of the tracks. Tracks that fail Validate are
+// left out, with a warning.
+func NewAnimationClip(name string, duration float64, tracks []*KeyframeTrack) (*AnimationClip) {
+	validTracks := make([]*KeyframeTrack, 0, len(tracks))
+	for _, track := range tracks {
+		if track.Validate() {
+			validTracks = append(validTracks, track)
+		} else {
+			fmt.Println("THREE.AnimationClip: Ignoring invalid track " + track.Name + " in clip " + name)
+		}
+	}
+
+	c := &AnimationClip{
+		Name: name,
+		Uuid: math3d.GenerateUUID(),
+		Tracks: validTracks,
+		Duration: duration,
+	}
+
+	// this means it should figure out its duration by scanning the tracks
+	if c.Duration < 0 {
+		c.ResetDuration()
+	}
+
+	return c
+}
+
+// ResetDuration sets Duration to the time of the last keyframe of the tracks.
+func (c *AnimationClip) ResetDuration() {
+	duration := 0.0
+
+	for _, track := range c.Tracks {
+		if len(track.Times) == 0 {
+			continue
+		}
+		duration = math.Max( duration, track.Times[ len(track.Times) - 1 ] )
+	}
+
+	c.Duration = duration
+}
+
+// Trim removes the keyframes of the tracks that lie outside the clip.
+func (c *AnimationClip) Trim() (*AnimationClip) {
+	for _, track := range c.Tracks {
+		track.Trim( 0, c.Duration )
+	}
+
+	return c
+}
+
+// FindClipByName returns the clip called name, or nil.
+func FindClipByName(clips []*AnimationClip, name string) (*AnimationClip) {
+	for _, clip := range clips {
+		if clip.Name == name {
+			return clip
+		}
+	}
+
+	return nil
+}
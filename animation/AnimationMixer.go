@@ -0,0 +1,241 @@
+package animation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uzudil/three.go/core"
+)
+
+/**
+ * Player for AnimationClips.
+ *
+ * @author Ben Houston / http://clara.io/
+ * @author David Sarno / http://lighthaus.us/
+ * @author tschw
+ */
+
+// AnimationMixer plays animation clips on the objects under Root. Call Update
+// once a frame with the time passed.
+type AnimationMixer struct {
+	Root *core.Object3D
+
+	// Time is the global time of the mixer in seconds, TimeScale scales how
+	// fast it runs.
+	Time float64
+	TimeScale float64
+
+	// OnLoop is called when an action with LoopRepeat or LoopPingPong wraps
+	// around, loopDelta times (negative when playing backwards).
+	OnLoop func(action *AnimationAction, loopDelta int)
+
+	// OnFinished is called when an action stops at the end of its clip,
+	// direction is 1 when playing forwards and -1 when playing backwards.
+	OnFinished func(action *AnimationAction, direction int)
+
+	actions []*AnimationAction
+	activeActions []*AnimationAction
+	bindings map[string]*PropertyMixer // by root uuid and track name
+}
+
+func NewAnimationMixer(root *core.Object3D) (*AnimationMixer) {
+	return &AnimationMixer{
+		Root: root,
+		TimeScale: 1,
+		actions: make([]*AnimationAction, 0),
+		activeActions: make([]*AnimationAction, 0),
+		bindings: make(map[string]*PropertyMixer),
+	}
+}
+
+// ClipAction returns the action that plays clip on optionalRoot, or on Root
+// if it is nil. The same action is returned on later calls.
+func (m *AnimationMixer) ClipAction(clip *AnimationClip, optionalRoot *core.Object3D) (*AnimationAction) {
+	root := optionalRoot
+	if root == nil {
+		root = m.Root
+	}
+
+	if action := m.ExistingAction( clip, root ); action != nil {
+		return action
+	}
+
+	action := newAnimationAction( m, clip, root )
+
+	for i, track := range clip.Tracks {
+		key := root.Uuid + ":" + track.Name
+
+		binding, ok := m.bindings[ key ]
+		if !ok {
+			propertyBinding := NewPropertyBinding( root, track.Name )
+
+			if propertyBinding.ValueSize != 0 && propertyBinding.ValueSize != track.ValueSize() {
+				fmt.Println(fmt.Sprintf("THREE.AnimationMixer: track %s has %d values per keyframe but the property takes %d, ignoring it.", track.Name, track.ValueSize(), propertyBinding.ValueSize))
+				propertyBinding.Unbind()
+			}
+
+			binding = newPropertyMixer( propertyBinding, track.ValueTypeName, track.ValueSize() )
+			m.bindings[ key ] = binding
+		}
+
+		action.interpolants[ i ] = track.CreateInterpolant( binding.incoming )
+		action.propertyMixers[ i ] = binding
+	}
+
+	m.actions = append(m.actions, action)
+
+	return action
+}
+
+// ExistingAction returns the action for clip on optionalRoot, or on Root if it
+// is nil, or nil if there is none yet.
+func (m *AnimationMixer) ExistingAction(clip *AnimationClip, optionalRoot *core.Object3D) (*AnimationAction) {
+	root := optionalRoot
+	if root == nil {
+		root = m.Root
+	}
+
+	for _, action := range m.actions {
+		if action.clip == clip && action.localRoot == root {
+			return action
+		}
+	}
+
+	return nil
+}
+
+// StopAllAction stops all actions.
+func (m *AnimationMixer) StopAllAction() (*AnimationMixer) {
+	for len(m.activeActions) > 0 {
+		m.activeActions[ len(m.activeActions) - 1 ].Stop()
+	}
+
+	return m
+}
+
+// Update advances the mixer by deltaTime seconds and animates the objects.
+func (m *AnimationMixer) Update(deltaTime float64) (*AnimationMixer) {
+	deltaTime *= m.TimeScale
+
+	time := m.Time + deltaTime
+	m.Time = time
+
+	timeDirection := 0.0
+	if deltaTime > 0 {
+		timeDirection = 1
+	} else if deltaTime < 0 {
+		timeDirection = -1
+	}
+
+	// run active actions, on a copy as callbacks may stop actions
+
+	for _, action := range append([]*AnimationAction{}, m.activeActions...) {
+		action.update( time, deltaTime, timeDirection )
+	}
+
+	// update scene graph
+
+	for _, binding := range m.bindings {
+		if binding.useCount > 0 {
+			binding.Apply()
+		}
+	}
+
+	return m
+}
+
+// UncacheClip stops and forgets all actions of clip.
+func (m *AnimationMixer) UncacheClip(clip *AnimationClip) {
+	m.uncache(func(action *AnimationAction) bool { return action.clip == clip })
+}
+
+// UncacheRoot stops and forgets all actions on root.
+func (m *AnimationMixer) UncacheRoot(root *core.Object3D) {
+	m.uncache(func(action *AnimationAction) bool { return action.localRoot == root })
+
+	prefix := root.Uuid + ":"
+	for key, binding := range m.bindings {
+		if strings.HasPrefix( key, prefix ) && binding.useCount == 0 {
+			delete(m.bindings, key)
+		}
+	}
+}
+
+// UncacheAction stops and forgets the action for clip on optionalRoot, or on
+// Root if it is nil.
+func (m *AnimationMixer) UncacheAction(clip *AnimationClip, optionalRoot *core.Object3D) {
+	if action := m.ExistingAction( clip, optionalRoot ); action != nil {
+		m.uncache(func(a *AnimationAction) bool { return a == action })
+	}
+}
+
+func (m *AnimationMixer) uncache(match func(*AnimationAction) bool) {
+	actions := m.actions[ :0 ]
+	for _, action := range m.actions {
+		if match( action ) {
+			m.deactivateAction( action )
+		} else {
+			actions = append(actions, action)
+		}
+	}
+	m.actions = actions
+}
+
+func (m *AnimationMixer) isActiveAction(action *AnimationAction) bool {
+	for _, a := range m.activeActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *AnimationMixer) activateAction(action *AnimationAction) {
+	if m.isActiveAction( action ) {
+		return
+	}
+
+	for _, binding := range action.propertyMixers {
+		if binding.useCount == 0 {
+			binding.SaveOriginalState()
+		}
+		binding.useCount ++
+	}
+
+	m.activeActions = append(m.activeActions, action)
+}
+
+func (m *AnimationMixer) deactivateAction(action *AnimationAction) {
+	for i, a := range m.activeActions {
+		if a != action {
+			continue
+		}
+
+		m.activeActions = append(m.activeActions[ :i ], m.activeActions[ i + 1: ]...)
+
+		for _, binding := range action.propertyMixers {
+			binding.useCount --
+			if binding.useCount == 0 {
+				binding.RestoreOriginalState()
+			}
+		}
+
+		return
+	}
+}
+
+func (m *AnimationMixer) looped(action *AnimationAction, loopDelta int) {
+	if m.OnLoop != nil {
+		m.OnLoop( action, loopDelta )
+	}
+}
+
+func (m *AnimationMixer) finished(action *AnimationAction, deltaTime float64) {
+	if m.OnFinished != nil {
+		direction := 1
+		if deltaTime < 0 {
+			direction = -1
+		}
+		m.OnFinished( action, direction )
+	}
+}
@@ -0,0 +1,52 @@
+package animation
+
+import (
+	"testing"
+
+	three "github.com/uzudil/three.go"
+	"github.com/uzudil/three.go/core"
+)
+
+func TestAnimationMixerPlaysClip(t *testing.T) {
+	box := core.NewObject3D()
+	box.Name = "box"
+
+	root := core.NewObject3D()
+	root.Add( box )
+
+	clip := NewAnimationClip( "move", -1, []*KeyframeTrack{
+		NewVectorKeyframeTrack( "box.position", []float64{ 0, 2 }, []float64{ 0,0,0, 2,4,6 }, 0 ),
+	} )
+
+	mixer := NewAnimationMixer( root )
+	action := mixer.ClipAction( clip, nil ).SetLoop( three.LoopOnce, 0 )
+	action.ClampWhenFinished = true
+	action.Play()
+
+	mixer.Update( 1 )
+	if box.Position.X != 1 || box.Position.Y != 2 || box.Position.Z != 3 {
+		t.Errorf("got position %v after 1s, want ( 1, 2, 3 )", box.Position)
+	}
+
+	mixer.Update( 5 )
+	if box.Position.Y != 4 {
+		t.Errorf("got position %v after the clip, want the last keyframe", box.Position)
+	}
+}
+
+func TestAnimationMixerIgnoresUnknownProperties(t *testing.T) {
+	root := core.NewObject3D()
+
+	clip := NewAnimationClip( "bad", -1, []*KeyframeTrack{
+		NewVectorKeyframeTrack( "nothing.position", []float64{ 0, 1 }, []float64{ 0,0,0, 1,1,1 }, 0 ),
+		NewNumberKeyframeTrack( "position", []float64{ 0, 1 }, []float64{ 0, 1 }, 0 ),
+	} )
+
+	mixer := NewAnimationMixer( root )
+	mixer.ClipAction( clip, nil ).Play()
+	mixer.Update( 0.5 )
+
+	if root.Position.X != 0 || root.Position.Y != 0 || root.Position.Z != 0 {
+		t.Errorf("got position %v, want it untouched", root.Position)
+	}
+}
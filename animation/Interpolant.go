@@ -0,0 +1,201 @@
+package animation
+
+import (
+	"sort"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**
+ * Abstract base class of interpolants over parametric samples.
+ *
+ * The parameter domain is one dimensional, typically the time or a path
+ * along a curve defined by the data.
+ *
+ * The sample values can have any dimensionality and derived classes may
+ * apply special interpretations to the data.
+ *
+ * @author tschw
+ */
+
+// Interpolant samples values stored at ParameterPositions, e.g. the times of
+// keyframes, ValueSize values per position. Evaluate writes the value at a
+// position into ResultBuffer. Before the first and after the last position
+// the first and last values are held.
+type Interpolant struct {
+	ParameterPositions []float64
+	SampleValues []float64
+	ValueSize int
+	ResultBuffer []float64
+
+	cachedIndex int
+
+	// interpolate writes into ResultBuffer the value at t, between the samples
+	// at i1 - 1 and i1, at positions t0 and t1.
+	interpolate func(i1 int, t0, t, t1 float64) ([]float64)
+}
+
+func newInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) (*Interpolant) {
+	if resultBuffer == nil {
+		resultBuffer = make([]float64, sampleSize)
+	}
+	return &Interpolant{
+		ParameterPositions: parameterPositions,
+		SampleValues: sampleValues,
+		ValueSize: sampleSize,
+		ResultBuffer: resultBuffer,
+		cachedIndex: 1,
+	}
+}
+
+// Evaluate returns ResultBuffer set to the value at position t.
+func (in *Interpolant) Evaluate(t float64) ([]float64) {
+	pp := in.ParameterPositions
+	n := len(pp)
+
+	if n == 0 {
+		return in.ResultBuffer
+	}
+	if t < pp[ 0 ] {
+		return in.copySampleValue( 0 )
+	}
+	if t >= pp[ n - 1 ] {
+		return in.copySampleValue( n - 1 )
+	}
+
+	// positions usually move forward a little per call, so try the interval
+	// of the last call before searching
+
+	i1 := in.cachedIndex
+	if i1 < 1 || i1 >= n || t < pp[ i1 - 1 ] || t >= pp[ i1 ] {
+		i1 = sort.Search(n, func(i int) bool { return pp[ i ] > t })
+		in.cachedIndex = i1
+	}
+
+	return in.interpolate( i1, pp[ i1 - 1 ], t, pp[ i1 ] )
+}
+
+func (in *Interpolant) copySampleValue(index int) ([]float64) {
+	stride := in.ValueSize
+	copy(in.ResultBuffer, in.SampleValues[ index * stride : ( index + 1 ) * stride ])
+	return in.ResultBuffer
+}
+
+// NewDiscreteInterpolant returns an interpolant that holds each value until
+// the next position.
+func NewDiscreteInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) (*Interpolant) {
+	in := newInterpolant(parameterPositions, sampleValues, sampleSize, resultBuffer)
+	in.interpolate = func(i1 int, t0, t, t1 float64) ([]float64) {
+		return in.copySampleValue( i1 - 1 )
+	}
+	return in
+}
+
+func NewLinearInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) (*Interpolant) {
+	in := newInterpolant(parameterPositions, sampleValues, sampleSize, resultBuffer)
+	in.interpolate = func(i1 int, t0, t, t1 float64) ([]float64) {
+		result := in.ResultBuffer
+		values := in.SampleValues
+		stride := in.ValueSize
+
+		offset1 := i1 * stride
+		offset0 := offset1 - stride
+
+		weight1 := ( t - t0 ) / ( t1 - t0 )
+		weight0 := 1 - weight1
+
+		for i := 0; i < stride; i ++ {
+			result[ i ] = values[ offset0 + i ] * weight0 + values[ offset1 + i ] * weight1
+		}
+
+		return result
+	}
+	return in
+}
+
+// NewQuaternionLinearInterpolant returns an interpolant that slerps between
+// quaternions stored as x, y, z, w.
+func NewQuaternionLinearInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) (*Interpolant) {
+	in := newInterpolant(parameterPositions, sampleValues, sampleSize, resultBuffer)
+	in.interpolate = func(i1 int, t0, t, t1 float64) ([]float64) {
+		result := in.ResultBuffer
+		values := in.SampleValues
+		stride := in.ValueSize
+
+		offset1 := i1 * stride
+		offset0 := offset1 - stride
+
+		alpha := ( t - t0 ) / ( t1 - t0 )
+
+		for i := 0; i < stride; i += 4 {
+			math3d.SlerpFlat( result, i, values, offset0 + i, values, offset1 + i, alpha )
+		}
+
+		return result
+	}
+	return in
+}
+
+// NewCubicInterpolant returns an interpolant along a smooth curve through the
+// values, with the tangent at each position set by its neighbours. The curve
+// has zero curvature at the first and last positions.
+func NewCubicInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) (*Interpolant) {
+	in := newInterpolant(parameterPositions, sampleValues, sampleSize, resultBuffer)
+	in.interpolate = func(i1 int, t0, t, t1 float64) ([]float64) {
+		result := in.ResultBuffer
+		values := in.SampleValues
+		stride := in.ValueSize
+		pp := in.ParameterPositions
+
+		// the neighbours of the interval, mirrored at the ends
+
+		iPrev := i1 - 2
+		iNext := i1 + 1
+
+		var tPrev, tNext float64
+
+		if iPrev >= 0 {
+			tPrev = pp[ iPrev ]
+		} else {
+			iPrev = i1
+			tPrev = t1
+		}
+
+		if iNext < len(pp) {
+			tNext = pp[ iNext ]
+		} else {
+			iNext = i1 - 1
+			tNext = t0
+		}
+
+		halfDt := ( t1 - t0 ) * 0.5
+
+		wP := halfDt / ( t0 - tPrev )
+		wN := halfDt / ( tNext - t1 )
+
+		o1 := i1 * stride
+		o0 := o1 - stride
+		oP := iPrev * stride
+		oN := iNext * stride
+
+		p := ( t - t0 ) / ( t1 - t0 )
+		pp2 := p * p
+		ppp := pp2 * p
+
+		// evaluate polynomials
+
+		sP := - wP * ppp + 2 * wP * pp2 - wP * p
+		s0 := ( 1 + wP ) * ppp + ( -1.5 - 2 * wP ) * pp2 + ( -0.5 + wP ) * p + 1
+		s1 := ( -1 - wN ) * ppp + ( 1.5 + wN ) * pp2 + 0.5 * p
+		sN := wN * ppp - wN * pp2
+
+		// combine data linearly
+
+		for i := 0; i < stride; i ++ {
+			result[ i ] = sP * values[ oP + i ] + s0 * values[ o0 + i ] + s1 * values[ o1 + i ] + sN * values[ oN + i ]
+		}
+
+		return result
+	}
+	return in
+}
@@ -0,0 +1,68 @@
+package animation
+
+import (
+	"math"
+	"testing"
+)
+
+func closeTo(a, b float64) bool {
+	return math.Abs( a - b ) < 1e-9
+}
+
+func TestLinearInterpolant(t *testing.T) {
+	interpolant := NewLinearInterpolant( []float64{ 0, 1, 3 }, []float64{ 0,10, 2,20, 6,40 }, 2, nil )
+
+	cases := []struct {
+		t float64
+		want []float64
+	}{
+		{ -1, []float64{ 0, 10 } },
+		{ 0.5, []float64{ 1, 15 } },
+		{ 2, []float64{ 4, 30 } },
+		{ 1, []float64{ 2, 20 } },
+		{ 5, []float64{ 6, 40 } },
+	}
+	for _, c := range cases {
+		got := interpolant.Evaluate( c.t )
+		if !closeTo( got[ 0 ], c.want[ 0 ] ) || !closeTo( got[ 1 ], c.want[ 1 ] ) {
+			t.Errorf("at %v got %v, want %v", c.t, got, c.want)
+		}
+	}
+}
+
+func TestDiscreteInterpolant(t *testing.T) {
+	interpolant := NewDiscreteInterpolant( []float64{ 0, 1, 2 }, []float64{ 5, 6, 7 }, 1, nil )
+
+	for _, c := range [][2]float64{ { 0, 5 }, { 0.9, 5 }, { 1, 6 }, { 1.5, 6 }, { 2, 7 } } {
+		if got := interpolant.Evaluate( c[ 0 ] )[ 0 ]; got != c[ 1 ] {
+			t.Errorf("at %v got %v, want %v", c[ 0 ], got, c[ 1 ])
+		}
+	}
+}
+
+func TestQuaternionLinearInterpolant(t *testing.T) {
+	// identity to a quarter turn around z
+	s := math.Sqrt( 0.5 )
+	interpolant := NewQuaternionLinearInterpolant( []float64{ 0, 1 }, []float64{ 0,0,0,1, 0,0,s,s }, 4, nil )
+
+	got := interpolant.Evaluate( 0.5 )
+	want := []float64{ 0, 0, math.Sin( math.Pi / 8 ), math.Cos( math.Pi / 8 ) }
+	for i := range want {
+		if !closeTo( got[ i ], want[ i ] ) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCubicInterpolantPassesThroughKeyframes(t *testing.T) {
+	interpolant := NewCubicInterpolant( []float64{ 0, 1, 2, 3 }, []float64{ 0, 1, 4, 9 }, 1, nil )
+
+	for i, want := range []float64{ 0, 1, 4, 9 } {
+		if got := interpolant.Evaluate( float64( i ) )[ 0 ]; !closeTo( got, want ) {
+			t.Errorf("at %d got %v, want %v", i, got, want)
+		}
+	}
+	if got := interpolant.Evaluate( 1.5 )[ 0 ]; got <= 1 || got >= 4 {
+		t.Errorf("at 1.5 got %v, want between 1 and 4", got)
+	}
+}
@@ -0,0 +1,231 @@
+package animation
+
+import (
+	"fmt"
+	"math"
+
+	three "github.com/uzudil/three.go"
+)
+
+/**
+ * A timed sequence of keyframes for a specific property.
+ *
+ * @author Ben Houston / http://clara.io/
+ * @author David Sarno / http://lighthaus.us/
+ * @author tschw
+ */
+
+// KeyframeTrack is a sequence of values of one property over time. Name is
+// the path of the property, e.g. "arm.quaternion", see PropertyBinding.
+// Values holds ValueSize() numbers per time in Times, which must increase.
+type KeyframeTrack struct {
+	Name string
+	Times []float64
+	Values []float64
+
+	// ValueTypeName is the kind of value the track holds: "number",
+	// "vector", "color", "quaternion" or "bool". It decides how values are
+	// interpolated and blended.
+	ValueTypeName string
+
+	Interpolation int
+	DefaultInterpolation int
+}
+
+func newKeyframeTrack(name string, times, values []float64, valueTypeName string, defaultInterpolation, interpolation int) (*KeyframeTrack) {
+	t := &KeyframeTrack{
+		Name: name,
+		Times: times,
+		Values: values,
+		ValueTypeName: valueTypeName,
+		Interpolation: defaultInterpolation,
+		DefaultInterpolation: defaultInterpolation,
+	}
+
+	if interpolation != 0 {
+		t.SetInterpolation( interpolation )
+	}
+
+	return t
+}
+
+// NewNumberKeyframeTrack returns a track of single numbers. interpolation is
+// one of three.InterpolateDiscrete, InterpolateLinear or InterpolateSmooth, or
+// 0 for linear.
+func NewNumberKeyframeTrack(name string, times, values []float64, interpolation int) (*KeyframeTrack) {
+	return newKeyframeTrack(name, times, values, "number", three.InterpolateLinear, interpolation)
+}
+
+// NewVectorKeyframeTrack returns a track of vectors, stored x, y(, z, w).
+func NewVectorKeyframeTrack(name string, times, values []float64, interpolation int) (*KeyframeTrack) {
+	return newKeyframeTrack(name, times, values, "vector", three.InterpolateLinear, interpolation)
+}
+
+// NewColorKeyframeTrack returns a track of colors, stored r, g, b.
+func NewColorKeyframeTrack(name string, times, values []float64, interpolation int) (*KeyframeTrack) {
+	return newKeyframeTrack(name, times, values, "color", three.InterpolateLinear, interpolation)
+}
+
+// NewQuaternionKeyframeTrack returns a track of rotations, stored x, y, z, w
+// and slerped between. Smooth interpolation is not supported.
+func NewQuaternionKeyframeTrack(name string, times, values []float64, interpolation int) (*KeyframeTrack) {
+	return newKeyframeTrack(name, times, values, "quaternion", three.InterpolateLinear, interpolation)
+}
+
+// NewBooleanKeyframeTrack returns a track of flags, which only change at keyframes.
+func NewBooleanKeyframeTrack(name string, times []float64, values []bool) (*KeyframeTrack) {
+	numbers := make([]float64, len(values))
+	for i, value := range values {
+		if value {
+			numbers[ i ] = 1
+		}
+	}
+	return newKeyframeTrack(name, times, numbers, "bool", three.InterpolateDiscrete, 0)
+}
+
+// SetInterpolation sets how values are interpolated between keyframes, if the
+// track type supports it.
+func (t *KeyframeTrack) SetInterpolation(interpolation int) (*KeyframeTrack) {
+	supported := false
+
+	switch interpolation {
+	case three.InterpolateDiscrete:
+		supported = true
+	case three.InterpolateLinear:
+		supported = t.ValueTypeName != "bool"
+	case three.InterpolateSmooth:
+		supported = t.ValueTypeName != "bool" && t.ValueTypeName != "quaternion"
+	}
+
+	if !supported {
+		fmt.Println(fmt.Sprintf("THREE.KeyframeTrack: unsupported interpolation %d for %s keyframe track named %s",
+			interpolation, t.ValueTypeName, t.Name))
+		return t
+	}
+
+	t.Interpolation = interpolation
+	return t
+}
+
+// ValueSize returns the number of values per keyframe.
+func (t *KeyframeTrack) ValueSize() int {
+	if len(t.Times) == 0 {
+		return 0
+	}
+	return len(t.Values) / len(t.Times)
+}
+
+// CreateInterpolant returns an interpolant over the keyframes that writes
+// into result, or into a buffer of its own if result is nil.
+func (t *KeyframeTrack) CreateInterpolant(result []float64) (*Interpolant) {
+	switch t.Interpolation {
+	case three.InterpolateDiscrete:
+		return NewDiscreteInterpolant( t.Times, t.Values, t.ValueSize(), result )
+	case three.InterpolateSmooth:
+		return NewCubicInterpolant( t.Times, t.Values, t.ValueSize(), result )
+	}
+
+	if t.ValueTypeName == "quaternion" {
+		return NewQuaternionLinearInterpolant( t.Times, t.Values, t.ValueSize(), result )
+	}
+	return NewLinearInterpolant( t.Times, t.Values, t.ValueSize(), result )
+}
+
+// Shift moves all keyframes in time by timeOffset.
+func (t *KeyframeTrack) Shift(timeOffset float64) (*KeyframeTrack) {
+	if timeOffset != 0 {
+		for i := range t.Times {
+			t.Times[ i ] += timeOffset
+		}
+	}
+	return t
+}
+
+// Scale scales all keyframe times by timeScale.
+func (t *KeyframeTrack) Scale(timeScale float64) (*KeyframeTrack) {
+	if timeScale != 1 {
+		for i := range t.Times {
+			t.Times[ i ] *= timeScale
+		}
+	}
+	return t
+}
+
+// Trim removes keyframes before startTime and after endTime, keeping at least
+// one keyframe.
+func (t *KeyframeTrack) Trim(startTime, endTime float64) (*KeyframeTrack) {
+	nKeys := len(t.Times)
+
+	from := 0
+	to := nKeys - 1
+
+	for from != nKeys && t.Times[ from ] < startTime {
+		from ++
+	}
+
+	for to != -1 && t.Times[ to ] > endTime {
+		to --
+	}
+
+	to ++ // inclusive -> exclusive bound
+
+	if from != 0 || to != nKeys {
+		// empty tracks are forbidden, so keep at least one keyframe
+		if from >= to {
+			if to < 1 {
+				to = 1
+			}
+			from = to - 1
+		}
+
+		stride := t.ValueSize()
+		t.Times = t.Times[ from : to ]
+		t.Values = t.Values[ from * stride : to * stride ]
+	}
+
+	return t
+}
+
+// Validate reports problems that would make the track play wrongly, such as
+// having no keyframes. NewAnimationClip leaves out tracks that fail it.
+func (t *KeyframeTrack) Validate() bool {
+	if len(t.Times) == 0 {
+		fmt.Println("THREE.KeyframeTrack: No keyframes in track named " + t.Name)
+		return false
+	}
+
+	valid := true
+
+	if len(t.Values) % len(t.Times) != 0 {
+		fmt.Println("THREE.KeyframeTrack: Invalid value size in track named " + t.Name)
+		valid = false
+	}
+
+	prevTime := math.Inf( -1 )
+
+	for i, currTime := range t.Times {
+		if math.IsNaN( currTime ) {
+			fmt.Println(fmt.Sprintf("THREE.KeyframeTrack: Time is not a valid number in track named %s at %d", t.Name, i))
+			valid = false
+			break
+		}
+
+		if currTime < prevTime {
+			fmt.Println(fmt.Sprintf("THREE.KeyframeTrack: Out of order keys in track named %s at %d", t.Name, i))
+			valid = false
+			break
+		}
+
+		prevTime = currTime
+	}
+
+	for i, value := range t.Values {
+		if math.IsNaN( value ) {
+			fmt.Println(fmt.Sprintf("THREE.KeyframeTrack: Value is not a valid number in track named %s at %d", t.Name, i))
+			valid = false
+			break
+		}
+	}
+
+	return valid
+}
@@ -0,0 +1,54 @@
+package animation
+
+import (
+	"testing"
+)
+
+func TestKeyframeTrackValidate(t *testing.T) {
+	tracks := map[string]*KeyframeTrack{
+		"no keyframes": NewNumberKeyframeTrack( "empty", []float64{}, []float64{}, 0 ),
+		"out of order": NewNumberKeyframeTrack( "unordered", []float64{ 0, 2, 1 }, []float64{ 0, 1, 2 }, 0 ),
+		"value size": NewVectorKeyframeTrack( "short", []float64{ 0, 1 }, []float64{ 0, 0, 0, 1, 1 }, 0 ),
+	}
+	for name, track := range tracks {
+		if track.Validate() {
+			t.Errorf("%s: valid", name)
+		}
+	}
+
+	if !NewVectorKeyframeTrack( "position", []float64{ 0, 1 }, []float64{ 0, 0, 0, 1, 1, 1 }, 0 ).Validate() {
+		t.Error("valid track is invalid")
+	}
+}
+
+func TestAnimationClipIgnoresInvalidTracks(t *testing.T) {
+	clip := NewAnimationClip( "clip", -1, []*KeyframeTrack{
+		NewNumberKeyframeTrack( "empty", []float64{}, []float64{}, 0 ),
+		NewNumberKeyframeTrack( "opacity", []float64{ 0, 2 }, []float64{ 1, 0 }, 0 ),
+	} )
+
+	if len(clip.Tracks) != 1 || clip.Tracks[ 0 ].Name != "opacity" {
+		t.Fatalf("got %d tracks, want only opacity", len(clip.Tracks))
+	}
+	if clip.Duration != 2 {
+		t.Errorf("got duration %v, want 2", clip.Duration)
+	}
+}
+
+func TestKeyframeTrackTrim(t *testing.T) {
+	track := NewVectorKeyframeTrack( "position", []float64{ 0, 1, 2, 3 }, []float64{ 0,0,0, 1,1,1, 2,2,2, 3,3,3 }, 0 )
+	track.Trim( 0.5, 2.5 )
+
+	if len(track.Times) != 2 || track.Times[ 0 ] != 1 || track.Times[ 1 ] != 2 {
+		t.Errorf("got times %v, want [ 1 2 ]", track.Times)
+	}
+	if len(track.Values) != 6 || track.Values[ 0 ] != 1 {
+		t.Errorf("got values %v, want [ 1 1 1 2 2 2 ]", track.Values)
+	}
+
+	// a range without keyframes keeps one
+	track.Trim( 10, 20 )
+	if len(track.Times) != 1 {
+		t.Errorf("got %d keyframes, want 1", len(track.Times))
+	}
+}
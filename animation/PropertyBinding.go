@@ -0,0 +1,393 @@
+package animation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/objects"
+)
+
+/**
+ * A reference to a real property in the scene graph.
+ *
+ * @author Ben Houston / http://clara.io/
+ * @author David Sarno / http://lighthaus.us/
+ * @author tschw
+ */
+
+// PropertyBinding reads and writes, as flat numbers, a property in the scene
+// graph under RootNode given by a path, see ParseTrackName. The property is
+// looked up once, in Bind; GetValue and SetValue then go straight to it.
+type PropertyBinding struct {
+	Path string
+	ParsedPath *ParsedPath
+	RootNode *core.Object3D
+	Node *core.Object3D
+
+	// ValueSize is the number of values the property takes, 0 while unbound.
+	ValueSize int
+
+	// GetValue writes the current value of the property into buffer at offset.
+	GetValue func(buffer []float64, offset int)
+	// SetValue sets the property to the value in buffer at offset.
+	SetValue func(buffer []float64, offset int)
+}
+
+// ParsedPath is a property path split into its parts. "body.material[0].color"
+// has NodeName "body", ObjectName "material", ObjectIndex "0" and PropertyName
+// "color"; "face.morphTargetInfluences[smile]" has NodeName "face",
+// PropertyName "morphTargetInfluences" and PropertyIndex "smile".
+type ParsedPath struct {
+	NodeName string
+	ObjectName string
+	ObjectIndex string
+	PropertyName string
+	PropertyIndex string
+}
+
+var trackNameRe = regexp.MustCompile( `^(?:([\w\-]*)\.)?(?:(\w+)(?:\[([^\]]+)\])?\.)?(\w+)(?:\[([^\]]+)\])?$` )
+
+// ParseTrackName splits a path of the form
+// "nodeName.objectName[objectIndex].propertyName[propertyIndex]" into its
+// parts. Everything but the property name is optional; a path without a node
+// name, e.g. ".material.opacity", is resolved on the root node.
+func ParseTrackName(trackName string) (*ParsedPath, error) {
+	matches := trackNameRe.FindStringSubmatch( trackName )
+
+	if matches == nil {
+		return nil, errors.New("THREE.PropertyBinding: cannot parse trackName at all: " + trackName)
+	}
+
+	return &ParsedPath{
+		NodeName: matches[ 1 ],
+		ObjectName: matches[ 2 ],
+		ObjectIndex: matches[ 3 ],
+		PropertyName: matches[ 4 ],
+		PropertyIndex: matches[ 5 ],
+	}, nil
+}
+
+func NewPropertyBinding(rootNode *core.Object3D, path string) (*PropertyBinding) {
+	b := &PropertyBinding{
+		Path: path,
+		RootNode: rootNode,
+	}
+	b.Bind()
+	return b
+}
+
+// Bind looks up the property again, e.g. after the scene graph has changed.
+func (b *PropertyBinding) Bind() {
+	b.Unbind()
+
+	parsedPath, err := ParseTrackName( b.Path )
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	b.ParsedPath = parsedPath
+
+	b.Node = findNode( b.RootNode, parsedPath.NodeName )
+
+	if b.Node == nil {
+		fmt.Println("THREE.PropertyBinding: Trying to update node for track: " + b.Path + " but it wasn't found.")
+		return
+	}
+
+	var target interface{} = b.Node.Self
+
+	if parsedPath.ObjectName != "" {
+		target = resolveObject( b.Node, parsedPath.ObjectName, parsedPath.ObjectIndex )
+
+		if target == nil {
+			fmt.Println("THREE.PropertyBinding: Can not bind to objectName of node, it was not found: " + b.Path)
+			return
+		}
+	}
+
+	getValue, setValue, valueSize := resolveProperty( target, parsedPath.PropertyName, parsedPath.PropertyIndex )
+
+	if getValue == nil {
+		fmt.Println("THREE.PropertyBinding: Trying to update property for track: " + b.Path + " but it wasn't found.")
+		return
+	}
+
+	b.GetValue = getValue
+	b.SetValue = setValue
+	b.ValueSize = valueSize
+}
+
+// Unbind makes reads and writes go nowhere until the next Bind.
+func (b *PropertyBinding) Unbind() {
+	b.Node = nil
+	b.ValueSize = 0
+	b.GetValue = func(buffer []float64, offset int) {}
+	b.SetValue = func(buffer []float64, offset int) {}
+}
+
+// findNode returns root if nodeName is empty or names root, otherwise the
+// first descendant of root called nodeName, or nil.
+func findNode(root *core.Object3D, nodeName string) (*core.Object3D) {
+	if nodeName == "" || root.Name == nodeName || root.Uuid == nodeName {
+		return root
+	}
+
+	for _, child := range root.Children {
+		if node := findNode( child, nodeName ); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// resolveObject returns the object of node called objectName: the material,
+// the one at objectIndex of a MultiMaterial, a bone of a SkinnedMesh by name
+// or index, or any other struct field of node.
+func resolveObject(node *core.Object3D, objectName, objectIndex string) (interface{}) {
+	var field reflect.Value
+
+	if objectName == "bones" {
+		mesh, ok := node.Self.(*objects.SkinnedMesh)
+		if !ok || objectIndex == "" {
+			return nil
+		}
+		field = reflect.ValueOf( mesh.Skeleton.Bones )
+	} else {
+		if objectName == "materials" {
+			objectName = "material"
+		}
+		field = fieldByName( node.Self, objectName )
+		if !field.IsValid() {
+			return nil
+		}
+	}
+
+	if objectIndex != "" && field.Kind() == reflect.Slice {
+		field = sliceElement( field, objectIndex )
+		if !field.IsValid() {
+			return nil
+		}
+	}
+
+	object := field.Interface()
+
+	if material, ok := object.(*materials.Material); ok {
+		if material == nil {
+			return nil
+		}
+
+		if objectIndex != "" {
+			multi, ok := material.Self.(*materials.MultiMaterial)
+			if !ok {
+				return nil
+			}
+			index, err := strconv.Atoi( objectIndex )
+			if err != nil {
+				return nil
+			}
+			material = multi.GetMaterial( index )
+			if material == nil {
+				return nil
+			}
+		}
+
+		// bind to the concrete material, e.g. for its color
+		return material.Self
+	}
+
+	if bone, ok := object.(*objects.Bone); ok && bone != nil {
+		return bone.Self
+	}
+
+	return object
+}
+
+// sliceElement returns the element of slice at index, which is either a
+// number or the Name of an element.
+func sliceElement(slice reflect.Value, index string) (reflect.Value) {
+	if i, err := strconv.Atoi( index ); err == nil {
+		if i < 0 || i >= slice.Len() {
+			return reflect.Value{}
+		}
+		return slice.Index( i )
+	}
+
+	for i := 0; i < slice.Len(); i ++ {
+		if name := fieldByName( slice.Index( i ).Interface(), "name" ); name.IsValid() && name.Kind() == reflect.String && name.String() == index {
+			return slice.Index( i )
+		}
+	}
+
+	return reflect.Value{}
+}
+
+// fieldByName returns the field of the struct object points to, or of the
+// structs it embeds, for a property name as written in paths, e.g. "opacity"
+// for the Opacity field.
+func fieldByName(object interface{}, name string) (reflect.Value) {
+	v := reflect.ValueOf( object )
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct || name == "" {
+		return reflect.Value{}
+	}
+
+	return v.FieldByName( strings.ToUpper( name[ :1 ] ) + name[ 1: ] )
+}
+
+// resolveProperty returns accessors for property propertyName of target, or
+// for its component propertyIndex, and the number of values they take. The
+// accessors are nil if there is no such property.
+func resolveProperty(target interface{}, propertyName, propertyIndex string) (func([]float64, int), func([]float64, int), int) {
+	field := fieldByName( target, propertyName )
+
+	if !field.IsValid() || !field.CanAddr() {
+		return nil, nil, 0
+	}
+
+	var getValue, setValue func([]float64, int)
+	var valueSize int
+
+	switch p := field.Addr().Interface().(type) {
+
+	case *float64:
+		getValue = func(buffer []float64, offset int) { buffer[ offset ] = *p }
+		setValue = func(buffer []float64, offset int) { *p = buffer[ offset ] }
+		valueSize = 1
+
+	case *int:
+		getValue = func(buffer []float64, offset int) { buffer[ offset ] = float64( *p ) }
+		setValue = func(buffer []float64, offset int) { *p = int( buffer[ offset ] ) }
+		valueSize = 1
+
+	case *bool:
+		getValue = func(buffer []float64, offset int) {
+			buffer[ offset ] = 0
+			if *p {
+				buffer[ offset ] = 1
+			}
+		}
+		setValue = func(buffer []float64, offset int) { *p = buffer[ offset ] >= 0.5 }
+		valueSize = 1
+
+	case *[]float64:
+		// e.g. morphTargetInfluences, all of them or the one at propertyIndex
+
+		if propertyIndex == "" {
+			getValue = func(buffer []float64, offset int) { copy(buffer[ offset : offset + len(*p) ], *p) }
+			setValue = func(buffer []float64, offset int) { copy(*p, buffer[ offset : offset + len(*p) ]) }
+			return getValue, setValue, len(*p)
+		}
+
+		index, err := strconv.Atoi( propertyIndex )
+		if err != nil {
+			// a morph target name
+			dictionary := fieldByName( target, "morphTargetDictionary" )
+			if !dictionary.IsValid() || dictionary.Kind() != reflect.Map {
+				return nil, nil, 0
+			}
+			names, _ := dictionary.Interface().(map[string]int)
+			var ok bool
+			if index, ok = names[ propertyIndex ]; !ok {
+				return nil, nil, 0
+			}
+		}
+
+		if index < 0 || index >= len(*p) {
+			return nil, nil, 0
+		}
+
+		getValue = func(buffer []float64, offset int) { buffer[ offset ] = ( *p )[ index ] }
+		setValue = func(buffer []float64, offset int) { ( *p )[ index ] = buffer[ offset ] }
+		return getValue, setValue, 1
+
+	case **math3d.Vector2:
+		getValue = func(buffer []float64, offset int) { ( *p ).ToArray( buffer, offset ) }
+		setValue = func(buffer []float64, offset int) { ( *p ).FromArray( buffer, offset ) }
+		valueSize = 2
+
+	case **math3d.Vector3:
+		getValue = func(buffer []float64, offset int) { ( *p ).ToArray( buffer, offset ) }
+		setValue = func(buffer []float64, offset int) { ( *p ).FromArray( buffer, offset ) }
+		valueSize = 3
+
+	case **math3d.Vector4:
+		getValue = func(buffer []float64, offset int) { ( *p ).ToArray( buffer, offset ) }
+		setValue = func(buffer []float64, offset int) { ( *p ).FromArray( buffer, offset ) }
+		valueSize = 4
+
+	case **math3d.Quaternion:
+		getValue = func(buffer []float64, offset int) { ( *p ).ToArray( buffer, offset ) }
+		setValue = func(buffer []float64, offset int) { ( *p ).FromArray( buffer, offset ) }
+		valueSize = 4
+
+	case **math3d.Euler:
+		getValue = func(buffer []float64, offset int) {
+			buffer[ offset ] = ( *p ).X
+			buffer[ offset + 1 ] = ( *p ).Y
+			buffer[ offset + 2 ] = ( *p ).Z
+		}
+		setValue = func(buffer []float64, offset int) {
+			( *p ).Set( buffer[ offset ], buffer[ offset + 1 ], buffer[ offset + 2 ], ( *p ).Order )
+		}
+		valueSize = 3
+
+	case **math3d.Color:
+		getValue = func(buffer []float64, offset int) { ( *p ).ToArray( buffer, offset ) }
+		setValue = func(buffer []float64, offset int) { ( *p ).FromArray( buffer, offset ) }
+		valueSize = 3
+
+	default:
+		return nil, nil, 0
+	}
+
+	if propertyIndex == "" {
+		return getValue, setValue, valueSize
+	}
+
+	// a single component, e.g. position[x]
+
+	component := strings.Index( "xyzw", propertyIndex )
+	if len(propertyIndex) != 1 || component == -1 {
+		component = strings.Index( "rgb", propertyIndex )
+	}
+	if len(propertyIndex) != 1 || component == -1 {
+		if index, err := strconv.Atoi( propertyIndex ); err == nil {
+			component = index
+		}
+	}
+
+	if component < 0 || component >= valueSize {
+		return nil, nil, 0
+	}
+
+	getWhole := getValue
+	setWhole := setValue
+	whole := make([]float64, valueSize)
+
+	getValue = func(buffer []float64, offset int) {
+		getWhole( whole, 0 )
+		buffer[ offset ] = whole[ component ]
+	}
+	setValue = func(buffer []float64, offset int) {
+		getWhole( whole, 0 )
+		whole[ component ] = buffer[ offset ]
+		setWhole( whole, 0 )
+	}
+
+	return getValue, setValue, 1
+}
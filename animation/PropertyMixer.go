@@ -0,0 +1,111 @@
+package animation
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**
+ * Buffered scene graph property that allows weighted accumulation.
+ *
+ * @author Ben Houston / http://clara.io/
+ * @author David Sarno / http://lighthaus.us/
+ * @author tschw
+ */
+
+// PropertyMixer blends the values that the running actions give one property,
+// by the actions' weights, and writes the result through Binding. Where the
+// weights add up to less than 1, the value the property had before it was
+// animated makes up the rest.
+type PropertyMixer struct {
+	Binding *PropertyBinding
+	ValueSize int
+
+	incoming []float64 // the interpolants of all actions write here
+	accu []float64
+	original []float64
+
+	cumulativeWeight float64
+	useCount int // actions playing that use this property
+
+	// mix blends src into dst by t, from 0 (keep dst) to 1 (copy src).
+	mix func(dst, src []float64, t float64)
+}
+
+func newPropertyMixer(binding *PropertyBinding, typeName string, valueSize int) (*PropertyMixer) {
+	p := &PropertyMixer{
+		Binding: binding,
+		ValueSize: valueSize,
+		incoming: make([]float64, valueSize),
+		accu: make([]float64, valueSize),
+		original: make([]float64, valueSize),
+	}
+
+	switch typeName {
+	case "quaternion":
+		p.mix = slerpMix
+	case "bool":
+		p.mix = selectMix
+	default:
+		p.mix = lerpMix
+	}
+
+	return p
+}
+
+// Accumulate blends the value in the incoming buffer into the result with weight.
+func (p *PropertyMixer) Accumulate(weight float64) {
+	if p.cumulativeWeight == 0 {
+		// accu is empty: just copy
+		copy(p.accu, p.incoming)
+		p.cumulativeWeight = weight
+	} else {
+		// accu is not empty: mix with the weight relative to what is there
+		p.cumulativeWeight += weight
+		p.mix( p.accu, p.incoming, weight / p.cumulativeWeight )
+	}
+}
+
+// Apply writes the blended value to the property and starts a new blend.
+func (p *PropertyMixer) Apply() {
+	weight := p.cumulativeWeight
+	p.cumulativeWeight = 0
+
+	if weight < 1 {
+		// accu doesn't sum up to 1, fill it up with the original value
+		p.mix( p.accu, p.original, 1 - weight )
+	}
+
+	p.Binding.SetValue( p.accu, 0 )
+}
+
+// SaveOriginalState remembers the current value of the property, to blend in
+// and to restore when no action animates it anymore.
+func (p *PropertyMixer) SaveOriginalState() {
+	p.Binding.GetValue( p.original, 0 )
+	copy(p.accu, p.original)
+	p.cumulativeWeight = 0
+}
+
+// RestoreOriginalState sets the property back to the saved value.
+func (p *PropertyMixer) RestoreOriginalState() {
+	p.Binding.SetValue( p.original, 0 )
+}
+
+func selectMix(dst, src []float64, t float64) {
+	if t >= 0.5 {
+		copy(dst, src)
+	}
+}
+
+func lerpMix(dst, src []float64, t float64) {
+	s := 1 - t
+	for i := range dst {
+		dst[ i ] = dst[ i ] * s + src[ i ] * t
+	}
+}
+
+func slerpMix(dst, src []float64, t float64) {
+	for i := 0; i + 4 <= len(dst); i += 4 {
+		math3d.SlerpFlat( dst, i, dst, i, src, i, t )
+	}
+}
@@ -2,6 +2,7 @@ package cameras
 import (
 	math3d "github.com/uzudil/three.go/math"
 	"math"
+	"github.com/uzudil/three.go/core"
 )
 
 
@@ -23,6 +24,7 @@ func NewPerspectiveCamera( fov, aspect, near, far float64 ) (*PerspectiveCamera)
 	}
 
 	p.Type = "PerspectiveCamera"
+	p.Self = p
 
 	p.Fov = fov
 	p.Aspect = aspect
@@ -31,6 +33,8 @@ func NewPerspectiveCamera( fov, aspect, near, far float64 ) (*PerspectiveCamera)
 
 	p.UpdateProjectionMatrix()
 
+	p.ToJSONObject = p.toJSONObject
+
 	return p
 }
 
@@ -139,18 +143,14 @@ func (p *PerspectiveCamera) Copy(source *PerspectiveCamera) (*PerspectiveCamera)
 	return p
 }
 
-/*
-THREE.PerspectiveCamera.prototype.toJSON = function ( meta ) {
-
-	var data = THREE.Object3D.prototype.toJSON.call( this, meta );
+func (p *PerspectiveCamera) toJSONObject(meta *core.JSONMeta) (map[string]interface{}) {
+	object := p.Object3D.BaseToJSONObject( meta )
 
-	data.object.zoom = p.zoom;
-	data.object.fov = p.fov;
-	data.object.aspect = p.aspect;
-	data.object.near = p.near;
-	data.object.far = p.far;
+	object["zoom"] = p.Zoom
+	object["fov"] = p.Fov
+	object["aspect"] = p.Aspect
+	object["near"] = p.Near
+	object["far"] = p.Far
 
-	return data;
-
-};
-*/
\ No newline at end of file
+	return object
+}
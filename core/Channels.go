@@ -23,3 +23,8 @@ func (channels *Channels) Toggle(channel int) {
 func (channels *Channels) Disable(channel int) {
 	channels.mask &= ^(1 << channel)
 }
+
+// Test reports whether channels and other have an enabled channel in common.
+func (channels *Channels) Test(other *Channels) bool {
+	return ( channels.mask & other.mask ) != 0
+}
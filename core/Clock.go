@@ -0,0 +1,65 @@
+package core
+
+import (
+	"time"
+)
+
+/**
+ * @author alteredq / http://alteredqualia.com/
+ */
+
+// Clock measures the time between frames, e.g. to advance an AnimationMixer
+// or a tween Group by GetDelta each frame. Times are in seconds.
+type Clock struct {
+	// AutoStart starts the clock on the first call to GetDelta.
+	AutoStart bool
+
+	startTime time.Time
+	oldTime time.Time
+	ElapsedTime float64
+
+	Running bool
+}
+
+func NewClock(autoStart bool) (*Clock) {
+	return &Clock{
+		AutoStart: autoStart,
+	}
+}
+
+func (c *Clock) Start() {
+	c.startTime = time.Now()
+	c.oldTime = c.startTime
+	c.Running = true
+}
+
+func (c *Clock) Stop() {
+	c.GetElapsedTime()
+	c.Running = false
+}
+
+// GetElapsedTime returns the seconds the clock has been running.
+func (c *Clock) GetElapsedTime() float64 {
+	c.GetDelta()
+	return c.ElapsedTime
+}
+
+// GetDelta returns the seconds since the last call to GetDelta.
+func (c *Clock) GetDelta() float64 {
+	diff := 0.0
+
+	if c.AutoStart && !c.Running {
+		c.Start()
+	}
+
+	if c.Running {
+		newTime := time.Now()
+
+		diff = newTime.Sub( c.oldTime ).Seconds()
+		c.oldTime = newTime
+
+		c.ElapsedTime += diff
+	}
+
+	return diff
+}
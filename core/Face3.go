@@ -41,7 +41,7 @@ func NewArraysFace3(a, b, c int, normals []*math.Vector3, colors []*math.Color,
 }
 
 func (f *Face3) Clone() (*Face3) {
-	return (&Face3{}).Copy(f)
+	return NewDefaultFace3( 0, 0, 0 ).Copy(f)
 }
 
 func (f *Face3) Copy(source *Face3) (*Face3) {
@@ -54,15 +54,14 @@ func (f *Face3) Copy(source *Face3) (*Face3) {
 
 	f.MaterialIndex = source.MaterialIndex
 
-	// todo: this won't work if source/target have different length VertexNormals or VertexColors
-	il := len(source.VertexNormals)
-	for i := 0; i < il; i ++ {
-		f.VertexNormals[ i ] = source.VertexNormals[ i ].Clone()
+	f.VertexNormals = make([]*math.Vector3, len(source.VertexNormals))
+	for i, normal := range source.VertexNormals {
+		f.VertexNormals[ i ] = normal.Clone()
 	}
 
-	il = len(source.VertexColors)
-	for i := 0; i < il; i ++ {
-		f.VertexColors[ i ] = source.VertexColors[ i ].Clone()
+	f.VertexColors = make([]*math.Color, len(source.VertexColors))
+	for i, color := range source.VertexColors {
+		f.VertexColors[ i ] = color.Clone()
 	}
 
 	return f
@@ -1,9 +1,42 @@
 package core
 import (
+	"math"
 	math3d "github.com/uzudil/three.go/math"
 	"github.com/uzudil/three.go/objects"
 )
 
+// GeometryGroup is a run of consecutive faces that share a material index.
+// Start and Count are in face vertices, three per face.
+type GeometryGroup struct {
+	Start, Count int
+	MaterialIndex int
+}
+
+// MorphTarget is a named set of alternative positions for the vertices of a
+// geometry, blended in by Mesh.MorphTargetInfluences.
+type MorphTarget struct {
+	Name string
+	Vertices []*math3d.Vector3
+}
+
+// MorphNormals are the normals of each face, and of its a, b and c vertices,
+// with the vertices of the geometry moved to a morph target.
+type MorphNormals struct {
+	FaceNormals []*math3d.Vector3
+	VertexNormals [][3]*math3d.Vector3
+}
+
+// GeometryBone is a bone of the skeleton a SkinnedMesh builds from its
+// geometry, posed relative to its parent. Parent is an index into
+// Geometry.Bones, -1 for a root bone.
+type GeometryBone struct {
+	Name string
+	Parent int
+	Position *math3d.Vector3
+	Quaternion *math3d.Quaternion
+	Scale *math3d.Vector3
+}
+
 type Geometry struct {
 	*EventDispatcher
 	Id int
@@ -13,7 +46,14 @@ type Geometry struct {
 	Vertices []*math3d.Vector3
 	Colors []*math3d.Color
 	Faces []*Face3
+	LineDistances []float64
 	FaceVertexUvs []([]([]*math3d.Vector2))
+	Groups []*GeometryGroup
+	MorphTargets []*MorphTarget
+	MorphNormals []*MorphNormals
+	SkinIndices []*math3d.Vector4 // up to four bones moving each vertex
+	SkinWeights []*math3d.Vector4 // how much each of those bones moves it
+	Bones []*GeometryBone
 	BoundingBox *math3d.Box3
 	BoundingSphere *math3d.Sphere
 	VerticesNeedUpdate bool
@@ -21,6 +61,9 @@ type Geometry struct {
 	UvsNeedUpdate bool
 	NormalsNeedUpdate bool
 	ColorsNeedUpdate bool
+	LineDistancesNeedUpdate bool
+	GroupsNeedUpdate bool
+	Parameters map[string]interface{}
 
 	RotateX func(angle float64) (*Geometry)
 	RotateY func(angle float64) (*Geometry)
@@ -43,7 +86,15 @@ func NewGeometry() (*Geometry) {
 		Vertices: make([]*math3d.Vector3, 0),
 		Colors: make([]*math3d.Color, 0),
 		Faces: make([]*Face3, 0),
-		FaceVertexUvs: make([]([]([]*math3d.Vector2)), 0),
+		LineDistances: make([]float64, 0),
+		FaceVertexUvs: [][][]*math3d.Vector2{ make([][]*math3d.Vector2, 0) },
+		Groups: make([]*GeometryGroup, 0),
+		MorphTargets: make([]*MorphTarget, 0),
+		MorphNormals: make([]*MorphNormals, 0),
+		SkinIndices: make([]*math3d.Vector4, 0),
+		SkinWeights: make([]*math3d.Vector4, 0),
+		Bones: make([]*GeometryBone, 0),
+		GroupsNeedUpdate: true,
 	}
 	g.RotateX = g.buildRotateX()
 	g.RotateY = g.buildRotateY()
@@ -382,155 +433,134 @@ func (g *Geometry) ComputeVertexNormals(areaWeighted bool) {
 			vertexNormals[ 1 ].Copy( vertices[ face.B ] )
 			vertexNormals[ 2 ].Copy( vertices[ face.C ] )
 		} else {
-			vertexNormals[ 0 ] = vertices[ face.A ].Clone()
-			vertexNormals[ 1 ] = vertices[ face.B ].Clone()
-			vertexNormals[ 2 ] = vertices[ face.C ].Clone()
+			face.VertexNormals = []*math3d.Vector3{
+				vertices[ face.A ].Clone(),
+				vertices[ face.B ].Clone(),
+				vertices[ face.C ].Clone(),
+			}
 		}
 	}
 }
-/*
-computeMorphNormals: function () {
-
-	var i, il, f, fl, face;
 
+// ComputeMorphNormals computes face and vertex normals for each morph target
+// into MorphNormals. The normals of the faces themselves are left as they were.
+func (g *Geometry) ComputeMorphNormals() {
 	// save original normals
-	// - create temp variables on first access
-	//   otherwise just copy (for faster repeated calls)
-
-	for ( f = 0, fl = g.faces.length; f < fl; f ++ ) {
-
-		face = g.faces[ f ];
-
-		if ( ! face.__originalFaceNormal ) {
-
-			face.__originalFaceNormal = face.normal.clone();
-
-		} else {
-
-			face.__originalFaceNormal.copy( face.normal );
-
-		}
-
-		if ( ! face.__originalVertexNormals ) face.__originalVertexNormals = [];
-
-		for ( i = 0, il = face.vertexNormals.length; i < il; i ++ ) {
-
-			if ( ! face.__originalVertexNormals[ i ] ) {
-
-				face.__originalVertexNormals[ i ] = face.vertexNormals[ i ].clone();
-
-			} else {
-
-				face.__originalVertexNormals[ i ].copy( face.vertexNormals[ i ] );
-
-			}
-
+	originalFaceNormals := make([]*math3d.Vector3, len(g.Faces))
+	originalVertexNormals := make([][]*math3d.Vector3, len(g.Faces))
+
+	for f, face := range g.Faces {
+		originalFaceNormals[ f ] = face.Normal.Clone()
+		originalVertexNormals[ f ] = make([]*math3d.Vector3, len(face.VertexNormals))
+		for i, normal := range face.VertexNormals {
+			originalVertexNormals[ f ][ i ] = normal.Clone()
 		}
-
 	}
 
 	// use temp geometry to compute face and vertex normals for each morph
 
-	var tmpGeo = new THREE.Geometry();
-	tmpGeo.faces = g.faces;
+	tmpGeo := NewGeometry()
+	tmpGeo.Faces = g.Faces
 
-	for ( i = 0, il = g.morphTargets.length; i < il; i ++ ) {
+	for i, morphTarget := range g.MorphTargets {
 
 		// create on first access
 
-		if ( ! g.morphNormals[ i ] ) {
-
-			g.morphNormals[ i ] = {};
-			g.morphNormals[ i ].faceNormals = [];
-			g.morphNormals[ i ].vertexNormals = [];
-
-			var dstNormalsFace = g.morphNormals[ i ].faceNormals;
-			var dstNormalsVertex = g.morphNormals[ i ].vertexNormals;
-
-			var faceNormal, vertexNormals;
-
-			for ( f = 0, fl = g.faces.length; f < fl; f ++ ) {
-
-				faceNormal = new THREE.Vector3();
-				vertexNormals = { a: new THREE.Vector3(), b: new THREE.Vector3(), c: new THREE.Vector3() };
-
-				dstNormalsFace.push( faceNormal );
-				dstNormalsVertex.push( vertexNormals );
-
+		if i >= len(g.MorphNormals) {
+			morphNormals := &MorphNormals{
+				FaceNormals: make([]*math3d.Vector3, len(g.Faces)),
+				VertexNormals: make([][3]*math3d.Vector3, len(g.Faces)),
 			}
-
+			for f := range g.Faces {
+				morphNormals.FaceNormals[ f ] = math3d.NewEmptyVector3()
+				morphNormals.VertexNormals[ f ] = [3]*math3d.Vector3{ math3d.NewEmptyVector3(), math3d.NewEmptyVector3(), math3d.NewEmptyVector3() }
+			}
+			g.MorphNormals = append(g.MorphNormals, morphNormals)
 		}
 
-		var morphNormals = g.morphNormals[ i ];
+		morphNormals := g.MorphNormals[ i ]
 
 		// set vertices to morph target
 
-		tmpGeo.vertices = g.morphTargets[ i ].vertices;
+		tmpGeo.Vertices = morphTarget.Vertices
 
 		// compute morph normals
 
-		tmpGeo.computeFaceNormals();
-		tmpGeo.computeVertexNormals();
+		tmpGeo.ComputeFaceNormals()
+		tmpGeo.ComputeVertexNormals( false )
 
 		// store morph normals
 
-		var faceNormal, vertexNormals;
-
-		for ( f = 0, fl = g.faces.length; f < fl; f ++ ) {
-
-			face = g.faces[ f ];
-
-			faceNormal = morphNormals.faceNormals[ f ];
-			vertexNormals = morphNormals.vertexNormals[ f ];
-
-			faceNormal.copy( face.normal );
-
-			vertexNormals.a.copy( face.vertexNormals[ 0 ] );
-			vertexNormals.b.copy( face.vertexNormals[ 1 ] );
-			vertexNormals.c.copy( face.vertexNormals[ 2 ] );
+		for f, face := range g.Faces {
+			morphNormals.FaceNormals[ f ].Copy( face.Normal )
 
+			morphNormals.VertexNormals[ f ][ 0 ].Copy( face.VertexNormals[ 0 ] )
+			morphNormals.VertexNormals[ f ][ 1 ].Copy( face.VertexNormals[ 1 ] )
+			morphNormals.VertexNormals[ f ][ 2 ].Copy( face.VertexNormals[ 2 ] )
 		}
-
 	}
 
 	// restore original normals
 
-	for ( f = 0, fl = g.faces.length; f < fl; f ++ ) {
-
-		face = g.faces[ f ];
-
-		face.normal = face.__originalFaceNormal;
-		face.vertexNormals = face.__originalVertexNormals;
-
+	for f, face := range g.Faces {
+		face.Normal = originalFaceNormals[ f ]
+		face.VertexNormals = originalVertexNormals[ f ]
 	}
+}
 
-},
-
+/*
 computeTangents: function () {
 
 	console.warn( 'THREE.Geometry: .computeTangents() has been removed.' );
 
 },
+*/
 
-computeLineDistances: function () {
+// ComputeLineDistances stores the distance along the polyline through the
+// vertices at each vertex, as used by dashed lines.
+func (g *Geometry) ComputeLineDistances() {
+	d := 0.0
+	vertices := g.Vertices
 
-	var d = 0;
-	var vertices = g.vertices;
+	g.LineDistances = make([]float64, len(vertices))
 
-	for ( var i = 0, il = vertices.length; i < il; i ++ ) {
+	for i := range vertices {
+		if i > 0 {
+			d += vertices[ i ].DistanceTo( vertices[ i - 1 ] )
+		}
+		g.LineDistances[ i ] = d
+	}
 
-		if ( i > 0 ) {
+	g.LineDistancesNeedUpdate = true
+}
 
-			d += vertices[ i ].distanceTo( vertices[ i - 1 ] );
+// ComputeGroups splits the faces into Groups by material index, for drawing
+// with a MultiMaterial. Set GroupsNeedUpdate after changing face material indices.
+func (g *Geometry) ComputeGroups() {
+	g.Groups = make([]*GeometryGroup, 0)
 
-		}
+	var group *GeometryGroup
 
-		g.lineDistances[ i ] = d;
+	for i, face := range g.Faces {
+		if group == nil || face.MaterialIndex != group.MaterialIndex {
+			if group != nil {
+				group.Count = i * 3 - group.Start
+				g.Groups = append(g.Groups, group)
+			}
+			group = &GeometryGroup{
+				Start: i * 3,
+				MaterialIndex: face.MaterialIndex,
+			}
+		}
+	}
 
+	if group != nil {
+		group.Count = len(g.Faces) * 3 - group.Start
+		g.Groups = append(g.Groups, group)
 	}
 
-},
-*/
+	g.GroupsNeedUpdate = false
+}
 
 func (g *Geometry) ComputeBoundingBox() {
 	if g.BoundingBox == nil {
@@ -549,16 +579,8 @@ func (g *Geometry) ComputeBoundingSphere() {
 func (g *Geometry) Merge(geometry *Geometry, matrix *math3d.Matrix4, materialIndexOffset int) {
 	var normalMatrix *math3d.Matrix3
 	vertexOffset := len(g.Vertices)
-	vertices1 := g.Vertices
 	vertices2 := geometry.Vertices
-	faces1 := g.Faces
 	faces2 := geometry.Faces
-	uvs1 := g.FaceVertexUvs[ 0 ]
-	uvs2 := geometry.FaceVertexUvs[ 0 ]
-
-	if materialIndexOffset == nil {
-		materialIndexOffset = 0
-	}
 
 	if matrix != nil {
 		normalMatrix = math3d.NewMatrix3().GetNormalMatrix( matrix )
@@ -570,7 +592,7 @@ func (g *Geometry) Merge(geometry *Geometry, matrix *math3d.Matrix4, materialInd
 		if matrix != nil {
 			vertexCopy.ApplyMatrix4( matrix )
 		}
-		vertices1 = append(vertices1, vertexCopy )
+		g.Vertices = append(g.Vertices, vertexCopy )
 	}
 
 	// faces
@@ -600,20 +622,28 @@ func (g *Geometry) Merge(geometry *Geometry, matrix *math3d.Matrix4, materialInd
 		}
 
 		faceCopy.MaterialIndex = face.MaterialIndex + materialIndexOffset
-		faces1 = append(faces1, faceCopy )
+		g.Faces = append(g.Faces, faceCopy )
 	}
 
 	// uvs
-	for _, uv := range uvs2 {
+	if len(geometry.FaceVertexUvs) == 0 {
+		return
+	}
+	if len(g.FaceVertexUvs) == 0 {
+		g.FaceVertexUvs = append(g.FaceVertexUvs, make([][]*math3d.Vector2, 0))
+	}
+	for _, uv := range geometry.FaceVertexUvs[ 0 ] {
 		if uv == nil {
 			continue;
 		}
-		uvCopy := make([]*math3d.Vector2, len(uv))
+		uvCopy := make([]*math3d.Vector2, 0, len(uv))
 		for _, u := range uv {
 			uvCopy = append(uvCopy, u.Clone())
 		}
-		uvs1 = append(uvs1, uvCopy)
+		g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], uvCopy)
 	}
+
+	g.GroupsNeedUpdate = true
 }
 
 func (g *Geometry) MergeMesh(mesh *objects.Mesh) {
@@ -626,93 +656,70 @@ func (g *Geometry) MergeMesh(mesh *objects.Mesh) {
  * Duplicated vertices are removed
  * and faces' vertices are updated.
  */
-/*
 func (g *Geometry) MergeVertices() int {
 
-	var verticesMap = {}; // Hashmap for looking up vertices by position coordinates (and making sure they are unique)
-	var unique = [], changes = [];
-
-	var v, key;
-	var precisionPoints = 4; // number of decimal points, e.g. 4 for epsilon of 0.0001
-	var precision = Math.pow( 10, precisionPoints );
-	var i, il, face;
-	var indices, j, jl;
-
-	for ( i = 0, il = g.vertices.length; i < il; i ++ ) {
+	verticesMap := make(map[[3]float64]int) // Hashmap for looking up vertices by position coordinates (and making sure they are unique)
+	unique := make([]*math3d.Vector3, 0, len(g.Vertices))
+	changes := make([]int, len(g.Vertices))
 
-		v = g.vertices[ i ];
-		key = Math.round( v.x * precision ) + '_' + Math.round( v.y * precision ) + '_' + Math.round( v.z * precision );
+	precisionPoints := 4.0 // number of decimal points, e.g. 4 for epsilon of 0.0001
+	precision := math.Pow( 10, precisionPoints )
 
-		if ( verticesMap[ key ] === undefined ) {
+	for i, v := range g.Vertices {
 
-			verticesMap[ key ] = i;
-			unique.push( g.vertices[ i ] );
-			changes[ i ] = unique.length - 1;
+		key := [3]float64{ math3d.Round( v.X * precision ), math3d.Round( v.Y * precision ), math3d.Round( v.Z * precision ) }
 
+		if index, ok := verticesMap[ key ]; !ok {
+			verticesMap[ key ] = i
+			unique = append(unique, v)
+			changes[ i ] = len(unique) - 1
 		} else {
-
-			//console.log('Duplicate vertex found. ', i, ' could be using ', verticesMap[key]);
-			changes[ i ] = changes[ verticesMap[ key ] ];
-
+			changes[ i ] = changes[ index ]
 		}
-
 	}
 
-
 	// if faces are completely degenerate after merging vertices, we
 	// have to remove them from the geometry.
-	var faceIndicesToRemove = [];
-
-	for ( i = 0, il = g.faces.length; i < il; i ++ ) {
+	faceIndicesToRemove := make([]int, 0)
 
-		face = g.faces[ i ];
+	for i, face := range g.Faces {
 
-		face.a = changes[ face.a ];
-		face.b = changes[ face.b ];
-		face.c = changes[ face.c ];
+		face.A = changes[ face.A ]
+		face.B = changes[ face.B ]
+		face.C = changes[ face.C ]
 
-		indices = [ face.a, face.b, face.c ];
-
-		var dupIndex = - 1;
+		indices := [3]int{ face.A, face.B, face.C }
 
 		// if any duplicate vertices are found in a Face3
 		// we have to remove the face as nothing can be saved
-		for ( var n = 0; n < 3; n ++ ) {
-
-			if ( indices[ n ] === indices[ ( n + 1 ) % 3 ] ) {
-
-				dupIndex = n;
-				faceIndicesToRemove.push( i );
-				break;
-
+		for n := 0; n < 3; n ++ {
+			if indices[ n ] == indices[ ( n + 1 ) % 3 ] {
+				faceIndicesToRemove = append(faceIndicesToRemove, i)
+				break
 			}
-
 		}
-
 	}
 
-	for ( i = faceIndicesToRemove.length - 1; i >= 0; i -- ) {
+	for i := len(faceIndicesToRemove) - 1; i >= 0; i -- {
+		idx := faceIndicesToRemove[ i ]
 
-		var idx = faceIndicesToRemove[ i ];
-
-		g.faces.splice( idx, 1 );
-
-		for ( j = 0, jl = g.faceVertexUvs.length; j < jl; j ++ ) {
-
-			g.faceVertexUvs[ j ].splice( idx, 1 );
+		g.Faces = append(g.Faces[:idx], g.Faces[idx + 1:]...)
 
+		for j, uvs := range g.FaceVertexUvs {
+			if idx < len(uvs) {
+				g.FaceVertexUvs[ j ] = append(uvs[:idx], uvs[idx + 1:]...)
+			}
 		}
-
 	}
 
 	// Use unique set of vertices
 
-	var diff = g.vertices.length - unique.length;
-	g.vertices = unique;
-	return diff;
-
-},
+	diff := len(g.Vertices) - len(unique)
+	g.Vertices = unique
+	return diff
+}
 
+/*
 sortFacesByMaterialIndex: function () {
 
 	var faces = g.faces;
@@ -760,230 +767,260 @@ sortFacesByMaterialIndex: function () {
 
 },
 
-toJSON: function () {
+*/
 
-	var data = {
-		metadata: {
-			version: 4.4,
-			type: 'Geometry',
-			generator: 'Geometry.toJSON'
-		}
-	};
+// ToJSON serializes the geometry in the three.js JSON format (version 4.4).
+// Geometries with Parameters are written as their parameters only, so the
+// loader can rebuild them with the matching constructor.
+func (g *Geometry) ToJSON(meta *JSONMeta) (map[string]interface{}) {
+
+	data := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"version": 4.4,
+			"type": "Geometry",
+			"generator": "Geometry.toJSON",
+		},
+	}
 
 	// standard Geometry serialization
 
-	data.uuid = g.uuid;
-	data.type = g.type;
-	if ( g.name !== '' ) data.name = g.name;
-
-	if ( g.parameters !== undefined ) {
-
-		var parameters = g.parameters;
-
-		for ( var key in parameters ) {
-
-			if ( parameters[ key ] !== undefined ) data[ key ] = parameters[ key ];
+	data["uuid"] = g.Uuid
+	data["type"] = g.Type
+	if g.Name != "" {
+		data["name"] = g.Name
+	}
 
+	if g.Parameters != nil {
+		for key, value := range g.Parameters {
+			if value != nil {
+				data[ key ] = value
+			}
 		}
-
-		return data;
-
+		return data
 	}
 
-	var vertices = [];
-
-	for ( var i = 0; i < g.vertices.length; i ++ ) {
-
-		var vertex = g.vertices[ i ];
-		vertices.push( vertex.x, vertex.y, vertex.z );
+	vertices := make([]float64, 0, len(g.Vertices) * 3)
+	for _, vertex := range g.Vertices {
+		vertices = append(vertices, vertex.X, vertex.Y, vertex.Z)
+	}
 
+	faces := make([]int, 0)
+	normals := make([]float64, 0)
+	normalsHash := make(map[[3]float64]int)
+	colors := make([]int, 0)
+	colorsHash := make(map[int]int)
+	uvs := make([]float64, 0)
+	uvsHash := make(map[[2]float64]int)
+
+	setBit := func(value, position int, enabled bool) int {
+		if enabled {
+			return value | ( 1 << uint(position) )
+		}
+		return value & ( ^( 1 << uint(position) ) )
 	}
 
-	var faces = [];
-	var normals = [];
-	var normalsHash = {};
-	var colors = [];
-	var colorsHash = {};
-	var uvs = [];
-	var uvsHash = {};
+	getNormalIndex := func(normal *math3d.Vector3) int {
+		hash := [3]float64{ normal.X, normal.Y, normal.Z }
+		if index, ok := normalsHash[ hash ]; ok {
+			return index
+		}
+		normalsHash[ hash ] = len(normals) / 3
+		normals = append(normals, normal.X, normal.Y, normal.Z)
+		return normalsHash[ hash ]
+	}
 
-	for ( var i = 0; i < g.faces.length; i ++ ) {
+	getColorIndex := func(color *math3d.Color) int {
+		hash := color.GetHex()
+		if index, ok := colorsHash[ hash ]; ok {
+			return index
+		}
+		colorsHash[ hash ] = len(colors)
+		colors = append(colors, hash)
+		return colorsHash[ hash ]
+	}
 
-		var face = g.faces[ i ];
+	getUvIndex := func(uv *math3d.Vector2) int {
+		hash := [2]float64{ uv.X, uv.Y }
+		if index, ok := uvsHash[ hash ]; ok {
+			return index
+		}
+		uvsHash[ hash ] = len(uvs) / 2
+		uvs = append(uvs, uv.X, uv.Y)
+		return uvsHash[ hash ]
+	}
 
-		var hasMaterial = false; // face.materialIndex !== undefined;
-		var hasFaceUv = false; // deprecated
-		var hasFaceVertexUv = g.faceVertexUvs[ 0 ][ i ] !== undefined;
-		var hasFaceNormal = face.normal.length() > 0;
-		var hasFaceVertexNormal = face.vertexNormals.length > 0;
-		var hasFaceColor = face.color.r !== 1 || face.color.g !== 1 || face.color.b !== 1;
-		var hasFaceVertexColor = face.vertexColors.length > 0;
+	for i, face := range g.Faces {
 
-		var faceType = 0;
+		hasMaterial := true
+		hasFaceUv := false // deprecated
+		hasFaceVertexUv := len(g.FaceVertexUvs) > 0 && i < len(g.FaceVertexUvs[ 0 ]) && g.FaceVertexUvs[ 0 ][ i ] != nil
+		hasFaceNormal := face.Normal.Length() > 0
+		hasFaceVertexNormal := len(face.VertexNormals) > 0
+		hasFaceColor := face.Color.GetHex() != 0xffffff
+		hasFaceVertexColor := len(face.VertexColors) > 0
 
-		faceType = setBit( faceType, 0, 0 );
-		faceType = setBit( faceType, 1, hasMaterial );
-		faceType = setBit( faceType, 2, hasFaceUv );
-		faceType = setBit( faceType, 3, hasFaceVertexUv );
-		faceType = setBit( faceType, 4, hasFaceNormal );
-		faceType = setBit( faceType, 5, hasFaceVertexNormal );
-		faceType = setBit( faceType, 6, hasFaceColor );
-		faceType = setBit( faceType, 7, hasFaceVertexColor );
+		faceType := 0
 
-		faces.push( faceType );
-		faces.push( face.a, face.b, face.c );
+		faceType = setBit( faceType, 0, false )
+		faceType = setBit( faceType, 1, hasMaterial )
+		faceType = setBit( faceType, 2, hasFaceUv )
+		faceType = setBit( faceType, 3, hasFaceVertexUv )
+		faceType = setBit( faceType, 4, hasFaceNormal )
+		faceType = setBit( faceType, 5, hasFaceVertexNormal )
+		faceType = setBit( faceType, 6, hasFaceColor )
+		faceType = setBit( faceType, 7, hasFaceVertexColor )
 
-		if ( hasFaceVertexUv ) {
+		faces = append(faces, faceType)
+		faces = append(faces, face.A, face.B, face.C)
 
-			var faceVertexUvs = g.faceVertexUvs[ 0 ][ i ];
+		if hasMaterial {
+			faces = append(faces, face.MaterialIndex)
+		}
 
-			faces.push(
+		if hasFaceVertexUv {
+			faceVertexUvs := g.FaceVertexUvs[ 0 ][ i ]
+			faces = append(faces,
 				getUvIndex( faceVertexUvs[ 0 ] ),
 				getUvIndex( faceVertexUvs[ 1 ] ),
-				getUvIndex( faceVertexUvs[ 2 ] )
-			);
-
+				getUvIndex( faceVertexUvs[ 2 ] ),
+			)
 		}
 
-		if ( hasFaceNormal ) {
-
-			faces.push( getNormalIndex( face.normal ) );
-
+		if hasFaceNormal {
+			faces = append(faces, getNormalIndex( face.Normal ))
 		}
 
-		if ( hasFaceVertexNormal ) {
-
-			var vertexNormals = face.vertexNormals;
-
-			faces.push(
+		if hasFaceVertexNormal {
+			vertexNormals := face.VertexNormals
+			faces = append(faces,
 				getNormalIndex( vertexNormals[ 0 ] ),
 				getNormalIndex( vertexNormals[ 1 ] ),
-				getNormalIndex( vertexNormals[ 2 ] )
-			);
-
+				getNormalIndex( vertexNormals[ 2 ] ),
+			)
 		}
 
-		if ( hasFaceColor ) {
-
-			faces.push( getColorIndex( face.color ) );
-
+		if hasFaceColor {
+			faces = append(faces, getColorIndex( face.Color ))
 		}
 
-		if ( hasFaceVertexColor ) {
-
-			var vertexColors = face.vertexColors;
-
-			faces.push(
+		if hasFaceVertexColor {
+			vertexColors := face.VertexColors
+			faces = append(faces,
 				getColorIndex( vertexColors[ 0 ] ),
 				getColorIndex( vertexColors[ 1 ] ),
-				getColorIndex( vertexColors[ 2 ] )
-			);
-
+				getColorIndex( vertexColors[ 2 ] ),
+			)
 		}
-
 	}
 
-	function setBit( value, position, enabled ) {
-
-		return enabled ? value | ( 1 << position ) : value & ( ~ ( 1 << position ) );
-
+	geometryData := map[string]interface{}{
+		"vertices": vertices,
+		"normals": normals,
+		"faces": faces,
 	}
-
-	function getNormalIndex( normal ) {
-
-		var hash = normal.x.toString() + normal.y.toString() + normal.z.toString();
-
-		if ( normalsHash[ hash ] !== undefined ) {
-
-			return normalsHash[ hash ];
-
-		}
-
-		normalsHash[ hash ] = normals.length / 3;
-		normals.push( normal.x, normal.y, normal.z );
-
-		return normalsHash[ hash ];
-
+	if len(colors) > 0 {
+		geometryData["colors"] = colors
 	}
-
-	function getColorIndex( color ) {
-
-		var hash = color.r.toString() + color.g.toString() + color.b.toString();
-
-		if ( colorsHash[ hash ] !== undefined ) {
-
-			return colorsHash[ hash ];
-
-		}
-
-		colorsHash[ hash ] = colors.length;
-		colors.push( color.getHex() );
-
-		return colorsHash[ hash ];
-
+	if len(uvs) > 0 {
+		geometryData["uvs"] = [][]float64{ uvs } // temporal backward compatibility
 	}
-
-	function getUvIndex( uv ) {
-
-		var hash = uv.x.toString() + uv.y.toString();
-
-		if ( uvsHash[ hash ] !== undefined ) {
-
-			return uvsHash[ hash ];
-
+	if len(g.SkinIndices) > 0 {
+		skinIndices := make([]float64, 0, len(g.SkinIndices) * 4)
+		for _, v := range g.SkinIndices {
+			skinIndices = append(skinIndices, v.X, v.Y, v.Z, v.W)
 		}
-
-		uvsHash[ hash ] = uvs.length / 2;
-		uvs.push( uv.x, uv.y );
-
-		return uvsHash[ hash ];
-
+		skinWeights := make([]float64, 0, len(g.SkinWeights) * 4)
+		for _, v := range g.SkinWeights {
+			skinWeights = append(skinWeights, v.X, v.Y, v.Z, v.W)
+		}
+		geometryData["influencesPerVertex"] = 4
+		geometryData["skinIndices"] = skinIndices
+		geometryData["skinWeights"] = skinWeights
 	}
+	data["data"] = geometryData
 
-	data.data = {};
-
-	data.data.vertices = vertices;
-	data.data.normals = normals;
-	if ( colors.length > 0 ) data.data.colors = colors;
-	if ( uvs.length > 0 ) data.data.uvs = [ uvs ]; // temporal backward compatibility
-	data.data.faces = faces;
-
-	return data;
+	return data
+}
 
-},
-*/
 func (g *Geometry) Clone() (*Geometry) {
 	return NewGeometry().Copy(g)
 }
 
 func (g *Geometry) Copy(source *Geometry) (*Geometry) {
-	g.Vertices = make([]*math3d.Vector3, len(source.Vertices))
-	g.Faces = make([]*Face3, len(source.Faces))
-	g.FaceVertexUvs = make([]([]*math3d.Vector2), 0)
+	g.Vertices = make([]*math3d.Vector3, 0, len(source.Vertices))
+	g.Colors = make([]*math3d.Color, 0, len(source.Colors))
+	g.Faces = make([]*Face3, 0, len(source.Faces))
+	g.FaceVertexUvs = make([]([]([]*math3d.Vector2)), len(source.FaceVertexUvs))
 
-	vertices := source.Vertices
+	for _, v := range source.Vertices {
+		g.Vertices = append(g.Vertices, v.Clone())
+	}
 
-	for _, v := range vertices {
-		g.Vertices = append(g.Vertices, v)
+	for _, color := range source.Colors {
+		g.Colors = append(g.Colors, color.Clone())
 	}
 
-	faces := source.Faces;
-	for _, face := range faces {
-		g.Faces = append(g.Faces, face)
+	for _, face := range source.Faces {
+		g.Faces = append(g.Faces, face.Clone())
 	}
 
 	for i, faceVertexUvs := range source.FaceVertexUvs {
-		if g.FaceVertexUvs[ i ] == nil {
-			g.FaceVertexUvs[ i ] = make([]([]*math3d.Vector2), 0)
-		}
+		g.FaceVertexUvs[ i ] = make([]([]*math3d.Vector2), 0, len(faceVertexUvs))
 		for _, uvs := range faceVertexUvs {
-			uvsCopy := make([]*math3d.Vector2, 0);
+			uvsCopy := make([]*math3d.Vector2, 0, len(uvs))
 			for _, uv := range uvs {
-				uvsCopy = append(uvsCopy, uv.Clone() )
+				uvsCopy = append(uvsCopy, uv.Clone())
 			}
-			g.FaceVertexUvs[ i ] = append(g.FaceVertexUvs[i], uvsCopy)
+			g.FaceVertexUvs[ i ] = append(g.FaceVertexUvs[ i ], uvsCopy)
+		}
+	}
+
+	g.MorphTargets = make([]*MorphTarget, 0, len(source.MorphTargets))
+	for _, morphTarget := range source.MorphTargets {
+		vertices := make([]*math3d.Vector3, 0, len(morphTarget.Vertices))
+		for _, v := range morphTarget.Vertices {
+			vertices = append(vertices, v.Clone())
+		}
+		g.MorphTargets = append(g.MorphTargets, &MorphTarget{ Name: morphTarget.Name, Vertices: vertices })
+	}
+
+	g.MorphNormals = make([]*MorphNormals, 0, len(source.MorphNormals))
+	for _, morphNormals := range source.MorphNormals {
+		normals := &MorphNormals{
+			FaceNormals: make([]*math3d.Vector3, len(morphNormals.FaceNormals)),
+			VertexNormals: make([][3]*math3d.Vector3, len(morphNormals.VertexNormals)),
+		}
+		for f, normal := range morphNormals.FaceNormals {
+			normals.FaceNormals[ f ] = normal.Clone()
 		}
+		for f, vertexNormals := range morphNormals.VertexNormals {
+			normals.VertexNormals[ f ] = [3]*math3d.Vector3{ vertexNormals[ 0 ].Clone(), vertexNormals[ 1 ].Clone(), vertexNormals[ 2 ].Clone() }
+		}
+		g.MorphNormals = append(g.MorphNormals, normals)
+	}
+
+	g.SkinIndices = make([]*math3d.Vector4, 0, len(source.SkinIndices))
+	for _, skinIndex := range source.SkinIndices {
+		g.SkinIndices = append(g.SkinIndices, skinIndex.Clone())
 	}
+
+	g.SkinWeights = make([]*math3d.Vector4, 0, len(source.SkinWeights))
+	for _, skinWeight := range source.SkinWeights {
+		g.SkinWeights = append(g.SkinWeights, skinWeight.Clone())
+	}
+
+	g.Bones = make([]*GeometryBone, 0, len(source.Bones))
+	for _, bone := range source.Bones {
+		g.Bones = append(g.Bones, &GeometryBone{
+			Name: bone.Name,
+			Parent: bone.Parent,
+			Position: bone.Position.Clone(),
+			Quaternion: bone.Quaternion.Clone(),
+			Scale: bone.Scale.Clone(),
+		})
+	}
+
+	g.GroupsNeedUpdate = true
 	return g
 }
 
@@ -0,0 +1,28 @@
+package core
+
+// JSONMeta collects the resources shared between objects while a hierarchy
+// is serialized, so that each geometry or material is written only once.
+type JSONMeta struct {
+	Geometries map[string]map[string]interface{}
+	Materials map[string]map[string]interface{}
+	Textures map[string]map[string]interface{}
+	Images map[string]map[string]interface{}
+}
+
+func NewJSONMeta() (*JSONMeta) {
+	return &JSONMeta{
+		Geometries: make(map[string]map[string]interface{}),
+		Materials: make(map[string]map[string]interface{}),
+		Textures: make(map[string]map[string]interface{}),
+		Images: make(map[string]map[string]interface{}),
+	}
+}
+
+func (meta *JSONMeta) extractFromCache(cache map[string]map[string]interface{}) ([]interface{}) {
+	values := make([]interface{}, 0, len(cache))
+	for _, data := range cache {
+		delete(data, "metadata")
+		values = append(values, data)
+	}
+	return values
+}
@@ -10,32 +10,41 @@ type Object3D struct {
 	Uuid string
 	Name string
 	Type string
-	Parent Object3D
-	Children []Object3D
+	Parent *Object3D
+	Children []*Object3D
+	Channels *Channels
 	Up *math.Vector3
 	Position *math.Vector3
 	Rotation *math.Euler
 	Quaternion *math.Quaternion
 	Scale *math.Vector3
 	RotationAutoUpdate bool
-	Matrix math.Matrix4
-	MatrixWorld math.Matrix4
+	Matrix *math.Matrix4
+	MatrixWorld *math.Matrix4
 	MatrixAutoUpdate bool
 	MatrixWorldNeedsUpdate bool
 	Visible bool
 	CastShadow, ReceiveShadow bool
 	FrustumCulled bool
 	RenderOrder int
-	UserData map[string]string
+	// UserData holds values as decoded from JSON: strings, float64s, bools,
+	// []interface{} and map[string]interface{}, so they are written back as read.
+	UserData map[string]interface{}
 	ModelViewMatrix *math.Matrix4
 	NormalMatrix *math.Matrix3
 	Geometry *Geometry
 
+	// Self is the value embedding this Object3D, e.g. a *objects.Mesh, so code
+	// walking Children can get back to it. It is the Object3D itself otherwise.
+	Self interface{}
+
 	GetWorldQuaternion func(*math.Vector3) (*math.Quaternion)
 	GetWorldRotation func(*math.Euler) (*math.Euler)
 	GetWorldScale func(*math.Vector3) (*math.Vector3)
 	GetWorldDirection func(*math.Vector3) (*math.Vector3)
 	LookAt func(*math.Vector3)
+	ToJSONObject func(*JSONMeta) (map[string]interface{})
+	Raycast func(*Raycaster, []*Intersection) ([]*Intersection)
 }
 
 var Object3DIdCount int = 0
@@ -52,7 +61,7 @@ func NewObject3D() (*Object3D) {
 		Type: "Object3D",
 		Parent: nil,
 		Channels: NewChannels(),
-		Children: make([]Object3D, 0),
+		Children: make([]*Object3D, 0),
 		Up: DefaultUp.Clone(),
 		Position: math.NewEmptyVector3(),
 		Rotation: math.NewEmptyEuler(),
@@ -68,7 +77,7 @@ func NewObject3D() (*Object3D) {
 		ReceiveShadow: false,
 		FrustumCulled: true,
 		RenderOrder: 0,
-		UserData: make(map[string]string),
+		UserData: make(map[string]interface{}),
 		ModelViewMatrix: math.NewMatrix4(),
 		NormalMatrix: *math.NewMatrix3(),
 		Geometry: nil,
@@ -90,6 +99,9 @@ func NewObject3D() (*Object3D) {
 	object3d.GetWorldScale() = object3d.buildGetWorldScale()
 	object3d.GetWorldDirection = object3d.buildGetWorldDirection()
 	object3d.LookAt = object3d.buildLookAt()
+	object3d.ToJSONObject = object3d.BaseToJSONObject
+	object3d.Raycast = object3d.raycast
+	object3d.Self = &object3d
 
 	return &object3d
 }
@@ -192,6 +204,12 @@ func (o *Object3D) Remove(object *Object3D) {
 	}
 }
 
+// raycast is overridden by objects that can be hit, it appends their
+// intersections with raycaster's ray to intersects.
+func (o *Object3D) raycast(raycaster *Raycaster, intersects []*Intersection) ([]*Intersection) {
+	return intersects
+}
+
 func (o *Object3D) UpdateMatrix() {
 	o.Matrix.Compose( o.Position, o.Quaternion, o.Scale )
 	o.MatrixWorldNeedsUpdate = true
@@ -315,3 +333,97 @@ func (o *Object3D) buildLookAt() (func(*math.Vector3)) {
 		o.Quaternion.SetFromRotationMatrix( m1 )
 	}
 }
+
+// ToJSON serializes the object hierarchy in the three.js JSON object format (version 4.4).
+// Pass nil as meta when serializing a root object: the geometries and materials
+// referenced by the hierarchy are then collected into the top level of the result.
+func (o *Object3D) ToJSON(meta *JSONMeta) (map[string]interface{}) {
+
+	isRootObject := meta == nil
+
+	data := map[string]interface{}{}
+
+	if isRootObject {
+		meta = NewJSONMeta()
+		data["metadata"] = map[string]interface{}{
+			"version": 4.4,
+			"type": "Object",
+			"generator": "Object3D.toJSON",
+		}
+	}
+
+	data["object"] = o.ToJSONObject( meta )
+
+	if isRootObject {
+		geometries := meta.extractFromCache( meta.Geometries )
+		materials := meta.extractFromCache( meta.Materials )
+		textures := meta.extractFromCache( meta.Textures )
+		images := meta.extractFromCache( meta.Images )
+
+		if len(geometries) > 0 {
+			data["geometries"] = geometries
+		}
+		if len(materials) > 0 {
+			data["materials"] = materials
+		}
+		if len(textures) > 0 {
+			data["textures"] = textures
+		}
+		if len(images) > 0 {
+			data["images"] = images
+		}
+	}
+
+	return data
+}
+
+// BaseToJSONObject is the default ToJSONObject: it writes the fields shared by all objects.
+// Types embedding Object3D replace ToJSONObject with a function that calls this and adds their own fields.
+func (o *Object3D) BaseToJSONObject(meta *JSONMeta) (map[string]interface{}) {
+
+	object := map[string]interface{}{
+		"uuid": o.Uuid,
+		"type": o.Type,
+	}
+
+	if o.Name != "" {
+		object["name"] = o.Name
+	}
+
+	if len(o.UserData) > 0 {
+		userData := make(map[string]interface{}, len(o.UserData))
+		for key, value := range o.UserData {
+			userData[ key ] = value
+		}
+		object["userData"] = userData
+	}
+
+	if o.CastShadow {
+		object["castShadow"] = true
+	}
+	if o.ReceiveShadow {
+		object["receiveShadow"] = true
+	}
+	if !o.Visible {
+		object["visible"] = false
+	}
+
+	object["matrix"] = o.Matrix.ToArray()
+
+	if o.Geometry != nil {
+		if _, ok := meta.Geometries[ o.Geometry.Uuid ]; !ok {
+			meta.Geometries[ o.Geometry.Uuid ] = o.Geometry.ToJSON( meta )
+		}
+		object["geometry"] = o.Geometry.Uuid
+	}
+
+	if len(o.Children) > 0 {
+		children := make([]interface{}, 0, len(o.Children))
+		for _, child := range o.Children {
+			children = append(children, child.ToJSONObject( meta ))
+		}
+		object["children"] = children
+	}
+
+	return object
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"math"
+	"sort"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// Raycaster finds the objects a ray passes through, e.g. for mouse picking.
+type Raycaster struct {
+	Ray *math3d.Ray
+	Near, Far float64
+
+	// PointsThreshold is how close, in world units, the ray has to pass a point to hit it.
+	PointsThreshold float64
+}
+
+// Intersection is a hit found by a Raycaster. Fields that don't apply to the
+// object hit are left at their zero values.
+type Intersection struct {
+	Distance float64 // from the ray origin
+	DistanceToRay float64 // for points
+	Point *math3d.Vector3
+	Index int // of the vertex hit, for points
+	Face *Face3 // for meshes
+	FaceIndex int
+	Uv *math3d.Vector2 // texture coordinates at Point, if the geometry has them
+	InstanceId int // for instanced meshes
+	Object *Object3D
+}
+
+func NewDefaultRaycaster() (*Raycaster) {
+	return NewRaycaster(math3d.NewEmptyVector3(), math3d.NewVector3(0, 0, - 1), 0, math.Inf(1))
+}
+
+// NewRaycaster creates a raycaster for the ray from origin along direction,
+// which must be normalized. Only hits between near and far are reported.
+func NewRaycaster(origin, direction *math3d.Vector3, near, far float64) (*Raycaster) {
+	return &Raycaster{
+		Ray: math3d.NewRay(origin, direction),
+		Near: near,
+		Far: far,
+		PointsThreshold: 1,
+	}
+}
+
+func (r *Raycaster) Set(origin, direction *math3d.Vector3) {
+	// direction is assumed to be normalized (for accurate distance calculations)
+	r.Ray.Set( origin, direction )
+}
+
+// IntersectObject returns the intersections of the ray with object, and its
+// descendants if recursive, closest first.
+func (r *Raycaster) IntersectObject(object *Object3D, recursive bool) ([]*Intersection) {
+	intersects := r.intersectObject( object, make([]*Intersection, 0), recursive )
+
+	sort.Sort( byDistance( intersects ) )
+
+	return intersects
+}
+
+// IntersectObjects is IntersectObject for several objects at once.
+func (r *Raycaster) IntersectObjects(objects []*Object3D, recursive bool) ([]*Intersection) {
+	intersects := make([]*Intersection, 0)
+
+	for _, object := range objects {
+		intersects = r.intersectObject( object, intersects, recursive )
+	}
+
+	sort.Sort( byDistance( intersects ) )
+
+	return intersects
+}
+
+func (r *Raycaster) intersectObject(object *Object3D, intersects []*Intersection, recursive bool) ([]*Intersection) {
+	if !object.Visible {
+		return intersects
+	}
+
+	intersects = object.Raycast( r, intersects )
+
+	if recursive {
+		children := object.Children
+		if selector, ok := object.Self.(raycastChildSelector); ok {
+			children = selector.RaycastChildren( r )
+		}
+		for _, child := range children {
+			intersects = r.intersectObject( child, intersects, true )
+		}
+	}
+
+	return intersects
+}
+
+// raycastChildSelector is implemented by objects whose Raycast already tests
+// some of their children, e.g. objects.LOD, to tell a recursive raycast which
+// children are left to visit.
+type raycastChildSelector interface {
+	RaycastChildren(raycaster *Raycaster) ([]*Object3D)
+}
+
+type byDistance []*Intersection
+
+func (a byDistance) Len() int           { return len(a) }
+func (a byDistance) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a byDistance) Less(i, j int) bool { return a[i].Distance < a[j].Distance }
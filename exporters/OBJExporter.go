@@ -0,0 +1,276 @@
+package exporters
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/objects"
+)
+
+// OBJExporter writes geometries and object hierarchies as Wavefront OBJ,
+// with the materials in a companion MTL file.
+type OBJExporter struct {
+	output *bytes.Buffer
+	materials *bytes.Buffer
+	indexVertex, indexUv, indexNormal int
+	materialNames map[string]string
+}
+
+func NewOBJExporter() (*OBJExporter) {
+	return &OBJExporter{}
+}
+
+func (e *OBJExporter) reset() {
+	e.output = &bytes.Buffer{}
+	e.materials = &bytes.Buffer{}
+	e.indexVertex = 0
+	e.indexUv = 0
+	e.indexNormal = 0
+	e.materialNames = make(map[string]string)
+}
+
+// ParseGeometry returns the OBJ text of a single geometry in its local space.
+func (e *OBJExporter) ParseGeometry(geometry *core.Geometry) string {
+	e.reset()
+	e.parseGeometry( geometry, geometry.Name, nil, nil )
+	return e.output.String()
+}
+
+// Parse returns the OBJ and MTL text of object and all its descendants, with
+// their world transforms applied. mtllib is the name the OBJ uses to refer to
+// the MTL file and may be empty.
+func (e *OBJExporter) Parse(object *core.Object3D, mtllib string) (string, string) {
+	e.reset()
+
+	if mtllib != "" {
+		fmt.Fprintf(e.output, "mtllib %s\n", mtllib)
+	}
+
+	object.UpdateMatrixWorld( true )
+
+	e.parseObject( object )
+
+	return e.output.String(), e.materials.String()
+}
+
+// Save writes the OBJ file to path and the MTL file next to it.
+func (e *OBJExporter) Save(object *core.Object3D, path string) error {
+	mtlPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".mtl"
+	obj, mtl := e.Parse( object, filepath.Base(mtlPath) )
+	if err := ioutil.WriteFile(path, []byte(obj), 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(mtlPath, []byte(mtl), 0644)
+}
+
+func (e *OBJExporter) parseObject(object *core.Object3D) {
+
+	if object.Geometry != nil {
+		var material *materials.Material
+		switch o := object.Self.(type) {
+		case *objects.Mesh:
+			material = o.Material
+		case *objects.SkinnedMesh:
+			material = o.Material
+		case *objects.InstancedMesh:
+			material = o.Material
+		case *objects.Line:
+			material = o.Material
+		case *objects.LineSegments:
+			material = o.Material
+		case *objects.LineLoop:
+			material = o.Material
+		case *objects.Points:
+			material = o.Material
+		}
+
+		var materialNames []string
+		if material != nil {
+			if multi, ok := material.Self.(*materials.MultiMaterial); ok {
+				// one name per material index
+				for _, subMaterial := range multi.Materials {
+					materialNames = append(materialNames, e.parseMaterial( subMaterial ))
+				}
+			} else {
+				materialNames = []string{ e.parseMaterial( material ) }
+			}
+		}
+
+		name := object.Name
+		if name == "" {
+			name = object.Geometry.Name
+		}
+		e.parseGeometry( object.Geometry, name, object.MatrixWorld, materialNames )
+	}
+
+	for _, child := range object.Children {
+		e.parseObject( child )
+	}
+}
+
+// parseMaterial adds a material to the MTL output once and returns its name.
+func (e *OBJExporter) parseMaterial(material *materials.Material) string {
+	if material == nil {
+		return ""
+	}
+	if name, ok := e.materialNames[ material.Uuid ]; ok {
+		return name
+	}
+
+	name := strings.Join(strings.Fields(material.Name), "_")
+	if name == "" {
+		name = fmt.Sprintf("material_%d", len(e.materialNames))
+	}
+	for _, other := range e.materialNames {
+		if other == name {
+			name = fmt.Sprintf("%s_%d", name, len(e.materialNames))
+			break
+		}
+	}
+	e.materialNames[ material.Uuid ] = name
+
+	fmt.Fprintf(e.materials, "newmtl %s\n", name)
+	if color := materialColor( material ); color != nil {
+		rgb := make([]float64, 3)
+		color.ToArray( rgb, 0 )
+		fmt.Fprintf(e.materials, "Kd %s %s %s\n", formatFloat(rgb[ 0 ]), formatFloat(rgb[ 1 ]), formatFloat(rgb[ 2 ]))
+	}
+	if material.Opacity < 1 {
+		fmt.Fprintf(e.materials, "d %s\n", formatFloat(material.Opacity))
+	}
+	fmt.Fprintf(e.materials, "illum 1\n\n")
+
+	return name
+}
+
+// materialColor returns the diffuse color of material, or nil if it has none.
+func materialColor(material *materials.Material) (*math3d.Color) {
+	switch m := material.Self.(type) {
+	case *materials.MeshBasicMaterial:
+		return m.Color
+	case *materials.LineBasicMaterial:
+		return m.Color
+	case *materials.LineDashedMaterial:
+		return m.Color
+	case *materials.PointsMaterial:
+		return m.Color
+	case *materials.SpriteMaterial:
+		return m.Color
+	}
+	return nil
+}
+
+// parseGeometry writes one object; matrix may be nil to keep the geometry in local space.
+// materialNames holds a single name used by all faces, or one name per material index.
+func (e *OBJExporter) parseGeometry(geometry *core.Geometry, name string, matrix *math3d.Matrix4, materialNames []string) {
+
+	if name == "" {
+		name = fmt.Sprintf("object_%d", e.indexVertex)
+	}
+	name = strings.Join(strings.Fields(name), "_")
+	fmt.Fprintf(e.output, "o %s\n", name)
+
+	var normalMatrix *math3d.Matrix3
+	if matrix != nil {
+		normalMatrix = math3d.NewMatrix3().GetNormalMatrix( matrix )
+	}
+
+	vertex := math3d.NewEmptyVector3()
+	for _, v := range geometry.Vertices {
+		vertex.Copy( v )
+		if matrix != nil {
+			vertex.ApplyMatrix4( matrix )
+		}
+		fmt.Fprintf(e.output, "v %s %s %s\n", formatFloat(vertex.X), formatFloat(vertex.Y), formatFloat(vertex.Z))
+	}
+
+	var faceUvs [][]*math3d.Vector2
+	if len(geometry.FaceVertexUvs) > 0 {
+		faceUvs = geometry.FaceVertexUvs[ 0 ]
+	}
+
+	// uvs and normals are written per face vertex, faces without uvs get -1
+	uvIndices := make([]int, len(geometry.Faces))
+	nUvs := 0
+	for i := range geometry.Faces {
+		uvIndices[ i ] = -1
+		if i < len(faceUvs) && len(faceUvs[ i ]) == 3 {
+			for _, uv := range faceUvs[ i ] {
+				fmt.Fprintf(e.output, "vt %s %s\n", formatFloat(uv.X), formatFloat(uv.Y))
+			}
+			uvIndices[ i ] = nUvs
+			nUvs += 3
+		}
+	}
+
+	normal := math3d.NewEmptyVector3()
+	for _, face := range geometry.Faces {
+		for j := 0; j < 3; j ++ {
+			if len(face.VertexNormals) == 3 {
+				normal.Copy( face.VertexNormals[ j ] )
+			} else {
+				normal.Copy( face.Normal )
+			}
+			if normalMatrix != nil {
+				normal.ApplyMatrix3( normalMatrix ).Normalize()
+			}
+			fmt.Fprintf(e.output, "vn %s %s %s\n", formatFloat(normal.X), formatFloat(normal.Y), formatFloat(normal.Z))
+		}
+	}
+
+	// one group per material index
+	order := make([]int, len(geometry.Faces))
+	for i := range order {
+		order[ i ] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return geometry.Faces[ order[ a ] ].MaterialIndex < geometry.Faces[ order[ b ] ].MaterialIndex
+	})
+
+	group := -1
+	for _, i := range order {
+		face := geometry.Faces[ i ]
+
+		if face.MaterialIndex != group || group == -1 {
+			group = face.MaterialIndex
+			fmt.Fprintf(e.output, "g %s_%d\n", name, group)
+
+			materialName := ""
+			if len(materialNames) == 1 {
+				materialName = materialNames[ 0 ]
+			} else if group >= 0 && group < len(materialNames) {
+				materialName = materialNames[ group ]
+			}
+			if materialName != "" {
+				fmt.Fprintf(e.output, "usemtl %s\n", materialName)
+			}
+		}
+
+		indices := []int{ face.A, face.B, face.C }
+		e.output.WriteString("f")
+		for j, index := range indices {
+			uv := ""
+			if uvIndices[ i ] >= 0 {
+				uv = strconv.Itoa(e.indexUv + uvIndices[ i ] + j + 1)
+			}
+			fmt.Fprintf(e.output, " %d/%s/%d", e.indexVertex + index + 1, uv, e.indexNormal + i * 3 + j + 1)
+		}
+		e.output.WriteString("\n")
+	}
+
+	e.indexVertex += len(geometry.Vertices)
+	e.indexUv += nUvs
+	e.indexNormal += len(geometry.Faces) * 3
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,45 @@
+package exporters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/objects"
+)
+
+func TestOBJExporterMultiMaterial(t *testing.T) {
+	geometry := core.NewGeometry()
+	geometry.Vertices = append(geometry.Vertices,
+		math3d.NewVector3( 0, 0, 0 ),
+		math3d.NewVector3( 1, 0, 0 ),
+		math3d.NewVector3( 0, 1, 0 ),
+	)
+	geometry.Faces = append(geometry.Faces, core.NewDefaultFace3( 0, 1, 2 ), core.NewDefaultFace3( 0, 2, 1 ))
+	geometry.Faces[ 1 ].MaterialIndex = 1
+	geometry.ComputeFaceNormals()
+
+	red := materials.NewMeshBasicMaterial(nil)
+	red.Name = "red"
+	red.Color.SetHex( 0xff0000 )
+	blue := materials.NewMeshBasicMaterial(nil)
+	blue.Name = "blue"
+
+	mesh := objects.NewMesh( geometry, materials.NewMultiMaterial( []*materials.Material{ red.Material, blue.Material } ).Material )
+	mesh.Name = "mesh"
+
+	obj, mtl := NewOBJExporter().Parse( mesh.Object3D, "" )
+
+	for _, want := range []string{ "g mesh_0\nusemtl red\n", "g mesh_1\nusemtl blue\n" } {
+		if !strings.Contains(obj, want) {
+			t.Errorf("obj has no %q:\n%s", want, obj)
+		}
+	}
+	for _, want := range []string{ "newmtl red\nKd 1 0 0\n", "newmtl blue\n" } {
+		if !strings.Contains(mtl, want) {
+			t.Errorf("mtl has no %q:\n%s", want, mtl)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Catmull-Rom 3D curve
+ **************************************************************/
+
+// The parametrizations of a CatmullRomCurve3.
+const (
+	CatmullRomCentripetal = "centripetal"
+	CatmullRomChordal = "chordal"
+	CatmullRomUniform = "catmullrom" // uses Tension
+)
+
+// CatmullRomCurve3 is a Catmull-Rom spline through Points. Unlike SplineCurve3
+// it supports closed curves and the centripetal and chordal parametrizations,
+// which don't overshoot or form cusps on unevenly spaced points.
+type CatmullRomCurve3 struct {
+	*Curve3
+	Points []*math3d.Vector3
+	Closed bool
+	Type string
+	Tension float64
+}
+
+func NewCatmullRomCurve3(points []*math3d.Vector3) (*CatmullRomCurve3) {
+	c := &CatmullRomCurve3{
+		Curve3: NewCurve3(),
+		Points: points,
+		Type: CatmullRomCentripetal,
+		Tension: 0.5,
+	}
+	c.GetPoint = c.getPoint
+	return c
+}
+
+func (c *CatmullRomCurve3) getPoint(t float64) (*math3d.Vector3) {
+
+	points := c.Points
+	l := len(points)
+
+	if l < 2 {
+		fmt.Println("THREE.CatmullRomCurve3: duh, you need at least 2 points")
+		return nil
+	}
+
+	segments := l - 1
+	if c.Closed {
+		segments = l
+	}
+
+	point := float64( segments ) * t
+	intPoint := int( math.Floor( point ) )
+	weight := point - float64( intPoint )
+
+	if c.Closed {
+		if intPoint <= 0 {
+			intPoint += ( int( math.Abs( float64( intPoint ) ) ) / l + 1 ) * l
+		}
+	} else if weight == 0 && intPoint == l - 1 {
+		intPoint = l - 2
+		weight = 1
+	}
+
+	var p0, p3 *math3d.Vector3 // 4 points
+
+	if c.Closed || intPoint > 0 {
+		p0 = points[ ( intPoint - 1 ) % l ]
+	} else {
+		// extrapolate first point
+		p0 = math3d.NewEmptyVector3().SubVectors( *points[ 0 ], *points[ 1 ] ).Add( points[ 0 ] )
+	}
+
+	p1 := points[ intPoint % l ]
+	p2 := points[ ( intPoint + 1 ) % l ]
+
+	if c.Closed || intPoint + 2 < l {
+		p3 = points[ ( intPoint + 2 ) % l ]
+	} else {
+		// extrapolate last point
+		p3 = math3d.NewEmptyVector3().SubVectors( *points[ l - 1 ], *points[ l - 2 ] ).Add( points[ l - 1 ] )
+	}
+
+	var px, py, pz cubicPoly
+
+	if c.Type == CatmullRomUniform {
+
+		px.initCatmullRom( p0.X, p1.X, p2.X, p3.X, c.Tension )
+		py.initCatmullRom( p0.Y, p1.Y, p2.Y, p3.Y, c.Tension )
+		pz.initCatmullRom( p0.Z, p1.Z, p2.Z, p3.Z, c.Tension )
+
+	} else {
+
+		// init Centripetal / Chordal Catmull-Rom
+		pow := 0.25
+		if c.Type == CatmullRomChordal {
+			pow = 0.5
+		}
+
+		dt0 := math.Pow( p0.DistanceToSquared( p1 ), pow )
+		dt1 := math.Pow( p1.DistanceToSquared( p2 ), pow )
+		dt2 := math.Pow( p2.DistanceToSquared( p3 ), pow )
+
+		// safety check for repeated points
+		if dt1 < 1e-4 {
+			dt1 = 1.0
+		}
+		if dt0 < 1e-4 {
+			dt0 = dt1
+		}
+		if dt2 < 1e-4 {
+			dt2 = dt1
+		}
+
+		px.initNonuniformCatmullRom( p0.X, p1.X, p2.X, p3.X, dt0, dt1, dt2 )
+		py.initNonuniformCatmullRom( p0.Y, p1.Y, p2.Y, p3.Y, dt0, dt1, dt2 )
+		pz.initNonuniformCatmullRom( p0.Z, p1.Z, p2.Z, p3.Z, dt0, dt1, dt2 )
+	}
+
+	return math3d.NewVector3( px.calc( weight ), py.calc( weight ), pz.calc( weight ) )
+}
+
+// cubicPoly is the cubic c0 + c1 * t + c2 * t^2 + c3 * t^3 of one coordinate
+// between two control points.
+type cubicPoly struct {
+	c0, c1, c2, c3 float64
+}
+
+// init computes the coefficients for a curve from x0 to x1 with tangents t0 and t1.
+func (p *cubicPoly) init(x0, x1, t0, t1 float64) {
+	p.c0 = x0
+	p.c1 = t0
+	p.c2 = - 3 * x0 + 3 * x1 - 2 * t0 - t1
+	p.c3 = 2 * x0 - 2 * x1 + t0 + t1
+}
+
+func (p *cubicPoly) initNonuniformCatmullRom(x0, x1, x2, x3, dt0, dt1, dt2 float64) {
+	// compute tangents when parameterized in [t1,t2]
+	t1 := ( x1 - x0 ) / dt0 - ( x2 - x0 ) / ( dt0 + dt1 ) + ( x2 - x1 ) / dt1
+	t2 := ( x2 - x1 ) / dt1 - ( x3 - x1 ) / ( dt1 + dt2 ) + ( x3 - x2 ) / dt2
+
+	// rescale tangents for parametrization in [0,1]
+	t1 *= dt1
+	t2 *= dt1
+
+	p.init( x1, x2, t1, t2 )
+}
+
+// initCatmullRom is the standard Catmull-Rom spline: interpolate between x1 and
+// x2 with previous/following points x0/x3.
+func (p *cubicPoly) initCatmullRom(x0, x1, x2, x3, tension float64) {
+	p.init( x1, x2, tension * ( x2 - x0 ), tension * ( x3 - x1 ) )
+}
+
+func (p *cubicPoly) calc(t float64) float64 {
+	t2 := t * t
+	t3 := t2 * t
+	return p.c0 + p.c1 * t + p.c2 * t2 + p.c3 * t3
+}
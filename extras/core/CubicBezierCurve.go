@@ -0,0 +1,41 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Cubic Bezier curve
+ **************************************************************/
+
+type CubicBezierCurve struct {
+	*Curve
+	V0, V1, V2, V3 *math3d.Vector2
+}
+
+func NewCubicBezierCurve(v0, v1, v2, v3 *math3d.Vector2) (*CubicBezierCurve) {
+	c := &CubicBezierCurve{
+		Curve: NewCurve(),
+		V0: v0,
+		V1: v1,
+		V2: v2,
+		V3: v3,
+	}
+	c.GetPoint = c.getPoint
+	c.GetTangent = c.getTangent
+	return c
+}
+
+func (c *CubicBezierCurve) getPoint(t float64) (*math3d.Vector2) {
+	return math3d.NewVector2(
+		ShapeUtils.B3( t, c.V0.X, c.V1.X, c.V2.X, c.V3.X ),
+		ShapeUtils.B3( t, c.V0.Y, c.V1.Y, c.V2.Y, c.V3.Y ),
+	)
+}
+
+func (c *CubicBezierCurve) getTangent(t float64) (*math3d.Vector2) {
+	return math3d.NewVector2(
+		CurveUtils.TangentCubicBezier( t, c.V0.X, c.V1.X, c.V2.X, c.V3.X ),
+		CurveUtils.TangentCubicBezier( t, c.V0.Y, c.V1.Y, c.V2.Y, c.V3.Y ),
+	).Normalize()
+}
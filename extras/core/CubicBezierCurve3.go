@@ -0,0 +1,43 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Cubic Bezier 3D curve
+ **************************************************************/
+
+type CubicBezierCurve3 struct {
+	*Curve3
+	V0, V1, V2, V3 *math3d.Vector3
+}
+
+func NewCubicBezierCurve3(v0, v1, v2, v3 *math3d.Vector3) (*CubicBezierCurve3) {
+	c := &CubicBezierCurve3{
+		Curve3: NewCurve3(),
+		V0: v0,
+		V1: v1,
+		V2: v2,
+		V3: v3,
+	}
+	c.GetPoint = c.getPoint
+	c.GetTangent = c.getTangent
+	return c
+}
+
+func (c *CubicBezierCurve3) getPoint(t float64) (*math3d.Vector3) {
+	return math3d.NewVector3(
+		ShapeUtils.B3( t, c.V0.X, c.V1.X, c.V2.X, c.V3.X ),
+		ShapeUtils.B3( t, c.V0.Y, c.V1.Y, c.V2.Y, c.V3.Y ),
+		ShapeUtils.B3( t, c.V0.Z, c.V1.Z, c.V2.Z, c.V3.Z ),
+	)
+}
+
+func (c *CubicBezierCurve3) getTangent(t float64) (*math3d.Vector3) {
+	return math3d.NewVector3(
+		CurveUtils.TangentCubicBezier( t, c.V0.X, c.V1.X, c.V2.X, c.V3.X ),
+		CurveUtils.TangentCubicBezier( t, c.V0.Y, c.V1.Y, c.V2.Y, c.V3.Y ),
+		CurveUtils.TangentCubicBezier( t, c.V0.Z, c.V1.Z, c.V2.Z, c.V3.Z ),
+	).Normalize()
+}
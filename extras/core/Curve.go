@@ -0,0 +1,190 @@
+// Package core holds the 2D curve, path and shape types used to build
+// geometries from outlines.
+package core
+
+import (
+	"fmt"
+	"math"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Abstract Curve base class
+ **************************************************************/
+
+// Curve is the base of all curves. Implementations replace GetPoint, which
+// maps t in [0, 1] to a point on the curve; everything else is derived from it.
+// GetTangent may be replaced by curves that can compute it exactly.
+//
+// GetPointAt and GetSpacedPoints use the arc length instead of t, so points are
+// spaced evenly along the curve.
+type Curve struct {
+	GetPoint func(t float64) (*math3d.Vector2)
+	GetTangent func(t float64) (*math3d.Vector2)
+
+	ArcLengthDivisions int
+	NeedsUpdate bool
+	cacheArcLengths []float64
+}
+
+func NewCurve() (*Curve) {
+	c := &Curve{
+		ArcLengthDivisions: 200,
+	}
+	c.GetPoint = c.getPoint
+	c.GetTangent = c.getTangent
+	return c
+}
+
+func (c *Curve) getPoint(t float64) (*math3d.Vector2) {
+	fmt.Println("THREE.Curve: Warning, GetPoint() not implemented!")
+	return nil
+}
+
+// GetPointAt returns a point at u, where u is a fraction of the curve's arc length.
+func (c *Curve) GetPointAt(u float64) (*math3d.Vector2) {
+	t := c.GetUtoTmapping( u, -1 )
+	return c.GetPoint( t )
+}
+
+// GetPoints samples the curve at divisions + 1 values of t.
+func (c *Curve) GetPoints(divisions int) ([]*math3d.Vector2) {
+	points := make([]*math3d.Vector2, 0, divisions + 1)
+	for d := 0; d <= divisions; d ++ {
+		points = append(points, c.GetPoint( float64( d ) / float64( divisions ) ))
+	}
+	return points
+}
+
+// GetSpacedPoints samples the curve at divisions + 1 equidistant points.
+func (c *Curve) GetSpacedPoints(divisions int) ([]*math3d.Vector2) {
+	points := make([]*math3d.Vector2, 0, divisions + 1)
+	for d := 0; d <= divisions; d ++ {
+		points = append(points, c.GetPointAt( float64( d ) / float64( divisions ) ))
+	}
+	return points
+}
+
+// GetLength returns the total arc length of the curve.
+func (c *Curve) GetLength() float64 {
+	lengths := c.GetLengths()
+	return lengths[ len(lengths) - 1 ]
+}
+
+// GetLengths returns the cumulative segment lengths of ArcLengthDivisions samples.
+func (c *Curve) GetLengths() ([]float64) {
+	divisions := c.ArcLengthDivisions
+
+	if len(c.cacheArcLengths) == divisions + 1 && !c.NeedsUpdate {
+		return c.cacheArcLengths
+	}
+
+	c.NeedsUpdate = false
+
+	cache := make([]float64, 0, divisions + 1)
+	last := c.GetPoint( 0 )
+	sum := 0.0
+
+	cache = append(cache, 0)
+
+	for p := 1; p <= divisions; p ++ {
+		current := c.GetPoint( float64( p ) / float64( divisions ) )
+		sum += current.DistanceTo( last )
+		cache = append(cache, sum)
+		last = current
+	}
+
+	c.cacheArcLengths = cache
+
+	return cache
+}
+
+func (c *Curve) UpdateArcLengths() {
+	c.NeedsUpdate = true
+	c.GetLengths()
+}
+
+// GetUtoTmapping maps u (a fraction of the arc length) to t. If distance is
+// not negative it is used as the target arc length instead of u.
+func (c *Curve) GetUtoTmapping(u, distance float64) float64 {
+	return getUtoTmapping( c.GetLengths(), u, distance )
+}
+
+// getTangent returns a unit vector tangent to the curve at t, found by sampling
+// two points close to it.
+func (c *Curve) getTangent(t float64) (*math3d.Vector2) {
+
+	delta := 0.0001
+	t1 := math.Max( t - delta, 0 )
+	t2 := math.Min( t + delta, 1 )
+
+	pt1 := c.GetPoint( t1 )
+	pt2 := c.GetPoint( t2 )
+
+	return pt2.Clone().Sub( pt1 ).Normalize()
+}
+
+func (c *Curve) GetTangentAt(u float64) (*math3d.Vector2) {
+	t := c.GetUtoTmapping( u, -1 )
+	return c.GetTangent( t )
+}
+
+// getUtoTmapping is shared by the 2D and 3D curves.
+func getUtoTmapping(arcLengths []float64, u, distance float64) float64 {
+
+	il := len(arcLengths)
+
+	var targetArcLength float64 // The targeted u distance value to get
+
+	if distance >= 0 {
+		targetArcLength = distance
+	} else {
+		targetArcLength = u * arcLengths[ il - 1 ]
+	}
+
+	// binary search for the index with largest value smaller than target u distance
+
+	low := 0
+	high := il - 1
+
+	for low <= high {
+
+		i := low + ( high - low ) / 2
+
+		comparison := arcLengths[ i ] - targetArcLength
+
+		if comparison < 0 {
+			low = i + 1
+		} else if comparison > 0 {
+			high = i - 1
+		} else {
+			high = i
+			break
+		}
+	}
+
+	i := high
+	if i < 0 {
+		return 0
+	}
+
+	if arcLengths[ i ] == targetArcLength || i == il - 1 {
+		return float64( i ) / float64( il - 1 )
+	}
+
+	// we could get finer grain at lengths, or use simple interpolation between two points
+
+	lengthBefore := arcLengths[ i ]
+	lengthAfter := arcLengths[ i + 1 ]
+
+	segmentLength := lengthAfter - lengthBefore
+
+	// determine where we are between the 'before' and 'after' points
+
+	segmentFraction := ( targetArcLength - lengthBefore ) / segmentLength
+
+	// add that fractional amount to t
+
+	return ( float64( i ) + segmentFraction ) / float64( il - 1 )
+}
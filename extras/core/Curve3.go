@@ -0,0 +1,131 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Abstract Curve3 base class
+ **************************************************************/
+
+// Curve3 is the 3D counterpart of Curve. Implementations replace GetPoint,
+// which maps t in [0, 1] to a point on the curve.
+type Curve3 struct {
+	GetPoint func(t float64) (*math3d.Vector3)
+	GetTangent func(t float64) (*math3d.Vector3)
+
+	ArcLengthDivisions int
+	NeedsUpdate bool
+	cacheArcLengths []float64
+}
+
+func NewCurve3() (*Curve3) {
+	c := &Curve3{
+		ArcLengthDivisions: 200,
+	}
+	c.GetPoint = c.getPoint
+	c.GetTangent = c.getTangent
+	return c
+}
+
+func (c *Curve3) getPoint(t float64) (*math3d.Vector3) {
+	fmt.Println("THREE.Curve3: Warning, GetPoint() not implemented!")
+	return nil
+}
+
+// GetPointAt returns a point at u, where u is a fraction of the curve's arc length.
+func (c *Curve3) GetPointAt(u float64) (*math3d.Vector3) {
+	t := c.GetUtoTmapping( u, -1 )
+	return c.GetPoint( t )
+}
+
+// GetPoints samples the curve at divisions + 1 values of t.
+func (c *Curve3) GetPoints(divisions int) ([]*math3d.Vector3) {
+	points := make([]*math3d.Vector3, 0, divisions + 1)
+	for d := 0; d <= divisions; d ++ {
+		points = append(points, c.GetPoint( float64( d ) / float64( divisions ) ))
+	}
+	return points
+}
+
+// GetSpacedPoints samples the curve at divisions + 1 equidistant points.
+func (c *Curve3) GetSpacedPoints(divisions int) ([]*math3d.Vector3) {
+	points := make([]*math3d.Vector3, 0, divisions + 1)
+	for d := 0; d <= divisions; d ++ {
+		points = append(points, c.GetPointAt( float64( d ) / float64( divisions ) ))
+	}
+	return points
+}
+
+// GetLength returns the total arc length of the curve.
+func (c *Curve3) GetLength() float64 {
+	lengths := c.GetLengths()
+	return lengths[ len(lengths) - 1 ]
+}
+
+// GetLengths returns the cumulative segment lengths of ArcLengthDivisions samples.
+func (c *Curve3) GetLengths() ([]float64) {
+	divisions := c.ArcLengthDivisions
+
+	if len(c.cacheArcLengths) == divisions + 1 && !c.NeedsUpdate {
+		return c.cacheArcLengths
+	}
+
+	c.NeedsUpdate = false
+
+	cache := make([]float64, 0, divisions + 1)
+	last := c.GetPoint( 0 )
+	sum := 0.0
+
+	cache = append(cache, 0)
+
+	for p := 1; p <= divisions; p ++ {
+		current := c.GetPoint( float64( p ) / float64( divisions ) )
+		sum += current.DistanceTo( last )
+		cache = append(cache, sum)
+		last = current
+	}
+
+	c.cacheArcLengths = cache
+
+	return cache
+}
+
+func (c *Curve3) UpdateArcLengths() {
+	c.NeedsUpdate = true
+	c.GetLengths()
+}
+
+// GetUtoTmapping maps u (a fraction of the arc length) to t. If distance is
+// not negative it is used as the target arc length instead of u.
+func (c *Curve3) GetUtoTmapping(u, distance float64) float64 {
+	return getUtoTmapping( c.GetLengths(), u, distance )
+}
+
+// getTangent returns a unit vector tangent to the curve at t, found by sampling
+// two points close to it.
+func (c *Curve3) getTangent(t float64) (*math3d.Vector3) {
+
+	delta := 0.0001
+	t1 := math.Max( t - delta, 0 )
+	t2 := math.Min( t + delta, 1 )
+
+	pt1 := c.GetPoint( t1 )
+	pt2 := c.GetPoint( t2 )
+
+	return pt2.Clone().Sub( *pt1 ).Normalize()
+}
+
+func (c *Curve3) GetTangentAt(u float64) (*math3d.Vector3) {
+	t := c.GetUtoTmapping( u, -1 )
+	return c.GetTangent( t )
+}
+
+// ComputeFrenetFrames returns the tangents, normals and binormals at
+// segments + 1 evenly spaced points along the curve.
+func (c *Curve3) ComputeFrenetFrames(segments int, closed bool) (*FrenetFrames) {
+	return NewFrenetFrames( c, segments, closed )
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Curved Path - a curve path is simply a array of connected
+ *  curves, but retains the api of a curve
+ **************************************************************/
+
+type CurvePath struct {
+	*Curve
+	Curves []*Curve
+	AutoClose bool // Automatically closes the path
+
+	cacheLengths []float64
+}
+
+func NewCurvePath() (*CurvePath) {
+	c := &CurvePath{
+		Curve: NewCurve(),
+		Curves: make([]*Curve, 0),
+	}
+	c.GetPoint = c.getPoint
+	return c
+}
+
+// Add appends a curve, e.g. the Curve of a LineCurve or EllipseCurve.
+func (c *CurvePath) Add(curve *Curve) {
+	c.Curves = append(c.Curves, curve)
+	c.cacheLengths = nil
+}
+
+// ClosePath adds a line from the end of the last curve to the start of the first one.
+func (c *CurvePath) ClosePath() {
+	if len(c.Curves) == 0 {
+		return
+	}
+
+	startPoint := c.Curves[ 0 ].GetPoint( 0 )
+	endPoint := c.Curves[ len(c.Curves) - 1 ].GetPoint( 1 )
+
+	if !startPoint.Equals( endPoint ) {
+		c.Add( NewLineCurve( endPoint, startPoint ).Curve )
+	}
+}
+
+// getPoint returns the point at t, where t is a fraction of the length of the
+// whole path.
+func (c *CurvePath) getPoint(t float64) (*math3d.Vector2) {
+
+	d := t * c.GetLength()
+	curveLengths := c.GetCurveLengths()
+
+	// To think about boundaries points.
+	for i, length := range curveLengths {
+
+		if length >= d {
+
+			diff := length - d
+			curve := c.Curves[ i ]
+
+			u := 1 - diff / curve.GetLength()
+
+			return curve.GetPointAt( u )
+		}
+	}
+
+	return nil
+}
+
+// GetLength returns the sum of the lengths of the curves.
+func (c *CurvePath) GetLength() float64 {
+	lengths := c.GetCurveLengths()
+	if len(lengths) == 0 {
+		return 0
+	}
+	return lengths[ len(lengths) - 1 ]
+}
+
+// GetCurveLengths returns the cumulative lengths of the curves.
+func (c *CurvePath) GetCurveLengths() ([]float64) {
+
+	// We use cache values if curves and cache array are same length
+
+	if len(c.cacheLengths) == len(c.Curves) {
+		return c.cacheLengths
+	}
+
+	// Get length of sub-curve
+	// Push sums into cached array
+
+	lengths := make([]float64, 0, len(c.Curves))
+	sums := 0.0
+
+	for _, curve := range c.Curves {
+		sums += curve.GetLength()
+		lengths = append(lengths, sums)
+	}
+
+	c.cacheLengths = lengths
+
+	return lengths
+}
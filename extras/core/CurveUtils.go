@@ -0,0 +1,27 @@
+package core
+
+// CurveUtils holds the polynomials the curves are built from.
+var CurveUtils = curveUtils{}
+
+type curveUtils struct{}
+
+func (curveUtils) TangentQuadraticBezier(t, p0, p1, p2 float64) float64 {
+	return 2 * ( 1 - t ) * ( p1 - p0 ) + 2 * t * ( p2 - p1 )
+}
+
+// Puay Bing, thanks for helping with this derivative!
+func (curveUtils) TangentCubicBezier(t, p0, p1, p2, p3 float64) float64 {
+	return - 3 * p0 * ( 1 - t ) * ( 1 - t ) +
+		3 * p1 * ( 1 - t ) * ( 1 - t ) - 6 * t * p1 * ( 1 - t ) +
+		6 * t * p2 * ( 1 - t ) - 3 * t * t * p2 +
+		3 * t * t * p3
+}
+
+// Interpolate is the Catmull-Rom spline through p1 and p2.
+func (curveUtils) Interpolate(p0, p1, p2, p3, t float64) float64 {
+	v0 := ( p2 - p0 ) * 0.5
+	v1 := ( p3 - p1 ) * 0.5
+	t2 := t * t
+	t3 := t * t2
+	return ( 2 * p1 - 2 * p2 + v0 + v1 ) * t3 + ( - 3 * p1 + 3 * p2 - 2 * v0 - v1 ) * t2 + v0 * t + p1
+}
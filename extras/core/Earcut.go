@@ -0,0 +1,506 @@
+package core
+
+import (
+	"math"
+	"sort"
+)
+
+// Earcut triangulates polygons with holes by ear clipping.
+// Port of https://github.com/mapbox/earcut (ISC license), without the z-order
+// hashing it uses to speed up very large polygons.
+var Earcut = earcut{}
+
+type earcut struct{}
+
+type earcutNode struct {
+	// vertex index in the coordinates array
+	i int
+
+	// vertex coordinates
+	x, y float64
+
+	// previous and next vertex nodes in a polygon ring
+	prev, next *earcutNode
+
+	// indicates whether this is a steiner point
+	steiner bool
+}
+
+// Triangulate takes flat x, y coordinates and the indices of the first vertex
+// of every hole, and returns the triangles as vertex indices.
+func (earcut) Triangulate(data []float64, holeIndices []int) ([]int) {
+
+	outerLen := len(data)
+	if len(holeIndices) > 0 {
+		outerLen = holeIndices[ 0 ] * 2
+	}
+
+	outerNode := earcutLinkedList( data, 0, outerLen, true )
+	triangles := make([]int, 0)
+
+	if outerNode == nil {
+		return triangles
+	}
+
+	if len(holeIndices) > 0 {
+		outerNode = earcutEliminateHoles( data, holeIndices, outerNode )
+	}
+
+	earcutLinked( outerNode, &triangles, 0 )
+
+	return triangles
+}
+
+// create a circular doubly linked list from polygon points in the specified winding order
+func earcutLinkedList(data []float64, start, end int, clockwise bool) (*earcutNode) {
+	var last *earcutNode
+
+	if clockwise == ( earcutSignedArea( data, start, end ) > 0 ) {
+		for i := start; i < end; i += 2 {
+			last = earcutInsertNode( i, data[ i ], data[ i + 1 ], last )
+		}
+	} else {
+		for i := end - 2; i >= start; i -= 2 {
+			last = earcutInsertNode( i, data[ i ], data[ i + 1 ], last )
+		}
+	}
+
+	if last != nil && earcutEquals( last, last.next ) {
+		earcutRemoveNode( last )
+		last = last.next
+	}
+
+	return last
+}
+
+// eliminate colinear or duplicate points
+func earcutFilterPoints(start, end *earcutNode) (*earcutNode) {
+	if start == nil {
+		return start
+	}
+	if end == nil {
+		end = start
+	}
+
+	p := start
+	for {
+		again := false
+
+		if !p.steiner && ( earcutEquals( p, p.next ) || earcutArea( p.prev, p, p.next ) == 0 ) {
+			earcutRemoveNode( p )
+			p = p.prev
+			end = p
+			if p == p.next {
+				break
+			}
+			again = true
+		} else {
+			p = p.next
+		}
+
+		if !again && p == end {
+			break
+		}
+	}
+
+	return end
+}
+
+// main ear slicing loop which triangulates a polygon (given as a linked list)
+func earcutLinked(ear *earcutNode, triangles *[]int, pass int) {
+	if ear == nil {
+		return
+	}
+
+	stop := ear
+
+	// iterate through ears, slicing them one by one
+	for ear.prev != ear.next {
+		prev := ear.prev
+		next := ear.next
+
+		if earcutIsEar( ear ) {
+			// cut off the triangle
+			*triangles = append(*triangles, prev.i / 2, ear.i / 2, next.i / 2)
+
+			earcutRemoveNode( ear )
+
+			// skipping the next vertex leads to less sliver triangles
+			ear = next.next
+			stop = next.next
+
+			continue
+		}
+
+		ear = next
+
+		// if we looped through the whole remaining polygon and can't find any more ears
+		if ear == stop {
+			switch pass {
+			case 0:
+				// try filtering points and slicing again
+				earcutLinked( earcutFilterPoints( ear, nil ), triangles, 1 )
+			case 1:
+				// if this didn't work, try curing all small self-intersections locally
+				ear = earcutCureLocalIntersections( ear, triangles )
+				earcutLinked( ear, triangles, 2 )
+			case 2:
+				// as a last resort, try splitting the remaining polygon into two
+				earcutSplit( ear, triangles )
+			}
+
+			break
+		}
+	}
+}
+
+// check whether a polygon node forms a valid ear with adjacent nodes
+func earcutIsEar(ear *earcutNode) bool {
+	a := ear.prev
+	b := ear
+	c := ear.next
+
+	if earcutArea( a, b, c ) >= 0 {
+		return false // reflex, can't be an ear
+	}
+
+	// now make sure we don't have other points inside the potential ear
+	p := ear.next.next
+
+	for p != ear.prev {
+		if earcutPointInTriangle( a.x, a.y, b.x, b.y, c.x, c.y, p.x, p.y ) && earcutArea( p.prev, p, p.next ) >= 0 {
+			return false
+		}
+		p = p.next
+	}
+
+	return true
+}
+
+// go through all polygon nodes and cure small local self-intersections
+func earcutCureLocalIntersections(start *earcutNode, triangles *[]int) (*earcutNode) {
+	p := start
+	for {
+		a := p.prev
+		b := p.next.next
+
+		if !earcutEquals( a, b ) && earcutIntersects( a, p, p.next, b ) && earcutLocallyInside( a, b ) && earcutLocallyInside( b, a ) {
+			*triangles = append(*triangles, a.i / 2, p.i / 2, b.i / 2)
+
+			// remove two nodes involved
+			earcutRemoveNode( p )
+			earcutRemoveNode( p.next )
+
+			p = b
+			start = b
+		}
+		p = p.next
+
+		if p == start {
+			break
+		}
+	}
+
+	return p
+}
+
+// try splitting polygon into two and triangulate them independently
+func earcutSplit(start *earcutNode, triangles *[]int) {
+	// look for a valid diagonal that divides the polygon into two
+	a := start
+	for {
+		b := a.next.next
+		for b != a.prev {
+			if a.i != b.i && earcutIsValidDiagonal( a, b ) {
+				// split the polygon in two by the diagonal
+				c := earcutSplitPolygon( a, b )
+
+				// filter colinear points around the cuts
+				a = earcutFilterPoints( a, a.next )
+				c = earcutFilterPoints( c, c.next )
+
+				// run earcut on each half
+				earcutLinked( a, triangles, 0 )
+				earcutLinked( c, triangles, 0 )
+				return
+			}
+			b = b.next
+		}
+		a = a.next
+
+		if a == start {
+			break
+		}
+	}
+}
+
+// link every hole into the outer loop, producing a single-ring polygon without holes
+func earcutEliminateHoles(data []float64, holeIndices []int, outerNode *earcutNode) (*earcutNode) {
+	queue := make([]*earcutNode, 0, len(holeIndices))
+
+	for i, holeIndex := range holeIndices {
+		start := holeIndex * 2
+		end := len(data)
+		if i < len(holeIndices) - 1 {
+			end = holeIndices[ i + 1 ] * 2
+		}
+
+		list := earcutLinkedList( data, start, end, false )
+		if list == nil {
+			continue
+		}
+		if list == list.next {
+			list.steiner = true
+		}
+		queue = append(queue, earcutGetLeftmost( list ))
+	}
+
+	sort.SliceStable(queue, func(a, b int) bool {
+		return queue[ a ].x < queue[ b ].x
+	})
+
+	// process holes from left to right
+	for _, hole := range queue {
+		earcutEliminateHole( hole, outerNode )
+		outerNode = earcutFilterPoints( outerNode, outerNode.next )
+	}
+
+	return outerNode
+}
+
+// find a bridge between vertices that connects hole with an outer ring and link it
+func earcutEliminateHole(hole, outerNode *earcutNode) {
+	outerNode = earcutFindHoleBridge( hole, outerNode )
+	if outerNode != nil {
+		b := earcutSplitPolygon( outerNode, hole )
+		earcutFilterPoints( b, b.next )
+	}
+}
+
+// David Eberly's algorithm for finding a bridge between hole and outer polygon
+func earcutFindHoleBridge(hole, outerNode *earcutNode) (*earcutNode) {
+	p := outerNode
+	hx := hole.x
+	hy := hole.y
+	qx := math.Inf( -1 )
+	var m *earcutNode
+
+	// find a segment intersected by a ray from the hole's leftmost point to the left;
+	// segment's endpoint with lesser x will be potential connection point
+	for {
+		if hy <= p.y && hy >= p.next.y && p.next.y != p.y {
+			x := p.x + ( hy - p.y ) * ( p.next.x - p.x ) / ( p.next.y - p.y )
+			if x <= hx && x > qx {
+				qx = x
+				if x == hx {
+					if hy == p.y {
+						return p
+					}
+					if hy == p.next.y {
+						return p.next
+					}
+				}
+				if p.x < p.next.x {
+					m = p
+				} else {
+					m = p.next
+				}
+			}
+		}
+		p = p.next
+
+		if p == outerNode {
+			break
+		}
+	}
+
+	if m == nil {
+		return nil
+	}
+
+	if hx == qx {
+		return m.prev // hole touches outer segment; pick lower endpoint
+	}
+
+	// look for points inside the triangle of hole point, segment intersection and endpoint;
+	// if there are no points found, we have a valid connection;
+	// otherwise choose the point of the minimum angle with the ray as connection point
+
+	stop := m
+	mx := m.x
+	my := m.y
+	tanMin := math.Inf( 1 )
+
+	p = m.next
+
+	for p != stop {
+		ax, cx := qx, hx
+		if hy < my {
+			ax, cx = hx, qx
+		}
+		if hx >= p.x && p.x >= mx && hx != p.x && earcutPointInTriangle( ax, hy, mx, my, cx, hy, p.x, p.y ) {
+
+			tan := math.Abs( hy - p.y ) / ( hx - p.x ) // tangential
+
+			if ( tan < tanMin || ( tan == tanMin && p.x > m.x ) ) && earcutLocallyInside( p, hole ) {
+				m = p
+				tanMin = tan
+			}
+		}
+
+		p = p.next
+	}
+
+	return m
+}
+
+// find the leftmost node of a polygon ring
+func earcutGetLeftmost(start *earcutNode) (*earcutNode) {
+	p := start
+	leftmost := start
+	for {
+		if p.x < leftmost.x {
+			leftmost = p
+		}
+		p = p.next
+
+		if p == start {
+			break
+		}
+	}
+
+	return leftmost
+}
+
+// check if a point lies within a convex triangle
+func earcutPointInTriangle(ax, ay, bx, by, cx, cy, px, py float64) bool {
+	return ( cx - px ) * ( ay - py ) - ( ax - px ) * ( cy - py ) >= 0 &&
+		( ax - px ) * ( by - py ) - ( bx - px ) * ( ay - py ) >= 0 &&
+		( bx - px ) * ( cy - py ) - ( cx - px ) * ( by - py ) >= 0
+}
+
+// check if a diagonal between two polygon nodes is valid (lies in polygon interior)
+func earcutIsValidDiagonal(a, b *earcutNode) bool {
+	return a.next.i != b.i && a.prev.i != b.i && !earcutIntersectsPolygon( a, b ) &&
+		earcutLocallyInside( a, b ) && earcutLocallyInside( b, a ) && earcutMiddleInside( a, b )
+}
+
+// signed area of a triangle
+func earcutArea(p, q, r *earcutNode) float64 {
+	return ( q.y - p.y ) * ( r.x - q.x ) - ( q.x - p.x ) * ( r.y - q.y )
+}
+
+// check if two points are equal
+func earcutEquals(p1, p2 *earcutNode) bool {
+	return p1.x == p2.x && p1.y == p2.y
+}
+
+// check if two segments intersect
+func earcutIntersects(p1, q1, p2, q2 *earcutNode) bool {
+	if ( earcutEquals( p1, q1 ) && earcutEquals( p2, q2 ) ) || ( earcutEquals( p1, q2 ) && earcutEquals( p2, q1 ) ) {
+		return true
+	}
+
+	return ( earcutArea( p1, q1, p2 ) > 0 ) != ( earcutArea( p1, q1, q2 ) > 0 ) &&
+		( earcutArea( p2, q2, p1 ) > 0 ) != ( earcutArea( p2, q2, q1 ) > 0 )
+}
+
+// check if a polygon diagonal intersects any polygon segments
+func earcutIntersectsPolygon(a, b *earcutNode) bool {
+	p := a
+	for {
+		if p.i != a.i && p.next.i != a.i && p.i != b.i && p.next.i != b.i && earcutIntersects( p, p.next, a, b ) {
+			return true
+		}
+		p = p.next
+
+		if p == a {
+			break
+		}
+	}
+
+	return false
+}
+
+// check if a polygon diagonal is locally inside the polygon
+func earcutLocallyInside(a, b *earcutNode) bool {
+	if earcutArea( a.prev, a, a.next ) < 0 {
+		return earcutArea( a, b, a.next ) >= 0 && earcutArea( a, a.prev, b ) >= 0
+	}
+	return earcutArea( a, b, a.prev ) < 0 || earcutArea( a, a.next, b ) < 0
+}
+
+// check if the middle point of a polygon diagonal is inside the polygon
+func earcutMiddleInside(a, b *earcutNode) bool {
+	p := a
+	inside := false
+	px := ( a.x + b.x ) / 2
+	py := ( a.y + b.y ) / 2
+
+	for {
+		if ( ( p.y > py ) != ( p.next.y > py ) ) && p.next.y != p.y && ( px < ( p.next.x - p.x ) * ( py - p.y ) / ( p.next.y - p.y ) + p.x ) {
+			inside = !inside
+		}
+		p = p.next
+
+		if p == a {
+			break
+		}
+	}
+
+	return inside
+}
+
+// link two polygon vertices with a bridge; if the vertices belong to the same ring, it splits polygon into two;
+// if one belongs to the outer ring and another to a hole, it merges it into a single ring
+func earcutSplitPolygon(a, b *earcutNode) (*earcutNode) {
+	a2 := &earcutNode{ i: a.i, x: a.x, y: a.y }
+	b2 := &earcutNode{ i: b.i, x: b.x, y: b.y }
+	an := a.next
+	bp := b.prev
+
+	a.next = b
+	b.prev = a
+
+	a2.next = an
+	an.prev = a2
+
+	b2.next = a2
+	a2.prev = b2
+
+	bp.next = b2
+	b2.prev = bp
+
+	return b2
+}
+
+// create a node and optionally link it with previous one (in a circular doubly linked list)
+func earcutInsertNode(i int, x, y float64, last *earcutNode) (*earcutNode) {
+	p := &earcutNode{ i: i, x: x, y: y }
+
+	if last == nil {
+		p.prev = p
+		p.next = p
+	} else {
+		p.next = last.next
+		p.prev = last
+		last.next.prev = p
+		last.next = p
+	}
+
+	return p
+}
+
+func earcutRemoveNode(p *earcutNode) {
+	p.next.prev = p.prev
+	p.prev.next = p.next
+}
+
+func earcutSignedArea(data []float64, start, end int) float64 {
+	sum := 0.0
+	for i, j := start, end - 2; i < end; i += 2 {
+		sum += ( data[ j ] - data[ i ] ) * ( data[ i + 1 ] + data[ j + 1 ] )
+		j = i
+	}
+	return sum
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+// triangulatedArea returns the summed area of the triangles over data.
+func triangulatedArea(data []float64, triangles []int) float64 {
+	area := 0.0
+	for i := 0; i + 2 < len(triangles); i += 3 {
+		a, b, c := triangles[ i ] * 2, triangles[ i + 1 ] * 2, triangles[ i + 2 ] * 2
+		area += math.Abs( ( data[ b ] - data[ a ] ) * ( data[ c + 1 ] - data[ a + 1 ] ) - ( data[ c ] - data[ a ] ) * ( data[ b + 1 ] - data[ a + 1 ] ) ) / 2
+	}
+	return area
+}
+
+func TestEarcutTriangulate(t *testing.T) {
+	cases := []struct {
+		name string
+		data []float64
+		holeIndices []int
+		triangles int
+		area float64
+	}{
+		{ "square", []float64{ 0,0, 1,0, 1,1, 0,1 }, nil, 2, 1 },
+		{ "clockwise square", []float64{ 0,0, 0,1, 1,1, 1,0 }, nil, 2, 1 },
+		{ "concave", []float64{ 0,0, 2,0, 2,1, 1,1, 1,2, 0,2 }, nil, 4, 3 },
+		{ "square with hole", []float64{ 0,0, 3,0, 3,3, 0,3, 1,1, 1,2, 2,2, 2,1 }, []int{ 4 }, 8, 8 },
+		{ "degenerate", []float64{ 0,0, 1,0, 2,0 }, nil, 0, 0 },
+	}
+
+	for _, c := range cases {
+		triangles := Earcut.Triangulate( c.data, c.holeIndices )
+
+		if len(triangles) != c.triangles * 3 {
+			t.Errorf("%s: got %d triangles, want %d", c.name, len(triangles) / 3, c.triangles)
+			continue
+		}
+		if area := triangulatedArea( c.data, triangles ); math.Abs( area - c.area ) > 1e-9 {
+			t.Errorf("%s: triangles cover %v, want %v", c.name, area, c.area)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"math"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Ellipse curve
+ **************************************************************/
+
+type EllipseCurve struct {
+	*Curve
+	AX, AY float64
+	XRadius, YRadius float64
+	AStartAngle, AEndAngle float64
+	AClockwise bool
+	ARotation float64
+}
+
+func NewEllipseCurve(aX, aY, xRadius, yRadius, aStartAngle, aEndAngle float64, aClockwise bool, aRotation float64) (*EllipseCurve) {
+	c := &EllipseCurve{
+		Curve: NewCurve(),
+		AX: aX,
+		AY: aY,
+		XRadius: xRadius,
+		YRadius: yRadius,
+		AStartAngle: aStartAngle,
+		AEndAngle: aEndAngle,
+		AClockwise: aClockwise,
+		ARotation: aRotation,
+	}
+	c.GetPoint = c.getPoint
+	return c
+}
+
+func (c *EllipseCurve) getPoint(t float64) (*math3d.Vector2) {
+
+	deltaAngle := c.AEndAngle - c.AStartAngle
+
+	if deltaAngle < 0 {
+		deltaAngle += math.Pi * 2
+	}
+	if deltaAngle > math.Pi * 2 {
+		deltaAngle -= math.Pi * 2
+	}
+
+	var angle float64
+
+	if c.AClockwise {
+		angle = c.AEndAngle + ( 1 - t ) * ( math.Pi * 2 - deltaAngle )
+	} else {
+		angle = c.AStartAngle + t * deltaAngle
+	}
+
+	x := c.AX + c.XRadius * math.Cos( angle )
+	y := c.AY + c.YRadius * math.Sin( angle )
+
+	if c.ARotation != 0 {
+
+		cos := math.Cos( c.ARotation )
+		sin := math.Sin( c.ARotation )
+
+		tx := x - c.AX
+		ty := y - c.AY
+
+		// Rotate the point about the center of the ellipse.
+		x = tx * cos - ty * sin + c.AX
+		y = tx * sin + ty * cos + c.AY
+	}
+
+	return math3d.NewVector2( x, y )
+}
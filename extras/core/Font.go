@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Font turns text into shapes using the glyph outlines of a TrueType or
+// OpenType font.
+type Font struct {
+	font *sfnt.Font
+	buf sfnt.Buffer
+}
+
+// NewFont parses the contents of a .ttf or .otf file.
+func NewFont(data []byte) (*Font, error) {
+	f, err := sfnt.Parse( data )
+	if err != nil {
+		return nil, err
+	}
+	return &Font{ font: f }, nil
+}
+
+// LoadFont reads and parses a .ttf or .otf file.
+func LoadFont(path string) (*Font, error) {
+	data, err := ioutil.ReadFile( path )
+	if err != nil {
+		return nil, err
+	}
+	return NewFont( data )
+}
+
+// GenerateShapes lays out text on the xy plane, starting at the origin with
+// the baseline on y = 0. size is the height of an em. A newline starts a new
+// line below the previous one.
+func (f *Font) GenerateShapes(text string, size float64) ([]*Shape) {
+
+	shapes := make([]*Shape, 0)
+
+	// load the outlines in font units, with 26.6 fixed point precision
+	unitsPerEm := f.font.UnitsPerEm()
+	ppem := fixed.I( int( unitsPerEm ) )
+	scale := size / float64( unitsPerEm )
+
+	lineHeight := float64( unitsPerEm )
+	if metrics, err := f.font.Metrics( &f.buf, ppem, font.HintingNone ); err == nil {
+		lineHeight = fromFixed( metrics.Height )
+	}
+
+	offsetX := 0.0
+	offsetY := 0.0
+
+	var prev sfnt.GlyphIndex
+	hasPrev := false
+
+	for _, char := range text {
+
+		if char == '\n' {
+			offsetX = 0
+			offsetY -= lineHeight
+			hasPrev = false
+			continue
+		}
+
+		glyph, err := f.font.GlyphIndex( &f.buf, char )
+		if err != nil || glyph == 0 {
+			fmt.Println("THREE.Font: character", string(char), "does not exist in font")
+			continue
+		}
+
+		if hasPrev {
+			if kern, err := f.font.Kern( &f.buf, prev, glyph, ppem, font.HintingNone ); err == nil {
+				offsetX += fromFixed( kern )
+			}
+		}
+
+		segments, err := f.font.LoadGlyph( &f.buf, glyph, ppem, nil )
+		if err != nil {
+			fmt.Println("THREE.Font: can't load the outline of", string(char), err)
+			continue
+		}
+
+		// sfnt has y pointing down
+		x := func(p fixed.Point26_6) float64 {
+			return ( offsetX + fromFixed( p.X ) ) * scale
+		}
+		y := func(p fixed.Point26_6) float64 {
+			return ( offsetY - fromFixed( p.Y ) ) * scale
+		}
+
+		path := NewPath()
+
+		for _, segment := range segments {
+			args := segment.Args
+			switch segment.Op {
+			case sfnt.SegmentOpMoveTo:
+				path.MoveTo( x( args[ 0 ] ), y( args[ 0 ] ) )
+			case sfnt.SegmentOpLineTo:
+				path.LineTo( x( args[ 0 ] ), y( args[ 0 ] ) )
+			case sfnt.SegmentOpQuadTo:
+				path.QuadraticCurveTo( x( args[ 0 ] ), y( args[ 0 ] ), x( args[ 1 ] ), y( args[ 1 ] ) )
+			case sfnt.SegmentOpCubeTo:
+				path.BezierCurveTo( x( args[ 0 ] ), y( args[ 0 ] ), x( args[ 1 ] ), y( args[ 1 ] ), x( args[ 2 ] ), y( args[ 2 ] ) )
+			}
+		}
+
+		shapes = append(shapes, path.ToShapes( solidsCCW( path ), false )...)
+
+		if advance, err := f.font.GlyphAdvance( &f.buf, glyph, ppem, font.HintingNone ); err == nil {
+			offsetX += fromFixed( advance )
+		}
+
+		prev = glyph
+		hasPrev = true
+	}
+
+	return shapes
+}
+
+// solidsCCW reports whether the outer contours of a glyph wind counter clockwise.
+// TrueType outlines wind their outer contours clockwise and CFF outlines counter
+// clockwise, so this uses the winding of the largest contour, which is always solid.
+func solidsCCW(path *Path) bool {
+	largestArea := 0.0
+
+	for _, subPath := range path.subPaths() {
+		area := ShapeUtils.Area( subPath.GetPoints( 1 ) )
+		if math.Abs( area ) > math.Abs( largestArea ) {
+			largestArea = area
+		}
+	}
+
+	return largestArea > 0
+}
+
+func fromFixed(value fixed.Int26_6) float64 {
+	return float64( value ) / 64
+}
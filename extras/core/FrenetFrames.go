@@ -0,0 +1,112 @@
+package core
+
+import (
+	"math"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// FrenetFrames holds a tangent, normal and binormal for each of segments + 1
+// evenly spaced points along a curve. The normals vary slowly (parallel
+// transport), so shapes swept along the curve don't twist.
+// For more info see: http://www.cs.indiana.edu/pub/techreports/TR425.pdf
+type FrenetFrames struct {
+	Tangents []*math3d.Vector3
+	Normals []*math3d.Vector3
+	Binormals []*math3d.Vector3
+}
+
+func NewFrenetFrames(path *Curve3, segments int, closed bool) (*FrenetFrames) {
+
+	numpoints := segments + 1
+
+	f := &FrenetFrames{
+		Tangents: make([]*math3d.Vector3, numpoints),
+		Normals: make([]*math3d.Vector3, numpoints),
+		Binormals: make([]*math3d.Vector3, numpoints),
+	}
+
+	tangents := f.Tangents
+	normals := f.Normals
+	binormals := f.Binormals
+
+	vec := math3d.NewEmptyVector3()
+	mat := math3d.NewMatrix4()
+
+	// compute the tangent vectors for each segment on the path
+
+	for i := 0; i < numpoints; i ++ {
+		u := float64( i ) / float64( numpoints - 1 )
+		tangents[ i ] = path.GetTangentAt( u )
+		tangents[ i ].Normalize()
+	}
+
+	// select an initial normal vector perpendicular to the first tangent vector,
+	// and in the direction of the smallest tangent xyz component
+
+	normal := math3d.NewEmptyVector3()
+	smallest := math.MaxFloat64
+	tx := math.Abs( tangents[ 0 ].X )
+	ty := math.Abs( tangents[ 0 ].Y )
+	tz := math.Abs( tangents[ 0 ].Z )
+
+	if tx <= smallest {
+		smallest = tx
+		normal.Set( 1, 0, 0 )
+	}
+	if ty <= smallest {
+		smallest = ty
+		normal.Set( 0, 1, 0 )
+	}
+	if tz <= smallest {
+		normal.Set( 0, 0, 1 )
+	}
+
+	vec.CrossVectors( tangents[ 0 ], normal ).Normalize()
+
+	normals[ 0 ] = math3d.NewEmptyVector3().CrossVectors( tangents[ 0 ], vec )
+	binormals[ 0 ] = math3d.NewEmptyVector3().CrossVectors( tangents[ 0 ], normals[ 0 ] )
+
+	// compute the slowly-varying normal and binormal vectors for each segment on the path
+
+	for i := 1; i < numpoints; i ++ {
+
+		normals[ i ] = normals[ i - 1 ].Clone()
+		binormals[ i ] = binormals[ i - 1 ].Clone()
+
+		vec.CrossVectors( tangents[ i - 1 ], tangents[ i ] )
+
+		if vec.Length() > epsilon {
+			vec.Normalize()
+
+			theta := math.Acos( math3d.Clamp( tangents[ i - 1 ].Dot( tangents[ i ] ), - 1, 1 ) ) // clamp for floating pt errors
+
+			normals[ i ].ApplyMatrix4( mat.MakeRotationAxis( vec, theta ) )
+		}
+
+		binormals[ i ].CrossVectors( tangents[ i ], normals[ i ] )
+	}
+
+	// if the curve is closed, postprocess the vectors so the first and last normal vectors are the same
+
+	if closed {
+
+		theta := math.Acos( math3d.Clamp( normals[ 0 ].Dot( normals[ numpoints - 1 ] ), - 1, 1 ) )
+		theta /= float64( numpoints - 1 )
+
+		if tangents[ 0 ].Dot( vec.CrossVectors( normals[ 0 ], normals[ numpoints - 1 ] ) ) > 0 {
+			theta = - theta
+		}
+
+		for i := 1; i < numpoints; i ++ {
+			// twist a little...
+			normals[ i ].ApplyMatrix4( mat.MakeRotationAxis( tangents[ i ], theta * float64( i ) ) )
+			binormals[ i ].CrossVectors( tangents[ i ], normals[ i ] )
+		}
+	}
+
+	return f
+}
+
+// epsilon is the difference between 1 and the smallest float64 greater than 1.
+const epsilon = 2.220446049250313e-16
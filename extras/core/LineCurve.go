@@ -0,0 +1,35 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Line
+ **************************************************************/
+
+type LineCurve struct {
+	*Curve
+	V1, V2 *math3d.Vector2
+}
+
+func NewLineCurve(v1, v2 *math3d.Vector2) (*LineCurve) {
+	c := &LineCurve{
+		Curve: NewCurve(),
+		V1: v1,
+		V2: v2,
+	}
+	c.GetPoint = c.getPoint
+	c.GetTangent = c.getTangent
+	return c
+}
+
+func (c *LineCurve) getPoint(t float64) (*math3d.Vector2) {
+	point := c.V2.Clone().Sub( c.V1 )
+	point.MultiplyScalar( t ).Add( c.V1 )
+	return point
+}
+
+func (c *LineCurve) getTangent(t float64) (*math3d.Vector2) {
+	return c.V2.Clone().Sub( c.V1 ).Normalize()
+}
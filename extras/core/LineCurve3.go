@@ -0,0 +1,35 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Line3D
+ **************************************************************/
+
+type LineCurve3 struct {
+	*Curve3
+	V1, V2 *math3d.Vector3
+}
+
+func NewLineCurve3(v1, v2 *math3d.Vector3) (*LineCurve3) {
+	c := &LineCurve3{
+		Curve3: NewCurve3(),
+		V1: v1,
+		V2: v2,
+	}
+	c.GetPoint = c.getPoint
+	c.GetTangent = c.getTangent
+	return c
+}
+
+func (c *LineCurve3) getPoint(t float64) (*math3d.Vector3) {
+	point := c.V2.Clone().Sub( *c.V1 )
+	point.MultiplyScalar( t ).Add( c.V1 )
+	return point
+}
+
+func (c *LineCurve3) getTangent(t float64) (*math3d.Vector3) {
+	return c.V2.Clone().Sub( *c.V1 ).Normalize()
+}
@@ -0,0 +1,447 @@
+package core
+
+import (
+	"math"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// Path is a CurvePath built with canvas like drawing commands.
+type Path struct {
+	*CurvePath
+	actions []pathAction
+}
+
+const (
+	pathMoveTo = "moveTo"
+	pathLineTo = "lineTo"
+	pathQuadraticCurveTo = "quadraticCurveTo" // Bezier quadratic curve
+	pathBezierCurveTo = "bezierCurveTo" // Bezier cubic curve
+	pathCSplineThru = "splineThru" // Catmull-Rom spline
+	pathEllipse = "ellipse"
+)
+
+type pathAction struct {
+	action string
+	// the end point of the action
+	x, y float64
+	curve *Curve
+	// the number of sampled segments per division
+	segments int
+}
+
+func NewPath() (*Path) {
+	return &Path{
+		CurvePath: NewCurvePath(),
+		actions: make([]pathAction, 0),
+	}
+}
+
+// NewPathFromPoints creates a path of straight lines through points.
+func NewPathFromPoints(points []*math3d.Vector2) (*Path) {
+	p := NewPath()
+	p.FromPoints( points )
+	return p
+}
+
+// FromPoints creates a path of straight lines through points.
+func (p *Path) FromPoints(vectors []*math3d.Vector2) {
+	if len(vectors) == 0 {
+		return
+	}
+
+	p.MoveTo( vectors[ 0 ].X, vectors[ 0 ].Y )
+
+	for _, v := range vectors[ 1: ] {
+		p.LineTo( v.X, v.Y )
+	}
+}
+
+// startPoint returns the end point of the previous action.
+func (p *Path) startPoint() (float64, float64) {
+	if len(p.actions) == 0 {
+		return 0, 0
+	}
+	last := p.actions[ len(p.actions) - 1 ]
+	return last.x, last.y
+}
+
+func (p *Path) addAction(action string, x, y float64, curve *Curve, segments int) {
+	if curve != nil {
+		p.Add( curve )
+	}
+	p.actions = append(p.actions, pathAction{ action, x, y, curve, segments })
+}
+
+func (p *Path) MoveTo(x, y float64) {
+	p.addAction( pathMoveTo, x, y, nil, 0 )
+}
+
+func (p *Path) LineTo(x, y float64) {
+	x0, y0 := p.startPoint()
+
+	curve := NewLineCurve( math3d.NewVector2( x0, y0 ), math3d.NewVector2( x, y ) )
+	p.addAction( pathLineTo, x, y, curve.Curve, 0 )
+}
+
+func (p *Path) QuadraticCurveTo(aCPx, aCPy, aX, aY float64) {
+	x0, y0 := p.startPoint()
+
+	curve := NewQuadraticBezierCurve(
+		math3d.NewVector2( x0, y0 ),
+		math3d.NewVector2( aCPx, aCPy ),
+		math3d.NewVector2( aX, aY ),
+	)
+	p.addAction( pathQuadraticCurveTo, aX, aY, curve.Curve, 1 )
+}
+
+func (p *Path) BezierCurveTo(aCP1x, aCP1y, aCP2x, aCP2y, aX, aY float64) {
+	x0, y0 := p.startPoint()
+
+	curve := NewCubicBezierCurve(
+		math3d.NewVector2( x0, y0 ),
+		math3d.NewVector2( aCP1x, aCP1y ),
+		math3d.NewVector2( aCP2x, aCP2y ),
+		math3d.NewVector2( aX, aY ),
+	)
+	p.addAction( pathBezierCurveTo, aX, aY, curve.Curve, 1 )
+}
+
+// SplineThru adds a Catmull-Rom spline through pts.
+func (p *Path) SplineThru(pts []*math3d.Vector2) {
+	if len(pts) == 0 {
+		return
+	}
+
+	x0, y0 := p.startPoint()
+
+	npts := append([]*math3d.Vector2{ math3d.NewVector2( x0, y0 ) }, pts...)
+
+	curve := NewSplineCurve( npts )
+	last := pts[ len(pts) - 1 ]
+	p.addAction( pathCSplineThru, last.X, last.Y, curve.Curve, len(pts) )
+}
+
+// Arc adds a circular arc with its center relative to the current point.
+func (p *Path) Arc(aX, aY, aRadius, aStartAngle, aEndAngle float64, aClockwise bool) {
+	x0, y0 := p.startPoint()
+	p.Absarc( aX + x0, aY + y0, aRadius, aStartAngle, aEndAngle, aClockwise )
+}
+
+func (p *Path) Absarc(aX, aY, aRadius, aStartAngle, aEndAngle float64, aClockwise bool) {
+	p.Absellipse( aX, aY, aRadius, aRadius, aStartAngle, aEndAngle, aClockwise, 0 )
+}
+
+// Ellipse adds an elliptical arc with its center relative to the current point.
+func (p *Path) Ellipse(aX, aY, xRadius, yRadius, aStartAngle, aEndAngle float64, aClockwise bool, aRotation float64) {
+	x0, y0 := p.startPoint()
+	p.Absellipse( aX + x0, aY + y0, xRadius, yRadius, aStartAngle, aEndAngle, aClockwise, aRotation )
+}
+
+func (p *Path) Absellipse(aX, aY, xRadius, yRadius, aStartAngle, aEndAngle float64, aClockwise bool, aRotation float64) {
+	curve := NewEllipseCurve( aX, aY, xRadius, yRadius, aStartAngle, aEndAngle, aClockwise, aRotation )
+
+	lastPoint := curve.GetPoint( 1 )
+	p.addAction( pathEllipse, lastPoint.X, lastPoint.Y, curve.Curve, 2 )
+}
+
+// GetSpacedPoints returns divisions points evenly spaced along the path.
+func (p *Path) GetSpacedPoints(divisions int) ([]*math3d.Vector2) {
+	points := make([]*math3d.Vector2, 0, divisions + 1)
+
+	for i := 0; i < divisions; i ++ {
+		points = append(points, p.GetPoint( float64( i ) / float64( divisions ) ))
+	}
+
+	if p.AutoClose && len(points) > 0 {
+		points = append(points, points[ 0 ])
+	}
+
+	return points
+}
+
+// GetPoints returns the points of the path, with every curve sampled in
+// divisions steps (ellipses in 2 * divisions, splines in divisions per point).
+func (p *Path) GetPoints(divisions int) ([]*math3d.Vector2) {
+
+	points := make([]*math3d.Vector2, 0)
+
+	for _, item := range p.actions {
+
+		switch item.action {
+
+		case pathMoveTo, pathLineTo:
+			points = append(points, math3d.NewVector2( item.x, item.y ))
+
+		case pathCSplineThru:
+			n := divisions * item.segments
+			for j := 1; j <= n; j ++ {
+				points = append(points, item.curve.GetPointAt( float64( j ) / float64( n ) ))
+			}
+
+		default:
+			n := divisions * item.segments
+			for j := 1; j <= n; j ++ {
+				points = append(points, item.curve.GetPoint( float64( j ) / float64( n ) ))
+			}
+		}
+	}
+
+	if len(points) == 0 {
+		return points
+	}
+
+	// Normalize to remove the closing point by default.
+	lastPoint := points[ len(points) - 1 ]
+	EPSILON := 0.0000000001
+	if len(points) > 1 && math.Abs( lastPoint.X - points[ 0 ].X ) < EPSILON && math.Abs( lastPoint.Y - points[ 0 ].Y ) < EPSILON {
+		points = points[ : len(points) - 1 ]
+	}
+
+	if p.AutoClose {
+		points = append(points, points[ 0 ])
+	}
+
+	return points
+}
+
+// subPaths splits the path into one Path per MoveTo.
+func (p *Path) subPaths() ([]*Path) {
+	subPaths := make([]*Path, 0)
+	lastPath := NewPath()
+
+	for _, item := range p.actions {
+		if item.action == pathMoveTo && len(lastPath.actions) != 0 {
+			subPaths = append(subPaths, lastPath)
+			lastPath = NewPath()
+		}
+		lastPath.addAction( item.action, item.x, item.y, item.curve, item.segments )
+	}
+
+	if len(lastPath.actions) != 0 {
+		subPaths = append(subPaths, lastPath)
+	}
+
+	return subPaths
+}
+
+// ToShapes converts a path of several sub paths (each starting with a MoveTo),
+// e.g. a font glyph, to shapes with holes. Sub paths winding clockwise are
+// solid and the others are holes, or the other way around if isCCW is set.
+// If noHoles is set every sub path becomes a shape.
+func (p *Path) ToShapes(isCCW, noHoles bool) ([]*Shape) {
+
+	isClockWise := ShapeUtils.IsClockWise
+
+	subPaths := p.subPaths()
+
+	toShapesNoHoles := func() ([]*Shape) {
+		shapes := make([]*Shape, 0, len(subPaths))
+		for _, tmpPath := range subPaths {
+			shapes = append(shapes, &Shape{ Path: tmpPath, Holes: make([]*Path, 0) })
+		}
+		return shapes
+	}
+
+	if len(subPaths) == 0 {
+		return []*Shape{}
+	}
+
+	if noHoles || len(subPaths) == 1 {
+		return toShapesNoHoles()
+	}
+
+	type shapePoints struct {
+		s *Shape
+		p []*math3d.Vector2
+	}
+
+	type holePoint struct {
+		h *Path
+		p *math3d.Vector2
+	}
+
+	holesFirst := !isClockWise( subPaths[ 0 ].GetPoints( 12 ) )
+	if isCCW {
+		holesFirst = !holesFirst
+	}
+
+	newShapes := make([]*shapePoints, 1)
+	newShapeHoles := [][]holePoint{ {} }
+	mainIdx := 0
+
+	for _, tmpPath := range subPaths {
+
+		tmpPoints := tmpPath.GetPoints( 12 )
+		solid := isClockWise( tmpPoints )
+		if isCCW {
+			solid = !solid
+		}
+
+		if solid {
+
+			if !holesFirst && newShapes[ mainIdx ] != nil {
+				mainIdx ++
+			}
+
+			for len(newShapes) <= mainIdx {
+				newShapes = append(newShapes, nil)
+				newShapeHoles = append(newShapeHoles, []holePoint{})
+			}
+
+			newShapes[ mainIdx ] = &shapePoints{ s: &Shape{ Path: tmpPath, Holes: make([]*Path, 0) }, p: tmpPoints }
+
+			if holesFirst {
+				mainIdx ++
+				for len(newShapes) <= mainIdx {
+					newShapes = append(newShapes, nil)
+					newShapeHoles = append(newShapeHoles, []holePoint{})
+				}
+			}
+
+			newShapeHoles[ mainIdx ] = []holePoint{}
+
+		} else {
+
+			newShapeHoles[ mainIdx ] = append(newShapeHoles[ mainIdx ], holePoint{ h: tmpPath, p: tmpPoints[ 0 ] })
+		}
+	}
+
+	// only Holes? -> probably all Shapes with wrong orientation
+	if newShapes[ 0 ] == nil {
+		return toShapesNoHoles()
+	}
+
+	// with holesFirst the last entry waits for a shape that never came
+	if newShapes[ len(newShapes) - 1 ] == nil {
+		newShapes = newShapes[ : len(newShapes) - 1 ]
+	}
+
+	if len(newShapes) > 1 {
+
+		// move holes to the shape that contains them
+
+		ambiguous := false
+		changed := false
+		betterShapeHoles := make([][]holePoint, len(newShapes))
+
+		for sIdx := range newShapes {
+
+			for _, ho := range newShapeHoles[ sIdx ] {
+
+				holeUnassigned := true
+
+				for s2Idx, s2 := range newShapes {
+
+					if isPointInsidePolygon( ho.p, s2.p ) {
+
+						if sIdx != s2Idx {
+							changed = true
+						}
+
+						if holeUnassigned {
+							holeUnassigned = false
+							betterShapeHoles[ s2Idx ] = append(betterShapeHoles[ s2Idx ], ho)
+						} else {
+							ambiguous = true
+						}
+					}
+				}
+
+				if holeUnassigned {
+					betterShapeHoles[ sIdx ] = append(betterShapeHoles[ sIdx ], ho)
+				}
+			}
+		}
+
+		if changed && !ambiguous {
+			newShapeHoles = betterShapeHoles
+		}
+	}
+
+	shapes := make([]*Shape, 0, len(newShapes))
+
+	for i, newShape := range newShapes {
+		tmpShape := newShape.s
+		for _, hole := range newShapeHoles[ i ] {
+			tmpShape.Holes = append(tmpShape.Holes, hole.h)
+		}
+		shapes = append(shapes, tmpShape)
+	}
+
+	return shapes
+}
+
+// isPointInsidePolygon reports whether inPt is inside or on the contour of inPolygon.
+func isPointInsidePolygon(inPt *math3d.Vector2, inPolygon []*math3d.Vector2) bool {
+
+	polyLen := len(inPolygon)
+
+	// inPt on polygon contour => immediate success    or
+	// toggling of inside/outside at every single! intersection point of an edge
+	//  with the horizontal line through inPt, left of inPt
+	//  not counting lowY endpoints of edges and whole edges on that line
+
+	inside := false
+
+	for p, q := polyLen - 1, 0; q < polyLen; p, q = q, q + 1 {
+
+		edgeLowPt := inPolygon[ p ]
+		edgeHighPt := inPolygon[ q ]
+
+		edgeDx := edgeHighPt.X - edgeLowPt.X
+		edgeDy := edgeHighPt.Y - edgeLowPt.Y
+
+		if math.Abs( edgeDy ) > epsilon {
+
+			// not parallel
+			if edgeDy < 0 {
+				edgeLowPt = inPolygon[ q ]
+				edgeDx = - edgeDx
+				edgeHighPt = inPolygon[ p ]
+				edgeDy = - edgeDy
+			}
+
+			if inPt.Y < edgeLowPt.Y || inPt.Y > edgeHighPt.Y {
+				continue
+			}
+
+			if inPt.Y == edgeLowPt.Y {
+
+				if inPt.X == edgeLowPt.X {
+					return true // inPt is on contour ?
+				}
+
+				// no intersection or edgeLowPt => doesn't count !!!
+
+			} else {
+
+				perpEdge := edgeDy * ( inPt.X - edgeLowPt.X ) - edgeDx * ( inPt.Y - edgeLowPt.Y )
+
+				if perpEdge == 0 {
+					return true // inPt is on contour ?
+				}
+				if perpEdge < 0 {
+					continue
+				}
+
+				inside = !inside // true intersection left of inPt
+			}
+
+		} else {
+
+			// parallel or collinear
+			if inPt.Y != edgeLowPt.Y {
+				continue // parallel
+			}
+
+			// edge lies on the same horizontal line as inPt
+			if ( edgeHighPt.X <= inPt.X && inPt.X <= edgeLowPt.X ) ||
+				( edgeLowPt.X <= inPt.X && inPt.X <= edgeHighPt.X ) {
+				return true // inPt: Point on contour !
+			}
+		}
+	}
+
+	return inside
+}
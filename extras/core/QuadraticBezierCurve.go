@@ -0,0 +1,40 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Quadratic Bezier curve
+ **************************************************************/
+
+type QuadraticBezierCurve struct {
+	*Curve
+	V0, V1, V2 *math3d.Vector2
+}
+
+func NewQuadraticBezierCurve(v0, v1, v2 *math3d.Vector2) (*QuadraticBezierCurve) {
+	c := &QuadraticBezierCurve{
+		Curve: NewCurve(),
+		V0: v0,
+		V1: v1,
+		V2: v2,
+	}
+	c.GetPoint = c.getPoint
+	c.GetTangent = c.getTangent
+	return c
+}
+
+func (c *QuadraticBezierCurve) getPoint(t float64) (*math3d.Vector2) {
+	return math3d.NewVector2(
+		ShapeUtils.B2( t, c.V0.X, c.V1.X, c.V2.X ),
+		ShapeUtils.B2( t, c.V0.Y, c.V1.Y, c.V2.Y ),
+	)
+}
+
+func (c *QuadraticBezierCurve) getTangent(t float64) (*math3d.Vector2) {
+	return math3d.NewVector2(
+		CurveUtils.TangentQuadraticBezier( t, c.V0.X, c.V1.X, c.V2.X ),
+		CurveUtils.TangentQuadraticBezier( t, c.V0.Y, c.V1.Y, c.V2.Y ),
+	).Normalize()
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// Shape is a closed Path with optional Holes, e.g. the outline of a floor plan
+// or of a font glyph.
+type Shape struct {
+	*Path
+	Holes []*Path
+}
+
+func NewShape() (*Shape) {
+	return &Shape{
+		Path: NewPath(),
+		Holes: make([]*Path, 0),
+	}
+}
+
+// NewShapeFromPoints creates a shape with straight edges through points.
+func NewShapeFromPoints(points []*math3d.Vector2) (*Shape) {
+	s := NewShape()
+	s.FromPoints( points )
+	return s
+}
+
+// GetPointsHoles returns the points of all holes.
+func (s *Shape) GetPointsHoles(divisions int) ([][]*math3d.Vector2) {
+	holesPts := make([][]*math3d.Vector2, len(s.Holes))
+	for i, hole := range s.Holes {
+		holesPts[ i ] = hole.GetPoints( divisions )
+	}
+	return holesPts
+}
+
+// GetSpacedPointsHoles returns evenly spaced points of all holes.
+func (s *Shape) GetSpacedPointsHoles(divisions int) ([][]*math3d.Vector2) {
+	holesPts := make([][]*math3d.Vector2, len(s.Holes))
+	for i, hole := range s.Holes {
+		holesPts[ i ] = hole.GetSpacedPoints( divisions )
+	}
+	return holesPts
+}
+
+// ExtractPoints returns the points of the outline and of the holes.
+func (s *Shape) ExtractPoints(divisions int) ([]*math3d.Vector2, [][]*math3d.Vector2) {
+	return s.GetPoints( divisions ), s.GetPointsHoles( divisions )
+}
+
+// ExtractSpacedPoints returns evenly spaced points of the outline and of the holes.
+func (s *Shape) ExtractSpacedPoints(divisions int) ([]*math3d.Vector2, [][]*math3d.Vector2) {
+	return s.GetSpacedPoints( divisions ), s.GetSpacedPointsHoles( divisions )
+}
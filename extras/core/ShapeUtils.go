@@ -0,0 +1,91 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// ShapeUtils holds the helpers for triangulating shapes.
+var ShapeUtils = shapeUtils{}
+
+type shapeUtils struct{}
+
+// Area returns the signed area of a contour, positive for counter clockwise contours.
+func (shapeUtils) Area(contour []*math3d.Vector2) float64 {
+	n := len(contour)
+	a := 0.0
+
+	for p, q := n - 1, 0; q < n; p, q = q, q + 1 {
+		a += contour[ p ].X * contour[ q ].Y - contour[ q ].X * contour[ p ].Y
+	}
+
+	return a * 0.5
+}
+
+func (shapeUtils) IsClockWise(pts []*math3d.Vector2) bool {
+	return ShapeUtils.Area( pts ) < 0
+}
+
+// TriangulateShape triangulates contour with holes cut out of it. The result
+// holds counter clockwise triangles of indices into the contour points followed
+// by the points of all holes, in order.
+func (shapeUtils) TriangulateShape(contour []*math3d.Vector2, holes [][]*math3d.Vector2) ([][3]int) {
+
+	// the indices of the holes count the closing point of the contour, if any
+	offset := len(contour)
+
+	// remove the closing points, as the contours are closed implicitly
+	contour = removeDupEndPts( contour )
+
+	vertices := make([]float64, 0, len(contour) * 2)
+	// indexMap maps the indices of the trimmed points back to contour + holes
+	indexMap := make([]int, 0, len(contour))
+
+	for i, point := range contour {
+		vertices = append(vertices, point.X, point.Y)
+		indexMap = append(indexMap, i)
+	}
+
+	// the indices of the first point of every hole
+	holeIndices := make([]int, 0, len(holes))
+
+	for _, hole := range holes {
+		holeIndices = append(holeIndices, len(indexMap))
+		for i, point := range removeDupEndPts( hole ) {
+			vertices = append(vertices, point.X, point.Y)
+			indexMap = append(indexMap, offset + i)
+		}
+		offset += len(hole)
+	}
+
+	triangles := Earcut.Triangulate( vertices, holeIndices )
+
+	faces := make([][3]int, 0, len(triangles) / 3)
+	for i := 0; i + 2 < len(triangles); i += 3 {
+		faces = append(faces, [3]int{ indexMap[ triangles[ i ] ], indexMap[ triangles[ i + 1 ] ], indexMap[ triangles[ i + 2 ] ] })
+	}
+
+	return faces
+}
+
+func removeDupEndPts(points []*math3d.Vector2) ([]*math3d.Vector2) {
+	l := len(points)
+	if l > 2 && points[ l - 1 ].Equals( points[ 0 ] ) {
+		return points[ : l - 1 ]
+	}
+	return points
+}
+
+// Bezier Curves formulas obtained from
+// http://en.wikipedia.org/wiki/B%C3%A9zier_curve
+
+// B2 is the quadratic Bezier polynomial.
+func (shapeUtils) B2(t, p0, p1, p2 float64) float64 {
+	k := 1 - t
+	return k * k * p0 + 2 * k * t * p1 + t * t * p2
+}
+
+// B3 is the cubic Bezier polynomial.
+func (shapeUtils) B3(t, p0, p1, p2, p3 float64) float64 {
+	k := 1 - t
+	return k * k * k * p0 + 3 * k * k * t * p1 + 3 * k * t * t * p2 + t * t * t * p3
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**************************************************************
+ *	Spline 3D curve
+ **************************************************************/
+
+// SplineCurve3 is a Catmull-Rom spline through Points.
+type SplineCurve3 struct {
+	*Curve3
+	Points []*math3d.Vector3
+}
+
+func NewSplineCurve3(points []*math3d.Vector3) (*SplineCurve3) {
+	c := &SplineCurve3{
+		Curve3: NewCurve3(),
+		Points: points,
+	}
+	c.GetPoint = c.getPoint
+	return c
+}
+
+func (c *SplineCurve3) getPoint(t float64) (*math3d.Vector3) {
+
+	points := c.Points
+	point := float64( len(points) - 1 ) * t
+
+	intPoint := int( point )
+	weight := point - float64( intPoint )
+
+	index := func(i int) int {
+		if i < 0 {
+			return 0
+		}
+		if i > len(points) - 1 {
+			return len(points) - 1
+		}
+		return i
+	}
+
+	point0 := points[ index( intPoint - 1 ) ]
+	point1 := points[ index( intPoint ) ]
+	point2 := points[ index( intPoint + 1 ) ]
+	point3 := points[ index( intPoint + 2 ) ]
+
+	interpolate := CurveUtils.Interpolate
+
+	return math3d.NewVector3(
+		interpolate( point0.X, point1.X, point2.X, point3.X, weight ),
+		interpolate( point0.Y, point1.Y, point2.Y, point3.Y, weight ),
+		interpolate( point0.Z, point1.Z, point2.Z, point3.Z, weight ),
+	)
+}
@@ -10,7 +10,6 @@ type BoxGeometry struct {
 	*core.Geometry
 	Width, Height, Depth float64
 	WidthSegments, HeightSegments, DepthSegments int
-	Parameters map[string]interface{}
 }
 
 func NewDefaultBoxGeometry(width, height, depth float64) (*BoxGeometry) {
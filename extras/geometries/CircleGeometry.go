@@ -0,0 +1,79 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+type CircleGeometry struct {
+	*core.Geometry
+	Radius float64
+	Segments int
+	ThetaStart, ThetaLength float64
+}
+
+func NewDefaultCircleGeometry(radius float64, segments int) (*CircleGeometry) {
+	return NewCircleGeometry(radius, segments, 0, math.Pi * 2)
+}
+
+func NewCircleGeometry(radius float64, segments int, thetaStart, thetaLength float64) (*CircleGeometry) {
+	if segments < 3 {
+		segments = 3
+	}
+
+	g := &CircleGeometry{
+		Geometry: core.NewGeometry(),
+		Radius: radius,
+		Segments: segments,
+		ThetaStart: thetaStart,
+		ThetaLength: thetaLength,
+	}
+	g.Type = "CircleGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"radius": radius,
+		"segments": segments,
+		"thetaStart": thetaStart,
+		"thetaLength": thetaLength,
+	}
+
+	uvs := make([]*math3d.Vector2, 0)
+	centerUV := math3d.NewVector2( 0.5, 0.5 )
+
+	g.Vertices = append(g.Vertices, math3d.NewEmptyVector3())
+	uvs = append(uvs, centerUV)
+
+	for i := 0; i <= segments; i ++ {
+
+		segment := thetaStart + float64( i ) / float64( segments ) * thetaLength
+
+		vertex := math3d.NewVector3(
+			radius * math.Cos( segment ),
+			radius * math.Sin( segment ),
+			0,
+		)
+
+		g.Vertices = append(g.Vertices, vertex)
+		uvs = append(uvs, math3d.NewVector2( ( vertex.X / radius + 1 ) / 2, ( vertex.Y / radius + 1 ) / 2 ))
+	}
+
+	n := math3d.NewVector3( 0, 0, 1 )
+
+	for i := 1; i <= segments; i ++ {
+
+		g.Faces = append(g.Faces, core.NewArraysFace3( i, i + 1, 0, []*math3d.Vector3{ n.Clone(), n.Clone(), n.Clone() }, nil, 0 ))
+		g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvs[ i ].Clone(), uvs[ i + 1 ].Clone(), centerUV.Clone() })
+	}
+
+	g.ComputeFaceNormals()
+
+	g.BoundingSphere = math3d.NewSphere( math3d.NewEmptyVector3(), radius )
+
+	return g
+}
+
+func (g *CircleGeometry) Clone() (*CircleGeometry) {
+	return NewCircleGeometry(g.Radius, g.Segments, g.ThetaStart, g.ThetaLength)
+}
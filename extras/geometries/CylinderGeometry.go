@@ -0,0 +1,187 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+type CylinderGeometry struct {
+	*core.Geometry
+	RadiusTop, RadiusBottom, Height float64
+	RadialSegments, HeightSegments int
+	OpenEnded bool
+	ThetaStart, ThetaLength float64
+}
+
+func NewDefaultCylinderGeometry(radiusTop, radiusBottom, height float64, radialSegments int) (*CylinderGeometry) {
+	return NewCylinderGeometry(radiusTop, radiusBottom, height, radialSegments, 1, false, 0, math.Pi * 2)
+}
+
+// NewCylinderGeometry builds a (possibly conical) cylinder along the y axis.
+// The caps use material indices 1 (top) and 2 (bottom).
+func NewCylinderGeometry(radiusTop, radiusBottom, height float64, radialSegments, heightSegments int, openEnded bool, thetaStart, thetaLength float64) (*CylinderGeometry) {
+	if radialSegments < 1 {
+		radialSegments = 8
+	}
+	if heightSegments < 1 {
+		heightSegments = 1
+	}
+
+	g := &CylinderGeometry{
+		Geometry: core.NewGeometry(),
+		RadiusTop: radiusTop,
+		RadiusBottom: radiusBottom,
+		Height: height,
+		RadialSegments: radialSegments,
+		HeightSegments: heightSegments,
+		OpenEnded: openEnded,
+		ThetaStart: thetaStart,
+		ThetaLength: thetaLength,
+	}
+	g.Type = "CylinderGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"radiusTop": radiusTop,
+		"radiusBottom": radiusBottom,
+		"height": height,
+		"radialSegments": radialSegments,
+		"heightSegments": heightSegments,
+		"openEnded": openEnded,
+		"thetaStart": thetaStart,
+		"thetaLength": thetaLength,
+	}
+
+	heightHalf := height / 2
+
+	vertices := make([][]int, 0)
+	uvs := make([][]*math3d.Vector2, 0)
+
+	for y := 0; y <= heightSegments; y ++ {
+
+		verticesRow := make([]int, 0)
+		uvsRow := make([]*math3d.Vector2, 0)
+
+		v := float64( y ) / float64( heightSegments )
+		radius := v * ( radiusBottom - radiusTop ) + radiusTop
+
+		for x := 0; x <= radialSegments; x ++ {
+
+			u := float64( x ) / float64( radialSegments )
+
+			vertex := math3d.NewVector3(
+				radius * math.Sin( u * thetaLength + thetaStart ),
+				- v * height + heightHalf,
+				radius * math.Cos( u * thetaLength + thetaStart ),
+			)
+
+			g.Vertices = append(g.Vertices, vertex)
+
+			verticesRow = append(verticesRow, len(g.Vertices) - 1)
+			uvsRow = append(uvsRow, math3d.NewVector2( u, 1 - v ))
+		}
+
+		vertices = append(vertices, verticesRow)
+		uvs = append(uvs, uvsRow)
+	}
+
+	tanTheta := ( radiusBottom - radiusTop ) / height
+
+	for x := 0; x < radialSegments; x ++ {
+
+		var na, nb *math3d.Vector3
+		if radiusTop != 0 {
+			na = g.Vertices[ vertices[ 0 ][ x ] ].Clone()
+			nb = g.Vertices[ vertices[ 0 ][ x + 1 ] ].Clone()
+		} else {
+			na = g.Vertices[ vertices[ 1 ][ x ] ].Clone()
+			nb = g.Vertices[ vertices[ 1 ][ x + 1 ] ].Clone()
+		}
+
+		na.SetY( math.Sqrt( na.X * na.X + na.Z * na.Z ) * tanTheta ).Normalize()
+		nb.SetY( math.Sqrt( nb.X * nb.X + nb.Z * nb.Z ) * tanTheta ).Normalize()
+
+		for y := 0; y < heightSegments; y ++ {
+
+			v1 := vertices[ y ][ x ]
+			v2 := vertices[ y + 1 ][ x ]
+			v3 := vertices[ y + 1 ][ x + 1 ]
+			v4 := vertices[ y ][ x + 1 ]
+
+			n1 := na.Clone()
+			n2 := na.Clone()
+			n3 := nb.Clone()
+			n4 := nb.Clone()
+
+			uv1 := uvs[ y ][ x ].Clone()
+			uv2 := uvs[ y + 1 ][ x ].Clone()
+			uv3 := uvs[ y + 1 ][ x + 1 ].Clone()
+			uv4 := uvs[ y ][ x + 1 ].Clone()
+
+			g.Faces = append(g.Faces, core.NewArraysFace3( v1, v2, v4, []*math3d.Vector3{ n1, n2, n4 }, nil, 0 ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uv1, uv2, uv4 })
+
+			g.Faces = append(g.Faces, core.NewArraysFace3( v2, v3, v4, []*math3d.Vector3{ n2.Clone(), n3, n4.Clone() }, nil, 0 ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uv2.Clone(), uv3, uv4.Clone() })
+		}
+	}
+
+	// top cap
+
+	if !openEnded && radiusTop > 0 {
+
+		g.Vertices = append(g.Vertices, math3d.NewVector3( 0, heightHalf, 0 ))
+
+		for x := 0; x < radialSegments; x ++ {
+
+			v1 := vertices[ 0 ][ x ]
+			v2 := vertices[ 0 ][ x + 1 ]
+			v3 := len(g.Vertices) - 1
+
+			n1 := math3d.NewVector3( 0, 1, 0 )
+			n2 := math3d.NewVector3( 0, 1, 0 )
+			n3 := math3d.NewVector3( 0, 1, 0 )
+
+			uv1 := uvs[ 0 ][ x ].Clone()
+			uv2 := uvs[ 0 ][ x + 1 ].Clone()
+			uv3 := math3d.NewVector2( uv2.X, 0 )
+
+			g.Faces = append(g.Faces, core.NewArraysFace3( v1, v2, v3, []*math3d.Vector3{ n1, n2, n3 }, nil, 1 ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uv1, uv2, uv3 })
+		}
+	}
+
+	// bottom cap
+
+	if !openEnded && radiusBottom > 0 {
+
+		g.Vertices = append(g.Vertices, math3d.NewVector3( 0, - heightHalf, 0 ))
+
+		for x := 0; x < radialSegments; x ++ {
+
+			v1 := vertices[ heightSegments ][ x + 1 ]
+			v2 := vertices[ heightSegments ][ x ]
+			v3 := len(g.Vertices) - 1
+
+			n1 := math3d.NewVector3( 0, - 1, 0 )
+			n2 := math3d.NewVector3( 0, - 1, 0 )
+			n3 := math3d.NewVector3( 0, - 1, 0 )
+
+			uv1 := uvs[ heightSegments ][ x + 1 ].Clone()
+			uv2 := uvs[ heightSegments ][ x ].Clone()
+			uv3 := math3d.NewVector2( uv2.X, 1 )
+
+			g.Faces = append(g.Faces, core.NewArraysFace3( v1, v2, v3, []*math3d.Vector3{ n1, n2, n3 }, nil, 2 ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uv1, uv2, uv3 })
+		}
+	}
+
+	g.ComputeFaceNormals()
+
+	return g
+}
+
+func (g *CylinderGeometry) Clone() (*CylinderGeometry) {
+	return NewCylinderGeometry(g.RadiusTop, g.RadiusBottom, g.Height, g.RadialSegments, g.HeightSegments, g.OpenEnded, g.ThetaStart, g.ThetaLength)
+}
@@ -0,0 +1,75 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+)
+
+// EdgesGeometry holds the feature edges of a geometry as line segments, i.e.
+// pairs of vertices: the edges of a single face and the edges between faces
+// whose normals differ by at least ThresholdAngle degrees.
+type EdgesGeometry struct {
+	*core.Geometry
+	ThresholdAngle float64
+}
+
+func NewDefaultEdgesGeometry(geometry *core.Geometry) (*EdgesGeometry) {
+	return NewEdgesGeometry(geometry, 1)
+}
+
+func NewEdgesGeometry(geometry *core.Geometry, thresholdAngle float64) (*EdgesGeometry) {
+	g := &EdgesGeometry{
+		Geometry: core.NewGeometry(),
+		ThresholdAngle: thresholdAngle,
+	}
+	g.Type = "EdgesGeometry"
+
+	thresholdDot := math.Cos( thresholdAngle * math.Pi / 180 )
+
+	type edge struct {
+		vert1, vert2 int
+		face1, face2 int
+	}
+
+	geometry2 := geometry.Clone()
+	geometry2.MergeVertices()
+	geometry2.ComputeFaceNormals()
+
+	vertices := geometry2.Vertices
+	faces := geometry2.Faces
+
+	// the edges in the order they were found, so the result doesn't depend on map order
+	edges := make([]*edge, 0)
+	hash := make(map[[2]int]*edge)
+
+	for i, face := range faces {
+
+		keys := [3]int{ face.A, face.B, face.C }
+
+		for j := 0; j < 3; j ++ {
+
+			key := [2]int{ keys[ j ], keys[ ( j + 1 ) % 3 ] }
+			if key[ 0 ] > key[ 1 ] {
+				key[ 0 ], key[ 1 ] = key[ 1 ], key[ 0 ]
+			}
+
+			if h, ok := hash[ key ]; ok {
+				h.face2 = i
+			} else {
+				h = &edge{ vert1: key[ 0 ], vert2: key[ 1 ], face1: i, face2: -1 }
+				hash[ key ] = h
+				edges = append(edges, h)
+			}
+		}
+	}
+
+	for _, h := range edges {
+
+		if h.face2 == -1 || faces[ h.face1 ].Normal.Dot( faces[ h.face2 ].Normal ) <= thresholdDot {
+			g.Vertices = append(g.Vertices, vertices[ h.vert1 ].Clone(), vertices[ h.vert2 ].Clone())
+		}
+	}
+
+	return g
+}
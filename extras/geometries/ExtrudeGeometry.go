@@ -0,0 +1,466 @@
+package geometries
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	extrascore "github.com/uzudil/three.go/extras/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// ExtrudeGeometryOptions controls how shapes are extruded.
+type ExtrudeGeometryOptions struct {
+	CurveSegments int // number of points on the curves
+	Steps int // number of points for z-side extrusions / used for subdividing segments of extrude spline too
+	Amount float64 // depth to extrude the shape
+
+	BevelEnabled bool // turn on bevel
+	BevelThickness float64 // how deep into the original shape bevel goes
+	BevelSize float64 // how far from shape outline is bevel
+	BevelSegments int // number of bevel layers
+
+	ExtrudePath *extrascore.Curve3 // 3d spline path to extrude shape along (bevels not supported)
+	Frames *extrascore.FrenetFrames // containing arrays of tangents, normals, binormals
+
+	Material int // material index for front and back faces
+	ExtrudeMaterial int // material index for extrusion and beveled faces
+	UVGenerator *UVGenerator // the uv generator, WorldUVGenerator if nil
+}
+
+func NewDefaultExtrudeGeometryOptions() (*ExtrudeGeometryOptions) {
+	return &ExtrudeGeometryOptions{
+		CurveSegments: 12,
+		Steps: 1,
+		Amount: 100,
+		BevelEnabled: true,
+		BevelThickness: 6,
+		BevelSize: 4,
+		BevelSegments: 3,
+		Material: 0,
+		ExtrudeMaterial: 1,
+	}
+}
+
+// UVGenerator maps the vertices of the lid faces and side walls to uvs.
+type UVGenerator struct {
+	GenerateTopUV func(geometry *core.Geometry, indexA, indexB, indexC int) ([]*math3d.Vector2)
+	GenerateSideWallUV func(geometry *core.Geometry, indexA, indexB, indexC, indexD int) ([]*math3d.Vector2)
+}
+
+// WorldUVGenerator uses the world coordinates of the vertices as uvs.
+var WorldUVGenerator = &UVGenerator{
+
+	GenerateTopUV: func(geometry *core.Geometry, indexA, indexB, indexC int) ([]*math3d.Vector2) {
+		a := geometry.Vertices[ indexA ]
+		b := geometry.Vertices[ indexB ]
+		c := geometry.Vertices[ indexC ]
+
+		return []*math3d.Vector2{
+			math3d.NewVector2( a.X, a.Y ),
+			math3d.NewVector2( b.X, b.Y ),
+			math3d.NewVector2( c.X, c.Y ),
+		}
+	},
+
+	GenerateSideWallUV: func(geometry *core.Geometry, indexA, indexB, indexC, indexD int) ([]*math3d.Vector2) {
+		a := geometry.Vertices[ indexA ]
+		b := geometry.Vertices[ indexB ]
+		c := geometry.Vertices[ indexC ]
+		d := geometry.Vertices[ indexD ]
+
+		if math.Abs( a.Y - b.Y ) < 0.01 {
+			return []*math3d.Vector2{
+				math3d.NewVector2( a.X, 1 - a.Z ),
+				math3d.NewVector2( b.X, 1 - b.Z ),
+				math3d.NewVector2( c.X, 1 - c.Z ),
+				math3d.NewVector2( d.X, 1 - d.Z ),
+			}
+		}
+
+		return []*math3d.Vector2{
+			math3d.NewVector2( a.Y, 1 - a.Z ),
+			math3d.NewVector2( b.Y, 1 - b.Z ),
+			math3d.NewVector2( c.Y, 1 - c.Z ),
+			math3d.NewVector2( d.Y, 1 - d.Z ),
+		}
+	},
+}
+
+// ExtrudeGeometry extrudes shapes along z, or along ExtrudePath if one is set.
+// Front and back faces get options.Material, the sides options.ExtrudeMaterial.
+type ExtrudeGeometry struct {
+	*core.Geometry
+}
+
+func NewExtrudeGeometry(shapes []*extrascore.Shape, options *ExtrudeGeometryOptions) (*ExtrudeGeometry) {
+	g := &ExtrudeGeometry{
+		Geometry: core.NewGeometry(),
+	}
+	g.Type = "ExtrudeGeometry"
+
+	for _, shape := range shapes {
+		g.AddShape( shape, options )
+	}
+
+	g.ComputeFaceNormals()
+
+	// can't really use automatic vertex normals
+	// as then front and back sides get smoothed too
+	// should do separate smoothing just for sides
+
+	return g
+}
+
+func (g *ExtrudeGeometry) AddShape(shape *extrascore.Shape, options *ExtrudeGeometryOptions) {
+
+	amount := options.Amount
+	bevelThickness := options.BevelThickness
+	bevelSize := options.BevelSize
+	bevelSegments := options.BevelSegments
+	bevelEnabled := options.BevelEnabled
+	curveSegments := options.CurveSegments
+	steps := options.Steps
+
+	if steps < 1 {
+		steps = 1
+	}
+
+	extrudePath := options.ExtrudePath
+	extrudeByPath := false
+	var extrudePts []*math3d.Vector3
+	var splineTube *extrascore.FrenetFrames
+
+	uvgen := options.UVGenerator
+	if uvgen == nil {
+		uvgen = WorldUVGenerator
+	}
+
+	binormal := math3d.NewEmptyVector3()
+	normal := math3d.NewEmptyVector3()
+	position2 := math3d.NewEmptyVector3()
+
+	if extrudePath != nil {
+		extrudePts = extrudePath.GetSpacedPoints( steps )
+		extrudeByPath = true
+		bevelEnabled = false // bevels not supported for path extrusion
+
+		// SETUP TNB variables
+		splineTube = options.Frames
+		if splineTube == nil {
+			splineTube = extrascore.NewFrenetFrames( extrudePath, steps, false )
+		}
+	}
+
+	// Safeguards if bevels are not enabled
+
+	if !bevelEnabled {
+		bevelSegments = 0
+		bevelThickness = 0
+		bevelSize = 0
+	}
+
+	shapesOffset := len(g.Vertices)
+
+	vertices, holes := shape.ExtractPoints( curveSegments )
+
+	if !extrascore.ShapeUtils.IsClockWise( vertices ) {
+		vertices = reverseVector2s( vertices )
+
+		// Maybe we should also check if holes are in the opposite direction, just to be safe ...
+		for h, ahole := range holes {
+			if extrascore.ShapeUtils.IsClockWise( ahole ) {
+				holes[ h ] = reverseVector2s( ahole )
+			}
+		}
+	}
+
+	faces := extrascore.ShapeUtils.TriangulateShape( vertices, holes )
+
+	/* Vertices */
+
+	contour := vertices // vertices has all points but contour has only points of circumference
+
+	vertices = append([]*math3d.Vector2{}, contour...)
+	for _, ahole := range holes {
+		vertices = append(vertices, ahole...)
+	}
+
+	scalePt2 := func(pt, vec *math3d.Vector2, size float64) (*math3d.Vector2) {
+		if vec == nil {
+			fmt.Println("THREE.ExtrudeGeometry: vec does not exist")
+		}
+		return vec.Clone().MultiplyScalar( size ).Add( pt )
+	}
+
+	vlen := len(vertices)
+
+	v := func(x, y, z float64) {
+		g.Vertices = append(g.Vertices, math3d.NewVector3( x, y, z ))
+	}
+
+	f3 := func(a, b, c int) {
+		a += shapesOffset
+		b += shapesOffset
+		c += shapesOffset
+
+		g.Faces = append(g.Faces, core.NewFace3( a, b, c, math3d.NewEmptyVector3(), math3d.NewDefaultColor(), options.Material ))
+
+		uvs := uvgen.GenerateTopUV( g.Geometry, a, b, c )
+		g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], uvs)
+	}
+
+	f4 := func(a, b, c, d int) {
+		a += shapesOffset
+		b += shapesOffset
+		c += shapesOffset
+		d += shapesOffset
+
+		g.Faces = append(g.Faces, core.NewFace3( a, b, d, math3d.NewEmptyVector3(), math3d.NewDefaultColor(), options.ExtrudeMaterial ))
+		g.Faces = append(g.Faces, core.NewFace3( b, c, d, math3d.NewEmptyVector3(), math3d.NewDefaultColor(), options.ExtrudeMaterial ))
+
+		uvs := uvgen.GenerateSideWallUV( g.Geometry, a, b, c, d )
+		g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvs[ 0 ], uvs[ 1 ], uvs[ 3 ] })
+		g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvs[ 1 ].Clone(), uvs[ 2 ], uvs[ 3 ].Clone() })
+	}
+
+	// Find directions for point movement
+
+	movements := func(points []*math3d.Vector2) ([]*math3d.Vector2) {
+		moves := make([]*math3d.Vector2, len(points))
+		il := len(points)
+		for i, j, k := 0, il - 1, 1; i < il; i, j, k = i + 1, j + 1, k + 1 {
+			if j == il {
+				j = 0
+			}
+			if k == il {
+				k = 0
+			}
+
+			//  (j)---(i)---(k)
+			moves[ i ] = getBevelVec( points[ i ], points[ j ], points[ k ] )
+		}
+		return moves
+	}
+
+	contourMovements := movements( contour )
+
+	holesMovements := make([][]*math3d.Vector2, 0, len(holes))
+	verticesMovements := append([]*math3d.Vector2{}, contourMovements...)
+
+	for _, ahole := range holes {
+		oneHoleMovements := movements( ahole )
+		holesMovements = append(holesMovements, oneHoleMovements)
+		verticesMovements = append(verticesMovements, oneHoleMovements...)
+	}
+
+	// bevelLayer adds the contour contracted and the holes expanded by bs, at depth z
+
+	bevelLayer := func(bs, z float64) {
+		for i, pt := range contour {
+			vert := scalePt2( pt, contourMovements[ i ], bs )
+			v( vert.X, vert.Y, z )
+		}
+
+		for h, ahole := range holes {
+			oneHoleMovements := holesMovements[ h ]
+			for i, pt := range ahole {
+				vert := scalePt2( pt, oneHoleMovements[ i ], bs )
+				v( vert.X, vert.Y, z )
+			}
+		}
+	}
+
+	// Loop bevelSegments, 1 for the front, 1 for the back
+
+	for b := 0; b < bevelSegments; b ++ {
+		t := float64( b ) / float64( bevelSegments )
+		z := bevelThickness * math.Cos( t * math.Pi / 2 )
+		bs := bevelSize * math.Sin( t * math.Pi / 2 )
+
+		bevelLayer( bs, - z )
+	}
+
+	bs := bevelSize
+
+	// Back facing vertices, then the stepped vertices including the front facing ones
+
+	for s := 0; s <= steps; s ++ {
+		for i := 0; i < vlen; i ++ {
+
+			vert := vertices[ i ]
+			if bevelEnabled {
+				vert = scalePt2( vertices[ i ], verticesMovements[ i ], bs )
+			}
+
+			if !extrudeByPath {
+				v( vert.X, vert.Y, amount / float64( steps ) * float64( s ) )
+			} else {
+				normal.Copy( splineTube.Normals[ s ] ).MultiplyScalar( vert.X )
+				binormal.Copy( splineTube.Binormals[ s ] ).MultiplyScalar( vert.Y )
+
+				position2.Copy( extrudePts[ s ] ).Add( normal ).Add( binormal )
+
+				v( position2.X, position2.Y, position2.Z )
+			}
+		}
+	}
+
+	// Add bevel segments planes
+
+	for b := bevelSegments - 1; b >= 0; b -- {
+		t := float64( b ) / float64( bevelSegments )
+		z := bevelThickness * math.Cos( t * math.Pi / 2 )
+		bs := bevelSize * math.Sin( t * math.Pi / 2 )
+
+		bevelLayer( bs, amount + z )
+	}
+
+	/* Faces */
+
+	// Top and bottom faces
+
+	layer := steps + bevelSegments * 2
+	offset := vlen * layer
+
+	// Bottom faces
+	for _, face := range faces {
+		f3( face[ 2 ], face[ 1 ], face[ 0 ] )
+	}
+
+	// Top faces
+	for _, face := range faces {
+		f3( face[ 0 ] + offset, face[ 1 ] + offset, face[ 2 ] + offset )
+	}
+
+	// Sides faces
+
+	sidewalls := func(contour []*math3d.Vector2, layeroffset int) {
+		for i := len(contour) - 1; i >= 0; i -- {
+			j := i
+			k := i - 1
+			if k < 0 {
+				k = len(contour) - 1
+			}
+
+			sl := steps + bevelSegments * 2
+
+			for s := 0; s < sl; s ++ {
+				slen1 := vlen * s
+				slen2 := vlen * ( s + 1 )
+
+				a := layeroffset + j + slen1
+				b := layeroffset + k + slen1
+				c := layeroffset + k + slen2
+				d := layeroffset + j + slen2
+
+				f4( a, b, c, d )
+			}
+		}
+	}
+
+	layeroffset := 0
+	sidewalls( contour, layeroffset )
+	layeroffset += len(contour)
+
+	for _, ahole := range holes {
+		sidewalls( ahole, layeroffset )
+		layeroffset += len(ahole)
+	}
+}
+
+// getBevelVec computes for inPt the corresponding point inPt' on a new contour
+// shifted by 1 unit (length of normalized vector) to the left.
+// If we walk along contour clockwise, this new contour is outside the old one.
+//
+// inPt' is the intersection of the two lines parallel to the two
+// adjacent edges of inPt at a distance of 1 unit on the left side.
+func getBevelVec(inPt, inPrev, inNext *math3d.Vector2) (*math3d.Vector2) {
+
+	const EPSILON = 2.220446049250313e-16
+
+	var v_trans_x, v_trans_y float64 // resulting translation vector for inPt
+	shrink_by := 1.0
+
+	// good reading for geometry algorithms (here: line-line intersection)
+	// http://geomalgorithms.com/a05-_intersect-1.html
+
+	v_prev_x := inPt.X - inPrev.X
+	v_prev_y := inPt.Y - inPrev.Y
+	v_next_x := inNext.X - inPt.X
+	v_next_y := inNext.Y - inPt.Y
+
+	v_prev_lensq := v_prev_x * v_prev_x + v_prev_y * v_prev_y
+
+	// check for collinear edges
+	collinear0 := v_prev_x * v_next_y - v_prev_y * v_next_x
+
+	if math.Abs( collinear0 ) > EPSILON {
+
+		// not collinear
+
+		// length of vectors for normalizing
+
+		v_prev_len := math.Sqrt( v_prev_lensq )
+		v_next_len := math.Sqrt( v_next_x * v_next_x + v_next_y * v_next_y )
+
+		// shift adjacent points by unit vectors to the left
+
+		ptPrevShift_x := inPrev.X - v_prev_y / v_prev_len
+		ptPrevShift_y := inPrev.Y + v_prev_x / v_prev_len
+
+		ptNextShift_x := inNext.X - v_next_y / v_next_len
+		ptNextShift_y := inNext.Y + v_next_x / v_next_len
+
+		// scaling factor for v_prev to intersection point
+
+		sf := ( ( ptNextShift_x - ptPrevShift_x ) * v_next_y -
+			( ptNextShift_y - ptPrevShift_y ) * v_next_x ) /
+			( v_prev_x * v_next_y - v_prev_y * v_next_x )
+
+		// vector from inPt to intersection point
+
+		v_trans_x = ptPrevShift_x + v_prev_x * sf - inPt.X
+		v_trans_y = ptPrevShift_y + v_prev_y * sf - inPt.Y
+
+		// Don't normalize!, otherwise sharp corners become ugly
+		//  but prevent crazy spikes
+		v_trans_lensq := v_trans_x * v_trans_x + v_trans_y * v_trans_y
+		if v_trans_lensq <= 2 {
+			return math3d.NewVector2( v_trans_x, v_trans_y )
+		}
+
+		shrink_by = math.Sqrt( v_trans_lensq / 2 )
+
+	} else {
+
+		// handle special case of collinear edges
+
+		direction_eq := false // assumes: opposite
+		if v_prev_x > EPSILON {
+			if v_next_x > EPSILON {
+				direction_eq = true
+			}
+		} else if v_prev_x < - EPSILON {
+			if v_next_x < - EPSILON {
+				direction_eq = true
+			}
+		} else if math.Signbit( v_prev_y ) == math.Signbit( v_next_y ) {
+			direction_eq = true
+		}
+
+		if direction_eq {
+			// lines are a straight sequence
+			v_trans_x = - v_prev_y
+			v_trans_y = v_prev_x
+			shrink_by = math.Sqrt( v_prev_lensq )
+		} else {
+			// lines are a straight spike
+			v_trans_x = v_prev_x
+			v_trans_y = v_prev_y
+			shrink_by = math.Sqrt( v_prev_lensq / 2 )
+		}
+	}
+
+	return math3d.NewVector2( v_trans_x / shrink_by, v_trans_y / shrink_by )
+}
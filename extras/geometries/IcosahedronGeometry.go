@@ -0,0 +1,40 @@
+package geometries
+
+import "math"
+
+type IcosahedronGeometry struct {
+	*PolyhedronGeometry
+}
+
+func NewIcosahedronGeometry(radius float64, detail int) (*IcosahedronGeometry) {
+	t := ( 1 + math.Sqrt( 5 ) ) / 2
+
+	vertices := []float64{
+		- 1, t, 0,    1, t, 0,    - 1, - t, 0,    1, - t, 0,
+		0, - 1, t,    0, 1, t,    0, - 1, - t,    0, 1, - t,
+		t, 0, - 1,    t, 0, 1,    - t, 0, - 1,    - t, 0, 1,
+	}
+
+	indices := []int{
+		0, 11, 5,    0, 5, 1,    0, 1, 7,    0, 7, 10,    0, 10, 11,
+		1, 5, 9,    5, 11, 4,    11, 10, 2,    10, 7, 6,    7, 1, 8,
+		3, 9, 4,    3, 4, 2,    3, 2, 6,    3, 6, 8,    3, 8, 9,
+		4, 9, 5,    2, 4, 11,    6, 2, 10,    8, 6, 7,    9, 8, 1,
+	}
+
+	g := &IcosahedronGeometry{
+		PolyhedronGeometry: NewPolyhedronGeometry( vertices, indices, radius, detail ),
+	}
+	g.Type = "IcosahedronGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"radius": radius,
+		"detail": detail,
+	}
+
+	return g
+}
+
+func (g *IcosahedronGeometry) Clone() (*IcosahedronGeometry) {
+	return NewIcosahedronGeometry(g.Radius, g.Detail)
+}
@@ -0,0 +1,107 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// LatheGeometry revolves a profile of points (x is the distance from the axis)
+// around the y axis.
+type LatheGeometry struct {
+	*core.Geometry
+	Points []*math3d.Vector2
+	Segments int
+	PhiStart, PhiLength float64
+}
+
+func NewDefaultLatheGeometry(points []*math3d.Vector2) (*LatheGeometry) {
+	return NewLatheGeometry(points, 12, 0, math.Pi * 2)
+}
+
+// NewLatheGeometry revolves points in segments steps, starting at phiStart and
+// covering phiLength radians.
+func NewLatheGeometry(points []*math3d.Vector2, segments int, phiStart, phiLength float64) (*LatheGeometry) {
+	if segments < 1 {
+		segments = 12
+	}
+
+	g := &LatheGeometry{
+		Geometry: core.NewGeometry(),
+		Points: points,
+		Segments: segments,
+		PhiStart: phiStart,
+		PhiLength: phiLength,
+	}
+	g.Type = "LatheGeometry"
+
+	jsonPoints := make([]map[string]interface{}, 0, len(points))
+	for _, pt := range points {
+		jsonPoints = append(jsonPoints, map[string]interface{}{ "x": pt.X, "y": pt.Y })
+	}
+
+	g.Parameters = map[string]interface{}{
+		"points": jsonPoints,
+		"segments": segments,
+		"phiStart": phiStart,
+		"phiLength": phiLength,
+	}
+
+	inversePointLength := 1.0 / float64( len(points) - 1 )
+	inverseSegments := 1.0 / float64( segments )
+
+	for i := 0; i <= segments; i ++ {
+
+		phi := phiStart + float64( i ) * inverseSegments * phiLength
+
+		sin := math.Sin( phi )
+		cos := math.Cos( phi )
+
+		for _, pt := range points {
+			g.Vertices = append(g.Vertices, math3d.NewVector3( sin * pt.X, pt.Y, cos * pt.X ))
+		}
+	}
+
+	np := len(points)
+
+	for i := 0; i < segments; i ++ {
+		for j := 0; j < np - 1; j ++ {
+
+			base := j + np * i
+			a := base
+			b := base + np
+			c := base + 1 + np
+			d := base + 1
+
+			u0 := float64( i ) * inverseSegments
+			v0 := float64( j ) * inversePointLength
+			u1 := u0 + inverseSegments
+			v1 := v0 + inversePointLength
+
+			g.Faces = append(g.Faces, core.NewDefaultFace3( a, b, d ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{
+				math3d.NewVector2( u0, v0 ),
+				math3d.NewVector2( u1, v0 ),
+				math3d.NewVector2( u0, v1 ),
+			})
+
+			g.Faces = append(g.Faces, core.NewDefaultFace3( b, c, d ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{
+				math3d.NewVector2( u1, v0 ),
+				math3d.NewVector2( u1, v1 ),
+				math3d.NewVector2( u0, v1 ),
+			})
+		}
+	}
+
+	g.MergeVertices()
+	g.ComputeFaceNormals()
+	g.ComputeVertexNormals( false )
+
+	return g
+}
+
+func (g *LatheGeometry) Clone() (*LatheGeometry) {
+	return NewLatheGeometry(g.Points, g.Segments, g.PhiStart, g.PhiLength)
+}
@@ -0,0 +1,31 @@
+package geometries
+
+type OctahedronGeometry struct {
+	*PolyhedronGeometry
+}
+
+func NewOctahedronGeometry(radius float64, detail int) (*OctahedronGeometry) {
+	vertices := []float64{
+		1, 0, 0,    - 1, 0, 0,    0, 1, 0,    0, - 1, 0,    0, 0, 1,    0, 0, - 1,
+	}
+
+	indices := []int{
+		0, 2, 4,    0, 4, 3,    0, 3, 5,    0, 5, 2,    1, 2, 5,    1, 5, 3,    1, 3, 4,    1, 4, 2,
+	}
+
+	g := &OctahedronGeometry{
+		PolyhedronGeometry: NewPolyhedronGeometry( vertices, indices, radius, detail ),
+	}
+	g.Type = "OctahedronGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"radius": radius,
+		"detail": detail,
+	}
+
+	return g
+}
+
+func (g *OctahedronGeometry) Clone() (*OctahedronGeometry) {
+	return NewOctahedronGeometry(g.Radius, g.Detail)
+}
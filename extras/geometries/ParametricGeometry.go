@@ -0,0 +1,78 @@
+package geometries
+
+import (
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// ParametricGeometry is a surface sampled from a function of u and v, both in [0, 1].
+type ParametricGeometry struct {
+	*core.Geometry
+	Func func(u, v float64, target *math3d.Vector3)
+	Slices, Stacks int
+}
+
+// NewParametricGeometry samples fn at (slices + 1) * (stacks + 1) points. fn
+// writes the point for u, v into target.
+func NewParametricGeometry(fn func(u, v float64, target *math3d.Vector3), slices, stacks int) (*ParametricGeometry) {
+	if slices < 1 {
+		slices = 1
+	}
+	if stacks < 1 {
+		stacks = 1
+	}
+
+	g := &ParametricGeometry{
+		Geometry: core.NewGeometry(),
+		Func: fn,
+		Slices: slices,
+		Stacks: stacks,
+	}
+	g.Type = "ParametricGeometry"
+
+	sliceCount := slices + 1
+
+	for i := 0; i <= stacks; i ++ {
+
+		v := float64( i ) / float64( stacks )
+
+		for j := 0; j <= slices; j ++ {
+
+			u := float64( j ) / float64( slices )
+
+			p := math3d.NewEmptyVector3()
+			fn( u, v, p )
+			g.Vertices = append(g.Vertices, p)
+		}
+	}
+
+	for i := 0; i < stacks; i ++ {
+		for j := 0; j < slices; j ++ {
+
+			a := i * sliceCount + j
+			b := i * sliceCount + j + 1
+			c := ( i + 1 ) * sliceCount + j + 1
+			d := ( i + 1 ) * sliceCount + j
+
+			uva := math3d.NewVector2( float64( j ) / float64( slices ), float64( i ) / float64( stacks ) )
+			uvb := math3d.NewVector2( float64( j + 1 ) / float64( slices ), float64( i ) / float64( stacks ) )
+			uvc := math3d.NewVector2( float64( j + 1 ) / float64( slices ), float64( i + 1 ) / float64( stacks ) )
+			uvd := math3d.NewVector2( float64( j ) / float64( slices ), float64( i + 1 ) / float64( stacks ) )
+
+			g.Faces = append(g.Faces, core.NewDefaultFace3( a, b, d ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uva, uvb, uvd })
+
+			g.Faces = append(g.Faces, core.NewDefaultFace3( b, c, d ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvb.Clone(), uvc, uvd.Clone() })
+		}
+	}
+
+	g.ComputeFaceNormals()
+	g.ComputeVertexNormals( false )
+
+	return g
+}
+
+func (g *ParametricGeometry) Clone() (*ParametricGeometry) {
+	return NewParametricGeometry(g.Func, g.Slices, g.Stacks)
+}
@@ -0,0 +1,103 @@
+package geometries
+
+import (
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+type PlaneGeometry struct {
+	*core.Geometry
+	Width, Height float64
+	WidthSegments, HeightSegments int
+}
+
+func NewDefaultPlaneGeometry(width, height float64) (*PlaneGeometry) {
+	return NewPlaneGeometry(width, height, 1, 1)
+}
+
+func NewPlaneGeometry(width, height float64, widthSegments, heightSegments int) (*PlaneGeometry) {
+	if widthSegments < 1 {
+		widthSegments = 1
+	}
+	if heightSegments < 1 {
+		heightSegments = 1
+	}
+
+	g := &PlaneGeometry{
+		Geometry: core.NewGeometry(),
+		Width: width,
+		Height: height,
+		WidthSegments: widthSegments,
+		HeightSegments: heightSegments,
+	}
+	g.Type = "PlaneGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"width": width,
+		"height": height,
+		"widthSegments": widthSegments,
+		"heightSegments": heightSegments,
+	}
+
+	width_half := width / 2
+	height_half := height / 2
+
+	gridX := widthSegments
+	gridY := heightSegments
+
+	gridX1 := gridX + 1
+	gridY1 := gridY + 1
+
+	segment_width := width / float64( gridX )
+	segment_height := height / float64( gridY )
+
+	normal := math3d.NewVector3( 0, 0, 1 )
+
+	for iy := 0; iy < gridY1; iy ++ {
+		y := float64( iy ) * segment_height - height_half
+		for ix := 0; ix < gridX1; ix ++ {
+			x := float64( ix ) * segment_width - width_half
+			g.Vertices = append(g.Vertices, math3d.NewVector3( x, - y, 0 ))
+		}
+	}
+
+	for iy := 0; iy < gridY; iy ++ {
+		for ix := 0; ix < gridX; ix ++ {
+
+			a := ix + gridX1 * iy
+			b := ix + gridX1 * ( iy + 1 )
+			c := ( ix + 1 ) + gridX1 * ( iy + 1 )
+			d := ( ix + 1 ) + gridX1 * iy
+
+			u0 := float64( ix ) / float64( gridX )
+			u1 := float64( ix + 1 ) / float64( gridX )
+			v0 := 1 - float64( iy ) / float64( gridY )
+			v1 := 1 - float64( iy + 1 ) / float64( gridY )
+
+			uva := math3d.NewVector2( u0, v0 )
+			uvb := math3d.NewVector2( u0, v1 )
+			uvc := math3d.NewVector2( u1, v1 )
+			uvd := math3d.NewVector2( u1, v0 )
+
+			face := core.NewDefaultFace3( a, b, d )
+			face.Normal.Copy( normal )
+			face.VertexNormals = append( face.VertexNormals, normal.Clone(), normal.Clone(), normal.Clone() )
+
+			g.Faces = append(g.Faces, face)
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uva, uvb, uvd })
+
+			face = core.NewDefaultFace3( b, c, d )
+			face.Normal.Copy( normal )
+			face.VertexNormals = append( face.VertexNormals, normal.Clone(), normal.Clone(), normal.Clone() )
+
+			g.Faces = append(g.Faces, face)
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvb.Clone(), uvc, uvd.Clone() })
+		}
+	}
+
+	return g
+}
+
+func (g *PlaneGeometry) Clone() (*PlaneGeometry) {
+	return NewPlaneGeometry(g.Width, g.Height, g.WidthSegments, g.HeightSegments)
+}
@@ -0,0 +1,194 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// PolyhedronGeometry projects the faces of a base solid onto a sphere, splitting
+// every face into 4^detail triangles.
+type PolyhedronGeometry struct {
+	*core.Geometry
+	Radius float64
+	Detail int
+}
+
+// polyhedronVertex is a vertex of the geometry together with its index and spherical uv
+type polyhedronVertex struct {
+	*math3d.Vector3
+	index int
+	uv *math3d.Vector2
+}
+
+func NewPolyhedronGeometry(vertices []float64, indices []int, radius float64, detail int) (*PolyhedronGeometry) {
+	g := &PolyhedronGeometry{
+		Geometry: core.NewGeometry(),
+		Radius: radius,
+		Detail: detail,
+	}
+	g.Type = "PolyhedronGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"vertices": vertices,
+		"indices": indices,
+		"radius": radius,
+		"detail": detail,
+	}
+
+	prepared := make([]*polyhedronVertex, 0)
+
+	azimuth := func(vector *math3d.Vector3) float64 {
+		return math.Atan2( vector.Z, - vector.X )
+	}
+
+	inclination := func(vector *math3d.Vector3) float64 {
+		return math.Atan2( - vector.Y, math.Sqrt( ( vector.X * vector.X ) + ( vector.Z * vector.Z ) ) )
+	}
+
+	// project vector onto the unit sphere
+	prepare := func(vector *math3d.Vector3) (*polyhedronVertex) {
+		vertex := &polyhedronVertex{
+			Vector3: vector.Normalize().Clone(),
+		}
+		g.Vertices = append(g.Vertices, vertex.Vector3)
+		vertex.index = len(g.Vertices) - 1
+		prepared = append(prepared, vertex)
+
+		// texture coords are equivalent to map coords, calculate angle and convert to fraction of a circle
+		u := azimuth( vector ) / 2 / math.Pi + 0.5
+		v := inclination( vector ) / math.Pi + 0.5
+		vertex.uv = math3d.NewVector2( u, 1 - v )
+
+		return vertex
+	}
+
+	// texture fixing hack
+	correctUV := func(uv *math3d.Vector2, vector *math3d.Vector3, azimuth float64) (*math3d.Vector2) {
+		if azimuth < 0 && uv.X == 1 {
+			uv = math3d.NewVector2( uv.X - 1, uv.Y )
+		}
+		if vector.X == 0 && vector.Z == 0 {
+			uv = math3d.NewVector2( azimuth / 2 / math.Pi + 0.5, uv.Y )
+		}
+		return uv.Clone()
+	}
+
+	centroid := math3d.NewEmptyVector3()
+
+	// approximate a curved face with recursively sub-divided triangles
+	makeFace := func(v1, v2, v3 *polyhedronVertex, materialIndex int) {
+		face := core.NewArraysFace3( v1.index, v2.index, v3.index, []*math3d.Vector3{ v1.Clone(), v2.Clone(), v3.Clone() }, nil, materialIndex )
+		g.Faces = append(g.Faces, face)
+
+		centroid.Copy( v1.Vector3 ).Add( v2.Vector3 ).Add( v3.Vector3 ).DivideScalar( 3 )
+
+		azi := azimuth( centroid )
+
+		g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{
+			correctUV( v1.uv, v1.Vector3, azi ),
+			correctUV( v2.uv, v2.Vector3, azi ),
+			correctUV( v3.uv, v3.Vector3, azi ),
+		})
+	}
+
+	// analytically subdivide a face to the required detail level
+	subdivide := func(face *core.Face3, detail int) {
+		cols := 1 << uint( detail )
+		a := prepare( g.Vertices[ face.A ] )
+		b := prepare( g.Vertices[ face.B ] )
+		c := prepare( g.Vertices[ face.C ] )
+		v := make([][]*polyhedronVertex, cols + 1)
+
+		materialIndex := face.MaterialIndex
+
+		// construct all of the vertices for this subdivision
+		for i := 0; i <= cols; i ++ {
+
+			aj := prepare( a.Clone().Lerp( c.Vector3, float64( i ) / float64( cols ) ) )
+			bj := prepare( b.Clone().Lerp( c.Vector3, float64( i ) / float64( cols ) ) )
+
+			rows := cols - i
+
+			for j := 0; j <= rows; j ++ {
+				if j == 0 && i == cols {
+					v[ i ] = append(v[ i ], aj)
+				} else {
+					v[ i ] = append(v[ i ], prepare( aj.Clone().Lerp( bj.Vector3, float64( j ) / float64( rows ) ) ))
+				}
+			}
+		}
+
+		// construct all of the faces
+		for i := 0; i < cols; i ++ {
+			for j := 0; j < 2 * ( cols - i ) - 1; j ++ {
+				k := j / 2
+				if j % 2 == 0 {
+					makeFace( v[ i ][ k + 1 ], v[ i + 1 ][ k ], v[ i ][ k ], materialIndex )
+				} else {
+					makeFace( v[ i ][ k + 1 ], v[ i + 1 ][ k + 1 ], v[ i + 1 ][ k ], materialIndex )
+				}
+			}
+		}
+	}
+
+	for i := 0; i + 2 < len(vertices); i += 3 {
+		prepare( math3d.NewVector3( vertices[ i ], vertices[ i + 1 ], vertices[ i + 2 ] ) )
+	}
+
+	p := prepared
+	faces := make([]*core.Face3, 0)
+
+	for i, j := 0, 0; i + 2 < len(indices); i, j = i + 3, j + 1 {
+		v1 := p[ indices[ i ] ]
+		v2 := p[ indices[ i + 1 ] ]
+		v3 := p[ indices[ i + 2 ] ]
+
+		faces = append(faces, core.NewArraysFace3( v1.index, v2.index, v3.index, []*math3d.Vector3{ v1.Clone(), v2.Clone(), v3.Clone() }, nil, j ))
+	}
+
+	for _, face := range faces {
+		subdivide( face, detail )
+	}
+
+	// handle case when face straddles the seam
+	for _, uvs := range g.FaceVertexUvs[ 0 ] {
+		x0 := uvs[ 0 ].X
+		x1 := uvs[ 1 ].X
+		x2 := uvs[ 2 ].X
+
+		max := math.Max( x0, math.Max( x1, x2 ) )
+		min := math.Min( x0, math.Min( x1, x2 ) )
+
+		if max > 0.9 && min < 0.1 { // 0.9 is somewhat arbitrary
+			if x0 < 0.2 {
+				uvs[ 0 ].X += 1
+			}
+			if x1 < 0.2 {
+				uvs[ 1 ].X += 1
+			}
+			if x2 < 0.2 {
+				uvs[ 2 ].X += 1
+			}
+		}
+	}
+
+	// apply radius
+	for _, vertex := range g.Vertices {
+		vertex.MultiplyScalar( radius )
+	}
+
+	// merge vertices
+	g.MergeVertices()
+
+	g.ComputeFaceNormals()
+
+	g.BoundingSphere = math3d.NewSphere( math3d.NewEmptyVector3(), radius )
+
+	return g
+}
+
+func (g *PolyhedronGeometry) Clone() (*PolyhedronGeometry) {
+	return NewPolyhedronGeometry(g.Parameters["vertices"].([]float64), g.Parameters["indices"].([]int), g.Radius, g.Detail)
+}
@@ -0,0 +1,83 @@
+package geometries
+
+import (
+	"github.com/uzudil/three.go/core"
+	extrascore "github.com/uzudil/three.go/extras/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// ShapeGeometry is a flat, triangulated version of one or more shapes in the xy plane.
+type ShapeGeometry struct {
+	*core.Geometry
+	CurveSegments int
+}
+
+func NewDefaultShapeGeometry(shape *extrascore.Shape) (*ShapeGeometry) {
+	return NewShapeGeometry([]*extrascore.Shape{ shape }, 12)
+}
+
+// NewShapeGeometry triangulates shapes, sampling their curves in curveSegments steps.
+// All faces get material index 0, use AddShape to add shapes with other indices.
+func NewShapeGeometry(shapes []*extrascore.Shape, curveSegments int) (*ShapeGeometry) {
+	g := &ShapeGeometry{
+		Geometry: core.NewGeometry(),
+		CurveSegments: curveSegments,
+	}
+	g.Type = "ShapeGeometry"
+
+	for _, shape := range shapes {
+		g.AddShape( shape, 0 )
+	}
+
+	g.ComputeFaceNormals()
+
+	return g
+}
+
+// AddShape adds the faces of a shape with the given material index.
+func (g *ShapeGeometry) AddShape(shape *extrascore.Shape, materialIndex int) {
+
+	shapesOffset := len(g.Vertices)
+	vertices, holes := shape.ExtractPoints( g.CurveSegments )
+
+	// the outline is expected clockwise and the holes counter clockwise
+	if !extrascore.ShapeUtils.IsClockWise( vertices ) {
+		vertices = reverseVector2s( vertices )
+
+		// maybe we should also check if holes are in the opposite direction, just to be safe...
+		for i, hole := range holes {
+			if extrascore.ShapeUtils.IsClockWise( hole ) {
+				holes[ i ] = reverseVector2s( hole )
+			}
+		}
+	}
+
+	faces := extrascore.ShapeUtils.TriangulateShape( vertices, holes )
+
+	// vertices has all points but not holes; holes has holes
+
+	for _, hole := range holes {
+		vertices = append(vertices, hole...)
+	}
+
+	for _, vert := range vertices {
+		g.Vertices = append(g.Vertices, math3d.NewVector3( vert.X, vert.Y, 0 ))
+	}
+
+	for _, face := range faces {
+		a := face[ 0 ] + shapesOffset
+		b := face[ 1 ] + shapesOffset
+		c := face[ 2 ] + shapesOffset
+
+		g.Faces = append(g.Faces, core.NewFace3( a, b, c, math3d.NewEmptyVector3(), math3d.NewDefaultColor(), materialIndex ))
+		g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], WorldUVGenerator.GenerateTopUV( g.Geometry, a, b, c ))
+	}
+}
+
+func reverseVector2s(points []*math3d.Vector2) ([]*math3d.Vector2) {
+	reversed := make([]*math3d.Vector2, len(points))
+	for i, point := range points {
+		reversed[ len(points) - 1 - i ] = point
+	}
+	return reversed
+}
@@ -0,0 +1,133 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+type SphereGeometry struct {
+	*core.Geometry
+	Radius float64
+	WidthSegments, HeightSegments int
+	PhiStart, PhiLength float64
+	ThetaStart, ThetaLength float64
+}
+
+func NewDefaultSphereGeometry(radius float64, widthSegments, heightSegments int) (*SphereGeometry) {
+	return NewSphereGeometry(radius, widthSegments, heightSegments, 0, math.Pi * 2, 0, math.Pi)
+}
+
+func NewSphereGeometry(radius float64, widthSegments, heightSegments int, phiStart, phiLength, thetaStart, thetaLength float64) (*SphereGeometry) {
+	if widthSegments < 3 {
+		widthSegments = 3
+	}
+	if heightSegments < 2 {
+		heightSegments = 2
+	}
+
+	g := &SphereGeometry{
+		Geometry: core.NewGeometry(),
+		Radius: radius,
+		WidthSegments: widthSegments,
+		HeightSegments: heightSegments,
+		PhiStart: phiStart,
+		PhiLength: phiLength,
+		ThetaStart: thetaStart,
+		ThetaLength: thetaLength,
+	}
+	g.Type = "SphereGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"radius": radius,
+		"widthSegments": widthSegments,
+		"heightSegments": heightSegments,
+		"phiStart": phiStart,
+		"phiLength": phiLength,
+		"thetaStart": thetaStart,
+		"thetaLength": thetaLength,
+	}
+
+	vertices := make([][]int, 0)
+	uvs := make([][]*math3d.Vector2, 0)
+
+	for y := 0; y <= heightSegments; y ++ {
+
+		verticesRow := make([]int, 0)
+		uvsRow := make([]*math3d.Vector2, 0)
+
+		for x := 0; x <= widthSegments; x ++ {
+
+			u := float64( x ) / float64( widthSegments )
+			v := float64( y ) / float64( heightSegments )
+
+			vertex := math3d.NewVector3(
+				- radius * math.Cos( phiStart + u * phiLength ) * math.Sin( thetaStart + v * thetaLength ),
+				radius * math.Cos( thetaStart + v * thetaLength ),
+				radius * math.Sin( phiStart + u * phiLength ) * math.Sin( thetaStart + v * thetaLength ),
+			)
+
+			g.Vertices = append(g.Vertices, vertex)
+
+			verticesRow = append(verticesRow, len(g.Vertices) - 1)
+			uvsRow = append(uvsRow, math3d.NewVector2( u, 1 - v ))
+		}
+
+		vertices = append(vertices, verticesRow)
+		uvs = append(uvs, uvsRow)
+	}
+
+	for y := 0; y < heightSegments; y ++ {
+		for x := 0; x < widthSegments; x ++ {
+
+			v1 := vertices[ y ][ x + 1 ]
+			v2 := vertices[ y ][ x ]
+			v3 := vertices[ y + 1 ][ x ]
+			v4 := vertices[ y + 1 ][ x + 1 ]
+
+			n1 := g.Vertices[ v1 ].Clone().Normalize()
+			n2 := g.Vertices[ v2 ].Clone().Normalize()
+			n3 := g.Vertices[ v3 ].Clone().Normalize()
+			n4 := g.Vertices[ v4 ].Clone().Normalize()
+
+			uv1 := uvs[ y ][ x + 1 ].Clone()
+			uv2 := uvs[ y ][ x ].Clone()
+			uv3 := uvs[ y + 1 ][ x ].Clone()
+			uv4 := uvs[ y + 1 ][ x + 1 ].Clone()
+
+			if math.Abs( g.Vertices[ v1 ].Y ) == radius {
+
+				// the top row degenerates to a triangle fan around the pole
+				uv1.X = ( uv1.X + uv2.X ) / 2
+				g.Faces = append(g.Faces, core.NewArraysFace3( v1, v3, v4, []*math3d.Vector3{ n1, n3, n4 }, nil, 0 ))
+				g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uv1, uv3, uv4 })
+
+			} else if math.Abs( g.Vertices[ v3 ].Y ) == radius {
+
+				uv3.X = ( uv3.X + uv4.X ) / 2
+				g.Faces = append(g.Faces, core.NewArraysFace3( v1, v2, v3, []*math3d.Vector3{ n1, n2, n3 }, nil, 0 ))
+				g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uv1, uv2, uv3 })
+
+			} else {
+
+				g.Faces = append(g.Faces, core.NewArraysFace3( v1, v2, v4, []*math3d.Vector3{ n1, n2, n4 }, nil, 0 ))
+				g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uv1, uv2, uv4 })
+
+				g.Faces = append(g.Faces, core.NewArraysFace3( v2, v3, v4, []*math3d.Vector3{ n2.Clone(), n3, n4.Clone() }, nil, 0 ))
+				g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uv2.Clone(), uv3, uv4.Clone() })
+
+			}
+		}
+	}
+
+	g.ComputeFaceNormals()
+
+	g.BoundingSphere = math3d.NewSphere( math3d.NewEmptyVector3(), radius )
+
+	return g
+}
+
+func (g *SphereGeometry) Clone() (*SphereGeometry) {
+	return NewSphereGeometry(g.Radius, g.WidthSegments, g.HeightSegments, g.PhiStart, g.PhiLength, g.ThetaStart, g.ThetaLength)
+}
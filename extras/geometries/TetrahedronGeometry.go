@@ -0,0 +1,31 @@
+package geometries
+
+type TetrahedronGeometry struct {
+	*PolyhedronGeometry
+}
+
+func NewTetrahedronGeometry(radius float64, detail int) (*TetrahedronGeometry) {
+	vertices := []float64{
+		1, 1, 1,    - 1, - 1, 1,    - 1, 1, - 1,    1, - 1, - 1,
+	}
+
+	indices := []int{
+		2, 1, 0,    0, 3, 2,    1, 3, 0,    2, 3, 1,
+	}
+
+	g := &TetrahedronGeometry{
+		PolyhedronGeometry: NewPolyhedronGeometry( vertices, indices, radius, detail ),
+	}
+	g.Type = "TetrahedronGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"radius": radius,
+		"detail": detail,
+	}
+
+	return g
+}
+
+func (g *TetrahedronGeometry) Clone() (*TetrahedronGeometry) {
+	return NewTetrahedronGeometry(g.Radius, g.Detail)
+}
@@ -0,0 +1,56 @@
+package geometries
+
+import (
+	extrascore "github.com/uzudil/three.go/extras/core"
+)
+
+// TextGeometryParameters controls how text is laid out and extruded.
+type TextGeometryParameters struct {
+	Font *extrascore.Font
+	Size float64 // size of the text, the height of an em
+	Height float64 // thickness to extrude text
+	CurveSegments int // number of points on the curves
+
+	BevelEnabled bool // turn on bevel
+	BevelThickness float64 // how deep into text bevel goes
+	BevelSize float64 // how far from text outline is bevel
+}
+
+func NewDefaultTextGeometryParameters(font *extrascore.Font) (*TextGeometryParameters) {
+	return &TextGeometryParameters{
+		Font: font,
+		Size: 100,
+		Height: 50,
+		CurveSegments: 4,
+		BevelEnabled: false,
+		BevelThickness: 10,
+		BevelSize: 8,
+	}
+}
+
+// TextGeometry is text extruded along z. The front and back faces get material
+// index 0, the sides 1.
+type TextGeometry struct {
+	*ExtrudeGeometry
+	Text string
+}
+
+func NewTextGeometry(text string, parameters *TextGeometryParameters) (*TextGeometry) {
+
+	shapes := parameters.Font.GenerateShapes( text, parameters.Size )
+
+	options := NewDefaultExtrudeGeometryOptions()
+	options.Amount = parameters.Height
+	options.CurveSegments = parameters.CurveSegments
+	options.BevelEnabled = parameters.BevelEnabled
+	options.BevelThickness = parameters.BevelThickness
+	options.BevelSize = parameters.BevelSize
+
+	g := &TextGeometry{
+		ExtrudeGeometry: NewExtrudeGeometry( shapes, options ),
+		Text: text,
+	}
+	g.Type = "TextGeometry"
+
+	return g
+}
@@ -0,0 +1,91 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+type TorusGeometry struct {
+	*core.Geometry
+	Radius, Tube float64
+	RadialSegments, TubularSegments int
+	Arc float64
+}
+
+func NewDefaultTorusGeometry(radius, tube float64) (*TorusGeometry) {
+	return NewTorusGeometry(radius, tube, 8, 6, math.Pi * 2)
+}
+
+func NewTorusGeometry(radius, tube float64, radialSegments, tubularSegments int, arc float64) (*TorusGeometry) {
+	g := &TorusGeometry{
+		Geometry: core.NewGeometry(),
+		Radius: radius,
+		Tube: tube,
+		RadialSegments: radialSegments,
+		TubularSegments: tubularSegments,
+		Arc: arc,
+	}
+	g.Type = "TorusGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"radius": radius,
+		"tube": tube,
+		"radialSegments": radialSegments,
+		"tubularSegments": tubularSegments,
+		"arc": arc,
+	}
+
+	center := math3d.NewEmptyVector3()
+	uvs := make([]*math3d.Vector2, 0)
+	normals := make([]*math3d.Vector3, 0)
+
+	for j := 0; j <= radialSegments; j ++ {
+		for i := 0; i <= tubularSegments; i ++ {
+
+			u := float64( i ) / float64( tubularSegments ) * arc
+			v := float64( j ) / float64( radialSegments ) * math.Pi * 2
+
+			center.X = radius * math.Cos( u )
+			center.Y = radius * math.Sin( u )
+
+			vertex := math3d.NewVector3(
+				( radius + tube * math.Cos( v ) ) * math.Cos( u ),
+				( radius + tube * math.Cos( v ) ) * math.Sin( u ),
+				tube * math.Sin( v ),
+			)
+
+			g.Vertices = append(g.Vertices, vertex)
+
+			uvs = append(uvs, math3d.NewVector2( float64( i ) / float64( tubularSegments ), float64( j ) / float64( radialSegments ) ))
+			normals = append(normals, vertex.Clone().Sub( *center ).Normalize())
+		}
+	}
+
+	for j := 1; j <= radialSegments; j ++ {
+		for i := 1; i <= tubularSegments; i ++ {
+
+			a := ( tubularSegments + 1 ) * j + i - 1
+			b := ( tubularSegments + 1 ) * ( j - 1 ) + i - 1
+			c := ( tubularSegments + 1 ) * ( j - 1 ) + i
+			d := ( tubularSegments + 1 ) * j + i
+
+			face := core.NewArraysFace3( a, b, d, []*math3d.Vector3{ normals[ a ].Clone(), normals[ b ].Clone(), normals[ d ].Clone() }, nil, 0 )
+			g.Faces = append(g.Faces, face)
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvs[ a ].Clone(), uvs[ b ].Clone(), uvs[ d ].Clone() })
+
+			face = core.NewArraysFace3( b, c, d, []*math3d.Vector3{ normals[ b ].Clone(), normals[ c ].Clone(), normals[ d ].Clone() }, nil, 0 )
+			g.Faces = append(g.Faces, face)
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvs[ b ].Clone(), uvs[ c ].Clone(), uvs[ d ].Clone() })
+		}
+	}
+
+	g.ComputeFaceNormals()
+
+	return g
+}
+
+func (g *TorusGeometry) Clone() (*TorusGeometry) {
+	return NewTorusGeometry(g.Radius, g.Tube, g.RadialSegments, g.TubularSegments, g.Arc)
+}
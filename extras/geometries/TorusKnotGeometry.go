@@ -0,0 +1,128 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// TorusKnotGeometry winds a tube p times around the axis of rotational symmetry
+// and q times around a circle in the interior of the torus.
+type TorusKnotGeometry struct {
+	*core.Geometry
+	Radius, Tube float64
+	RadialSegments, TubularSegments int
+	P, Q int
+	HeightScale float64
+}
+
+func NewDefaultTorusKnotGeometry(radius, tube float64) (*TorusKnotGeometry) {
+	return NewTorusKnotGeometry(radius, tube, 64, 8, 2, 3, 1)
+}
+
+func NewTorusKnotGeometry(radius, tube float64, radialSegments, tubularSegments, p, q int, heightScale float64) (*TorusKnotGeometry) {
+	g := &TorusKnotGeometry{
+		Geometry: core.NewGeometry(),
+		Radius: radius,
+		Tube: tube,
+		RadialSegments: radialSegments,
+		TubularSegments: tubularSegments,
+		P: p,
+		Q: q,
+		HeightScale: heightScale,
+	}
+	g.Type = "TorusKnotGeometry"
+
+	g.Parameters = map[string]interface{}{
+		"radius": radius,
+		"tube": tube,
+		"radialSegments": radialSegments,
+		"tubularSegments": tubularSegments,
+		"p": p,
+		"q": q,
+		"heightScale": heightScale,
+	}
+
+	getPos := func(u float64) (*math3d.Vector3) {
+		cu := math.Cos( u )
+		su := math.Sin( u )
+		quOverP := float64( q ) / float64( p ) * u
+		cs := math.Cos( quOverP )
+
+		return math3d.NewVector3(
+			radius * ( 2 + cs ) * 0.5 * cu,
+			radius * ( 2 + cs ) * su * 0.5,
+			heightScale * radius * math.Sin( quOverP ) * 0.5,
+		)
+	}
+
+	grid := make([][]int, radialSegments)
+	tang := math3d.NewEmptyVector3()
+	n := math3d.NewEmptyVector3()
+	bitan := math3d.NewEmptyVector3()
+
+	for i := 0; i < radialSegments; i ++ {
+
+		grid[ i ] = make([]int, tubularSegments)
+		u := float64( i ) / float64( radialSegments ) * 2 * float64( p ) * math.Pi
+		p1 := getPos( u )
+		p2 := getPos( u + 0.01 )
+		tang.SubVectors( *p2, *p1 )
+		n.AddVectors( *p2, *p1 )
+
+		bitan.CrossVectors( tang, n )
+		n.CrossVectors( bitan, tang )
+		bitan.Normalize()
+		n.Normalize()
+
+		for j := 0; j < tubularSegments; j ++ {
+
+			v := float64( j ) / float64( tubularSegments ) * 2 * math.Pi
+			cx := - tube * math.Cos( v ) // TODO: Hack: Negating it so it faces outside.
+			cy := tube * math.Sin( v )
+
+			pos := math3d.NewVector3(
+				p1.X + cx * n.X + cy * bitan.X,
+				p1.Y + cx * n.Y + cy * bitan.Y,
+				p1.Z + cx * n.Z + cy * bitan.Z,
+			)
+
+			g.Vertices = append(g.Vertices, pos)
+			grid[ i ][ j ] = len(g.Vertices) - 1
+		}
+	}
+
+	for i := 0; i < radialSegments; i ++ {
+		for j := 0; j < tubularSegments; j ++ {
+
+			ip := ( i + 1 ) % radialSegments
+			jp := ( j + 1 ) % tubularSegments
+
+			a := grid[ i ][ j ]
+			b := grid[ ip ][ j ]
+			c := grid[ ip ][ jp ]
+			d := grid[ i ][ jp ]
+
+			uva := math3d.NewVector2( float64( i ) / float64( radialSegments ), float64( j ) / float64( tubularSegments ) )
+			uvb := math3d.NewVector2( float64( i + 1 ) / float64( radialSegments ), float64( j ) / float64( tubularSegments ) )
+			uvc := math3d.NewVector2( float64( i + 1 ) / float64( radialSegments ), float64( j + 1 ) / float64( tubularSegments ) )
+			uvd := math3d.NewVector2( float64( i ) / float64( radialSegments ), float64( j + 1 ) / float64( tubularSegments ) )
+
+			g.Faces = append(g.Faces, core.NewDefaultFace3( a, b, d ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uva, uvb, uvd })
+
+			g.Faces = append(g.Faces, core.NewDefaultFace3( b, c, d ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvb.Clone(), uvc, uvd.Clone() })
+		}
+	}
+
+	g.ComputeFaceNormals()
+	g.ComputeVertexNormals( false )
+
+	return g
+}
+
+func (g *TorusKnotGeometry) Clone() (*TorusKnotGeometry) {
+	return NewTorusKnotGeometry(g.Radius, g.Tube, g.RadialSegments, g.TubularSegments, g.P, g.Q, g.HeightScale)
+}
@@ -0,0 +1,144 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	extrascore "github.com/uzudil/three.go/extras/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// TubeGeometry sweeps a circle along a 3D curve.
+type TubeGeometry struct {
+	*core.Geometry
+	Path *extrascore.Curve3
+	Segments int
+	Radius float64
+	RadialSegments int
+	Closed bool
+	Taper func(u float64) float64
+
+	Tangents []*math3d.Vector3
+	Normals []*math3d.Vector3
+	Binormals []*math3d.Vector3
+}
+
+// NoTaper keeps the radius of a tube constant.
+func NoTaper(u float64) float64 {
+	return 1
+}
+
+// SinusoidalTaper narrows a tube to a point at both ends.
+func SinusoidalTaper(u float64) float64 {
+	return math.Sin( math.Pi * u )
+}
+
+func NewDefaultTubeGeometry(path *extrascore.Curve3, radius float64) (*TubeGeometry) {
+	return NewTubeGeometry(path, 64, radius, 8, false, NoTaper)
+}
+
+// NewTubeGeometry sweeps a circle of radius along path in segments steps. The
+// radius at u (a fraction of the arc length) is scaled by taper( u ).
+func NewTubeGeometry(path *extrascore.Curve3, segments int, radius float64, radialSegments int, closed bool, taper func(u float64) float64) (*TubeGeometry) {
+	if segments < 1 {
+		segments = 64
+	}
+	if radialSegments < 1 {
+		radialSegments = 8
+	}
+	if taper == nil {
+		taper = NoTaper
+	}
+
+	g := &TubeGeometry{
+		Geometry: core.NewGeometry(),
+		Path: path,
+		Segments: segments,
+		Radius: radius,
+		RadialSegments: radialSegments,
+		Closed: closed,
+		Taper: taper,
+	}
+	g.Type = "TubeGeometry"
+
+	numpoints := segments + 1
+
+	frames := path.ComputeFrenetFrames( segments, closed )
+
+	g.Tangents = frames.Tangents
+	g.Normals = frames.Normals
+	g.Binormals = frames.Binormals
+
+	// construct the grid
+
+	grid := make([][]int, numpoints)
+	pos2 := math3d.NewEmptyVector3()
+
+	for i := 0; i < numpoints; i ++ {
+
+		grid[ i ] = make([]int, radialSegments)
+
+		u := float64( i ) / float64( numpoints - 1 )
+
+		pos := path.GetPointAt( u )
+
+		normal := g.Normals[ i ]
+		binormal := g.Binormals[ i ]
+
+		r := radius * taper( u )
+
+		for j := 0; j < radialSegments; j ++ {
+
+			v := float64( j ) / float64( radialSegments ) * 2 * math.Pi
+
+			cx := - r * math.Cos( v ) // TODO: Hack: Negating it so it faces outside.
+			cy := r * math.Sin( v )
+
+			pos2.Copy( pos )
+			pos2.X += cx * normal.X + cy * binormal.X
+			pos2.Y += cx * normal.Y + cy * binormal.Y
+			pos2.Z += cx * normal.Z + cy * binormal.Z
+
+			g.Vertices = append(g.Vertices, pos2.Clone())
+			grid[ i ][ j ] = len(g.Vertices) - 1
+		}
+	}
+
+	// construct the mesh
+
+	for i := 0; i < segments; i ++ {
+		for j := 0; j < radialSegments; j ++ {
+
+			ip := i + 1
+			if closed {
+				ip = ( i + 1 ) % segments
+			}
+			jp := ( j + 1 ) % radialSegments
+
+			a := grid[ i ][ j ] // *** NOT NECESSARILY PLANAR ! ***
+			b := grid[ ip ][ j ]
+			c := grid[ ip ][ jp ]
+			d := grid[ i ][ jp ]
+
+			uva := math3d.NewVector2( float64( i ) / float64( segments ), float64( j ) / float64( radialSegments ) )
+			uvb := math3d.NewVector2( float64( i + 1 ) / float64( segments ), float64( j ) / float64( radialSegments ) )
+			uvc := math3d.NewVector2( float64( i + 1 ) / float64( segments ), float64( j + 1 ) / float64( radialSegments ) )
+			uvd := math3d.NewVector2( float64( i ) / float64( segments ), float64( j + 1 ) / float64( radialSegments ) )
+
+			g.Faces = append(g.Faces, core.NewDefaultFace3( a, b, d ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uva, uvb, uvd })
+
+			g.Faces = append(g.Faces, core.NewDefaultFace3( b, c, d ))
+			g.FaceVertexUvs[ 0 ] = append(g.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvb.Clone(), uvc, uvd.Clone() })
+		}
+	}
+
+	g.ComputeFaceNormals()
+	g.ComputeVertexNormals( false )
+
+	return g
+}
+
+func (g *TubeGeometry) Clone() (*TubeGeometry) {
+	return NewTubeGeometry(g.Path, g.Segments, g.Radius, g.RadialSegments, g.Closed, g.Taper)
+}
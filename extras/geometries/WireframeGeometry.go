@@ -0,0 +1,45 @@
+package geometries
+
+import (
+	"github.com/uzudil/three.go/core"
+)
+
+// WireframeGeometry holds every edge of the faces of a geometry once, as line
+// segments, i.e. pairs of vertices.
+type WireframeGeometry struct {
+	*core.Geometry
+}
+
+func NewWireframeGeometry(geometry *core.Geometry) (*WireframeGeometry) {
+	g := &WireframeGeometry{
+		Geometry: core.NewGeometry(),
+	}
+	g.Type = "WireframeGeometry"
+
+	vertices := geometry.Vertices
+
+	// an edge is shared by up to two faces, only add it once
+	hash := make(map[[2]int]bool)
+
+	for _, face := range geometry.Faces {
+
+		keys := [3]int{ face.A, face.B, face.C }
+
+		for j := 0; j < 3; j ++ {
+
+			key := [2]int{ keys[ j ], keys[ ( j + 1 ) % 3 ] }
+			if key[ 0 ] > key[ 1 ] {
+				key[ 0 ], key[ 1 ] = key[ 1 ], key[ 0 ]
+			}
+
+			if hash[ key ] {
+				continue
+			}
+			hash[ key ] = true
+
+			g.Vertices = append(g.Vertices, vertices[ key[ 0 ] ].Clone(), vertices[ key[ 1 ] ].Clone())
+		}
+	}
+
+	return g
+}
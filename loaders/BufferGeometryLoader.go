@@ -0,0 +1,152 @@
+package loaders
+
+import (
+	"fmt"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// BufferGeometryLoader reads the "BufferGeometry" entries of three.js JSON
+// files. There is no BufferGeometry here, so the position, normal, color, uv
+// and index attributes are converted to a Geometry of Face3s.
+type BufferGeometryLoader struct {
+}
+
+func NewBufferGeometryLoader() (*BufferGeometryLoader) {
+	return &BufferGeometryLoader{}
+}
+
+func (loader *BufferGeometryLoader) Load(path string) (*core.Geometry, error) {
+	json, err := readJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return loader.Parse(json)
+}
+
+// Parse converts the "data" object of a BufferGeometry entry.
+func (loader *BufferGeometryLoader) Parse(json map[string]interface{}) (*core.Geometry, error) {
+	geometry := core.NewGeometry()
+
+	attributes, _ := json["attributes"].(map[string]interface{})
+
+	positions, err := getAttribute(attributes, "position", 3)
+	if err != nil {
+		return nil, err
+	}
+	if positions == nil {
+		return nil, fmt.Errorf("THREE.BufferGeometryLoader: no position attribute")
+	}
+	normals, err := getAttribute(attributes, "normal", 3)
+	if err != nil {
+		return nil, err
+	}
+	colors, err := getAttribute(attributes, "color", 3)
+	if err != nil {
+		return nil, err
+	}
+	uvs, err := getAttribute(attributes, "uv", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	vertexCount := len(positions) / 3
+
+	if normals != nil && len(normals) / 3 != vertexCount ||
+		colors != nil && len(colors) / 3 != vertexCount ||
+		uvs != nil && len(uvs) / 2 != vertexCount {
+		return nil, fmt.Errorf("THREE.BufferGeometryLoader: attributes have different lengths")
+	}
+
+	for i := 0; i < vertexCount; i ++ {
+		geometry.Vertices = append(geometry.Vertices, math3d.NewVector3( positions[ i * 3 ], positions[ i * 3 + 1 ], positions[ i * 3 + 2 ] ))
+	}
+
+	var indices []float64
+	if index, ok := json["index"].(map[string]interface{}); ok {
+		indices = getFloatArray(index, "array")
+	} else {
+		// not indexed, every three vertices are a triangle
+		indices = make([]float64, vertexCount - vertexCount % 3)
+		for i := range indices {
+			indices[ i ] = float64( i )
+		}
+	}
+
+	if len(indices) % 3 != 0 {
+		return nil, fmt.Errorf("THREE.BufferGeometryLoader: index count %d is not a multiple of 3", len(indices))
+	}
+
+	for _, index := range indices {
+		if index < 0 || int(index) >= vertexCount {
+			return nil, fmt.Errorf("THREE.BufferGeometryLoader: index %d out of range", int(index))
+		}
+	}
+
+	// the material index of each triangle, from the draw groups
+	materialIndices := make([]int, len(indices) / 3)
+	for _, group := range getObjectArray(json, "groups") {
+		start := getInt(group, "start", 0)
+		count := getInt(group, "count", 0)
+		materialIndex := getInt(group, "materialIndex", 0)
+		for i := start / 3; i < ( start + count ) / 3 && i < len(materialIndices); i ++ {
+			if i >= 0 {
+				materialIndices[ i ] = materialIndex
+			}
+		}
+	}
+
+	for i := 0; i < len(indices); i += 3 {
+		a, b, c := int(indices[ i ]), int(indices[ i + 1 ]), int(indices[ i + 2 ])
+
+		face := core.NewDefaultFace3( a, b, c )
+		face.MaterialIndex = materialIndices[ i / 3 ]
+
+		if normals != nil {
+			for _, v := range []int{ a, b, c } {
+				face.VertexNormals = append(face.VertexNormals, math3d.NewVector3( normals[ v * 3 ], normals[ v * 3 + 1 ], normals[ v * 3 + 2 ] ))
+			}
+		}
+
+		if colors != nil {
+			for _, v := range []int{ a, b, c } {
+				color := math3d.NewDefaultColor()
+				color.FromArray( colors, v * 3 )
+				face.VertexColors = append(face.VertexColors, color)
+			}
+		}
+
+		geometry.Faces = append(geometry.Faces, face)
+
+		if uvs != nil {
+			geometry.FaceVertexUvs[ 0 ] = append(geometry.FaceVertexUvs[ 0 ], []*math3d.Vector2{
+				math3d.NewVector2( uvs[ a * 2 ], uvs[ a * 2 + 1 ] ),
+				math3d.NewVector2( uvs[ b * 2 ], uvs[ b * 2 + 1 ] ),
+				math3d.NewVector2( uvs[ c * 2 ], uvs[ c * 2 + 1 ] ),
+			})
+		}
+	}
+
+	geometry.ComputeFaceNormals()
+	geometry.ComputeBoundingSphere()
+
+	return geometry, nil
+}
+
+// getAttribute returns the values of the attribute called name, nil if there
+// is none, or an error if it doesn't have itemSize values per vertex.
+func getAttribute(attributes map[string]interface{}, name string, itemSize int) ([]float64, error) {
+	attribute, ok := attributes[ name ].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	array := getFloatArray(attribute, "array")
+
+	if getInt(attribute, "itemSize", itemSize) != itemSize || len(array) % itemSize != 0 {
+		return nil, fmt.Errorf("THREE.BufferGeometryLoader: attribute %s should have %d values per vertex", name, itemSize)
+	}
+
+	return array, nil
+}
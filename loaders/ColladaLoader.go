@@ -0,0 +1,731 @@
+package loaders
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/objects"
+)
+
+// ColladaLoader reads Collada (.dae) documents. It supports node transforms
+// (matrix, lookat and translate/rotate/scale stacks), triangles, polylist and
+// polygons primitives, and phong/lambert/blinn/constant effects, which are
+// mapped to MeshBasicMaterials using their diffuse color.
+type ColladaLoader struct {
+	// ConvertUpAxis rotates the result so Y is up when the document uses X_UP or Z_UP.
+	ConvertUpAxis bool
+}
+
+func NewColladaLoader() (*ColladaLoader) {
+	return &ColladaLoader{
+		ConvertUpAxis: true,
+	}
+}
+
+type colladaDocument struct {
+	UpAxis string `xml:"asset>up_axis"`
+	Unit *struct {
+		Meter float64 `xml:"meter,attr"`
+	} `xml:"asset>unit"`
+	Effects []colladaEffect `xml:"library_effects>effect"`
+	Materials []colladaMaterial `xml:"library_materials>material"`
+	Geometries []colladaGeometry `xml:"library_geometries>geometry"`
+	Nodes []colladaNode `xml:"library_nodes>node"`
+	VisualScenes []colladaVisualScene `xml:"library_visual_scenes>visual_scene"`
+	Scene struct {
+		InstanceVisualScene struct {
+			Url string `xml:"url,attr"`
+		} `xml:"instance_visual_scene"`
+	} `xml:"scene"`
+}
+
+type colladaEffect struct {
+	Id string `xml:"id,attr"`
+	Technique struct {
+		Shaders []colladaShader `xml:",any"`
+	} `xml:"profile_COMMON>technique"`
+}
+
+type colladaShader struct {
+	XMLName xml.Name
+	Emission colladaColorParam `xml:"emission"`
+	Diffuse colladaColorParam `xml:"diffuse"`
+	Transparent *struct {
+		Opaque string `xml:"opaque,attr"`
+	} `xml:"transparent"`
+	Transparency *float64 `xml:"transparency>float"`
+}
+
+type colladaColorParam struct {
+	Color string `xml:"color"`
+}
+
+type colladaMaterial struct {
+	Id string `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+	InstanceEffect struct {
+		Url string `xml:"url,attr"`
+	} `xml:"instance_effect"`
+}
+
+type colladaGeometry struct {
+	Id string `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+	Sources []colladaSource `xml:"mesh>source"`
+	Vertices struct {
+		Id string `xml:"id,attr"`
+		Inputs []colladaInput `xml:"input"`
+	} `xml:"mesh>vertices"`
+	Triangles []colladaPrimitive `xml:"mesh>triangles"`
+	Polylists []colladaPrimitive `xml:"mesh>polylist"`
+	Polygons []colladaPrimitive `xml:"mesh>polygons"`
+}
+
+type colladaSource struct {
+	Id string `xml:"id,attr"`
+	FloatArray string `xml:"float_array"`
+	Accessor struct {
+		Stride int `xml:"stride,attr"`
+	} `xml:"technique_common>accessor"`
+}
+
+type colladaInput struct {
+	Semantic string `xml:"semantic,attr"`
+	Source string `xml:"source,attr"`
+	Offset int `xml:"offset,attr"`
+	Set int `xml:"set,attr"`
+}
+
+type colladaPrimitive struct {
+	Material string `xml:"material,attr"`
+	Count int `xml:"count,attr"`
+	Inputs []colladaInput `xml:"input"`
+	VCount string `xml:"vcount"`
+	P []string `xml:"p"`
+}
+
+type colladaVisualScene struct {
+	Id string `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+	Nodes []colladaNode `xml:"node"`
+}
+
+type colladaNode struct {
+	Id string `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+	Nodes []colladaNode `xml:"node"`
+	InstanceGeometries []colladaInstanceGeometry `xml:"instance_geometry"`
+	InstanceNodes []struct {
+		Url string `xml:"url,attr"`
+	} `xml:"instance_node"`
+	// transforms, in document order
+	Elements []colladaElement `xml:",any"`
+}
+
+type colladaElement struct {
+	XMLName xml.Name
+	Value string `xml:",chardata"`
+}
+
+type colladaInstanceGeometry struct {
+	Url string `xml:"url,attr"`
+	InstanceMaterials []struct {
+		Symbol string `xml:"symbol,attr"`
+		Target string `xml:"target,attr"`
+	} `xml:"bind_material>technique_common>instance_material"`
+}
+
+// colladaLibrary holds the parsed document and the objects already built from it.
+type colladaLibrary struct {
+	document *colladaDocument
+	effects map[string]*colladaEffect
+	materialDefs map[string]*colladaMaterial
+	geometryDefs map[string]*colladaGeometry
+	nodeDefs map[string]*colladaNode
+	// ids of the nodes being built, to stop instance_node cycles
+	building map[string]bool
+	materials map[string]*materials.Material
+	geometries map[string]([]*colladaMesh)
+}
+
+// colladaMesh is the geometry built from one primitive element, with the material symbol it is bound to.
+type colladaMesh struct {
+	geometry *core.Geometry
+	symbol string
+}
+
+func (loader *ColladaLoader) Load(path string) (*core.Object3D, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return loader.Parse(data)
+}
+
+// Parse builds the object hierarchy of the document's visual scene.
+func (loader *ColladaLoader) Parse(data []byte) (*core.Object3D, error) {
+
+	document := &colladaDocument{}
+	if err := xml.Unmarshal(data, document); err != nil {
+		return nil, err
+	}
+
+	library := &colladaLibrary{
+		document: document,
+		effects: make(map[string]*colladaEffect),
+		materialDefs: make(map[string]*colladaMaterial),
+		geometryDefs: make(map[string]*colladaGeometry),
+		nodeDefs: make(map[string]*colladaNode),
+		building: make(map[string]bool),
+		materials: make(map[string]*materials.Material),
+		geometries: make(map[string]([]*colladaMesh)),
+	}
+
+	for i := range document.Effects {
+		library.effects[ document.Effects[ i ].Id ] = &document.Effects[ i ]
+	}
+	for i := range document.Materials {
+		library.materialDefs[ document.Materials[ i ].Id ] = &document.Materials[ i ]
+	}
+	for i := range document.Geometries {
+		library.geometryDefs[ document.Geometries[ i ].Id ] = &document.Geometries[ i ]
+	}
+	for i := range document.Nodes {
+		library.nodeDefs[ document.Nodes[ i ].Id ] = &document.Nodes[ i ]
+	}
+
+	if len(document.VisualScenes) == 0 {
+		return nil, fmt.Errorf("THREE.ColladaLoader: document has no visual scene")
+	}
+
+	visualScene := &document.VisualScenes[ 0 ]
+	url := parseUrl( document.Scene.InstanceVisualScene.Url )
+	for i := range document.VisualScenes {
+		if document.VisualScenes[ i ].Id == url {
+			visualScene = &document.VisualScenes[ i ]
+		}
+	}
+
+	scene := core.NewObject3D()
+	scene.Name = visualScene.Name
+
+	for i := range visualScene.Nodes {
+		object, err := library.buildNode( &visualScene.Nodes[ i ] )
+		if err != nil {
+			return nil, err
+		}
+		scene.Add( object )
+	}
+
+	if loader.ConvertUpAxis {
+		switch document.UpAxis {
+		case "Z_UP":
+			scene.Rotation.Set( - math3d.DegToRad( 90 ), 0, 0, scene.Rotation.Order )
+		case "X_UP":
+			scene.Rotation.Set( 0, 0, math3d.DegToRad( 90 ), scene.Rotation.Order )
+		}
+	}
+
+	if document.Unit != nil && document.Unit.Meter > 0 {
+		scene.Scale.Set( document.Unit.Meter, document.Unit.Meter, document.Unit.Meter )
+	}
+
+	return scene, nil
+}
+
+func (library *colladaLibrary) buildNode(node *colladaNode) (*core.Object3D, error) {
+
+	object := core.NewObject3D()
+	object.Name = node.Name
+	if object.Name == "" {
+		object.Name = node.Id
+	}
+
+	if node.Id != "" {
+		library.building[ node.Id ] = true
+		defer delete(library.building, node.Id)
+	}
+
+	matrix, err := parseTransforms( node.Elements )
+	if err != nil {
+		return nil, err
+	}
+	matrix.Decompose( object.Position, object.Quaternion, object.Scale )
+
+	for i := range node.InstanceGeometries {
+		instance := &node.InstanceGeometries[ i ]
+
+		meshes, err := library.buildGeometry( parseUrl( instance.Url ) )
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mesh := range meshes {
+			var material *materials.Material
+			for _, instanceMaterial := range instance.InstanceMaterials {
+				if instanceMaterial.Symbol == mesh.symbol {
+					material = library.buildMaterial( parseUrl( instanceMaterial.Target ) )
+				}
+			}
+			if material == nil {
+				material = materials.NewMeshBasicMaterial(nil).Material
+			}
+			object.Add( objects.NewMesh( mesh.geometry, material ).Object3D )
+		}
+	}
+
+	for _, instanceNode := range node.InstanceNodes {
+		nodeDef, ok := library.nodeDefs[ parseUrl( instanceNode.Url ) ]
+		if !ok {
+			fmt.Println("THREE.ColladaLoader: Undefined node", instanceNode.Url)
+			continue
+		}
+		if library.building[ nodeDef.Id ] {
+			fmt.Println("THREE.ColladaLoader: Skipping node instancing itself", instanceNode.Url)
+			continue
+		}
+		child, err := library.buildNode( nodeDef )
+		if err != nil {
+			return nil, err
+		}
+		object.Add( child )
+	}
+
+	for i := range node.Nodes {
+		child, err := library.buildNode( &node.Nodes[ i ] )
+		if err != nil {
+			return nil, err
+		}
+		object.Add( child )
+	}
+
+	return object, nil
+}
+
+// parseTransforms combines a node's transform stack into a single matrix.
+func parseTransforms(elements []colladaElement) (*math3d.Matrix4, error) {
+
+	matrix := math3d.NewMatrix4()
+	transform := math3d.NewMatrix4()
+	axis := math3d.NewEmptyVector3()
+
+	for _, element := range elements {
+
+		values, err := parseFloats( element.Value )
+		if err != nil {
+			return nil, err
+		}
+
+		expect := func(count int) error {
+			if len(values) != count {
+				return fmt.Errorf("THREE.ColladaLoader: <%s> needs %d values, found %d", element.XMLName.Local, count, len(values))
+			}
+			return nil
+		}
+
+		switch element.XMLName.Local {
+		case "matrix":
+			if err := expect(16); err != nil {
+				return nil, err
+			}
+			// collada matrices are row major, the same order Matrix4.Set takes
+			transform.Set(
+				values[ 0 ], values[ 1 ], values[ 2 ], values[ 3 ],
+				values[ 4 ], values[ 5 ], values[ 6 ], values[ 7 ],
+				values[ 8 ], values[ 9 ], values[ 10 ], values[ 11 ],
+				values[ 12 ], values[ 13 ], values[ 14 ], values[ 15 ],
+			)
+		case "translate":
+			if err := expect(3); err != nil {
+				return nil, err
+			}
+			transform.MakeTranslation( values[ 0 ], values[ 1 ], values[ 2 ] )
+		case "rotate":
+			if err := expect(4); err != nil {
+				return nil, err
+			}
+			axis.Set( values[ 0 ], values[ 1 ], values[ 2 ] ).Normalize()
+			transform.MakeRotationAxis( axis, math3d.DegToRad( values[ 3 ] ) )
+		case "scale":
+			if err := expect(3); err != nil {
+				return nil, err
+			}
+			transform.MakeScale( values[ 0 ], values[ 1 ], values[ 2 ] )
+		case "lookat":
+			if err := expect(9); err != nil {
+				return nil, err
+			}
+			eye := math3d.NewVector3( values[ 0 ], values[ 1 ], values[ 2 ] )
+			target := math3d.NewVector3( values[ 3 ], values[ 4 ], values[ 5 ] )
+			up := math3d.NewVector3( values[ 6 ], values[ 7 ], values[ 8 ] )
+			transform.Identity()
+			transform.LookAt( eye, target, up )
+			transform.SetPosition( eye )
+		default:
+			continue
+		}
+
+		matrix.MultiplyMatrices( matrix, transform )
+	}
+
+	return matrix, nil
+}
+
+func (library *colladaLibrary) buildMaterial(id string) (*materials.Material) {
+
+	if material, ok := library.materials[ id ]; ok {
+		return material
+	}
+
+	basic := materials.NewMeshBasicMaterial(nil)
+
+	materialDef, ok := library.materialDefs[ id ]
+	if !ok {
+		fmt.Println("THREE.ColladaLoader: Undefined material", id)
+	} else {
+		basic.Name = materialDef.Name
+		effect, ok := library.effects[ parseUrl( materialDef.InstanceEffect.Url ) ]
+		if !ok {
+			fmt.Println("THREE.ColladaLoader: Undefined effect", materialDef.InstanceEffect.Url)
+		} else {
+			for _, shader := range effect.Technique.Shaders {
+				applyColladaShader( basic, &shader )
+			}
+		}
+	}
+
+	library.materials[ id ] = basic.Material
+	return basic.Material
+}
+
+func applyColladaShader(material *materials.MeshBasicMaterial, shader *colladaShader) {
+
+	switch shader.XMLName.Local {
+	case "phong", "lambert", "blinn":
+		if color, ok := parseColor( shader.Diffuse.Color ); ok {
+			material.Color.Copy( color )
+		}
+	case "constant":
+		if color, ok := parseColor( shader.Emission.Color ); ok {
+			material.Color.Copy( color )
+		}
+	default:
+		return
+	}
+
+	if shader.Transparency != nil {
+		opacity := *shader.Transparency
+		if shader.Transparent != nil && shader.Transparent.Opaque == "RGB_ZERO" {
+			opacity = 1 - opacity
+		}
+		if opacity < 1 {
+			material.Opacity = opacity
+			material.Transparent = true
+		}
+	}
+}
+
+func (library *colladaLibrary) buildGeometry(id string) ([]*colladaMesh, error) {
+
+	if meshes, ok := library.geometries[ id ]; ok {
+		return meshes, nil
+	}
+
+	geometryDef, ok := library.geometryDefs[ id ]
+	if !ok {
+		return nil, fmt.Errorf("THREE.ColladaLoader: Undefined geometry %s", id)
+	}
+
+	sources := make(map[string]*colladaSource)
+	for i := range geometryDef.Sources {
+		sources[ geometryDef.Sources[ i ].Id ] = &geometryDef.Sources[ i ]
+	}
+
+	meshes := make([]*colladaMesh, 0)
+
+	build := func(primitive *colladaPrimitive, polygons bool) error {
+		geometry, err := buildColladaPrimitive( geometryDef, sources, primitive, polygons )
+		if err != nil {
+			return err
+		}
+		geometry.Name = geometryDef.Name
+		meshes = append(meshes, &colladaMesh{ geometry, primitive.Material })
+		return nil
+	}
+
+	for i := range geometryDef.Triangles {
+		if err := build( &geometryDef.Triangles[ i ], false ); err != nil {
+			return nil, err
+		}
+	}
+	for i := range geometryDef.Polylists {
+		if err := build( &geometryDef.Polylists[ i ], false ); err != nil {
+			return nil, err
+		}
+	}
+	for i := range geometryDef.Polygons {
+		if err := build( &geometryDef.Polygons[ i ], true ); err != nil {
+			return nil, err
+		}
+	}
+
+	library.geometries[ id ] = meshes
+	return meshes, nil
+}
+
+// buildColladaPrimitive turns one <triangles>, <polylist> or <polygons> element into a geometry.
+// Polygons are triangulated as fans; normals, uvs and colors become per face vertex attributes.
+func buildColladaPrimitive(geometryDef *colladaGeometry, sources map[string]*colladaSource, primitive *colladaPrimitive, polygons bool) (*core.Geometry, error) {
+
+	geometry := core.NewGeometry()
+
+	var positions, normals, uvs, colors []float64
+	var normalStride, uvStride, colorStride int
+	positionOffset, normalOffset, uvOffset, colorOffset := -1, -1, -1, -1
+	stride := 0
+
+	getSource := func(url string) (*colladaSource, []float64, error) {
+		source, ok := sources[ parseUrl( url ) ]
+		if !ok {
+			return nil, nil, fmt.Errorf("THREE.ColladaLoader: Undefined source %s", url)
+		}
+		values, err := parseFloats( source.FloatArray )
+		return source, values, err
+	}
+
+	sourceStride := func(source *colladaSource, defaultStride int) int {
+		if source.Accessor.Stride > 0 {
+			return source.Accessor.Stride
+		}
+		return defaultStride
+	}
+
+	for _, input := range primitive.Inputs {
+		if input.Offset + 1 > stride {
+			stride = input.Offset + 1
+		}
+
+		switch input.Semantic {
+		case "VERTEX":
+			positionOffset = input.Offset
+			for _, vertexInput := range geometryDef.Vertices.Inputs {
+				source, values, err := getSource( vertexInput.Source )
+				if err != nil {
+					return nil, err
+				}
+				switch vertexInput.Semantic {
+				case "POSITION":
+					positions = values
+					if sourceStride( source, 3 ) != 3 {
+						return nil, fmt.Errorf("THREE.ColladaLoader: unsupported position stride in %s", geometryDef.Id)
+					}
+				case "NORMAL":
+					normals, normalStride, normalOffset = values, sourceStride( source, 3 ), input.Offset
+				case "TEXCOORD":
+					uvs, uvStride, uvOffset = values, sourceStride( source, 2 ), input.Offset
+				case "COLOR":
+					colors, colorStride, colorOffset = values, sourceStride( source, 3 ), input.Offset
+				}
+			}
+		case "NORMAL":
+			source, values, err := getSource( input.Source )
+			if err != nil {
+				return nil, err
+			}
+			normals, normalStride, normalOffset = values, sourceStride( source, 3 ), input.Offset
+		case "TEXCOORD":
+			if input.Set > 0 && uvOffset >= 0 {
+				continue
+			}
+			source, values, err := getSource( input.Source )
+			if err != nil {
+				return nil, err
+			}
+			uvs, uvStride, uvOffset = values, sourceStride( source, 2 ), input.Offset
+		case "COLOR":
+			source, values, err := getSource( input.Source )
+			if err != nil {
+				return nil, err
+			}
+			colors, colorStride, colorOffset = values, sourceStride( source, 3 ), input.Offset
+		}
+	}
+
+	if positionOffset < 0 {
+		return nil, fmt.Errorf("THREE.ColladaLoader: primitive without VERTEX input in %s", geometryDef.Id)
+	}
+
+	for i := 0; i + 2 < len(positions); i += 3 {
+		geometry.Vertices = append(geometry.Vertices, math3d.NewVector3( positions[ i ], positions[ i + 1 ], positions[ i + 2 ] ))
+	}
+
+	// the polygons as lists of index tuples
+	indices := make([]int, 0)
+	vcount := make([]int, 0)
+
+	if polygons {
+		for _, p := range primitive.P {
+			values, err := parseInts( p )
+			if err != nil {
+				return nil, err
+			}
+			indices = append(indices, values...)
+			vcount = append(vcount, len(values) / stride)
+		}
+	} else {
+		for _, p := range primitive.P {
+			values, err := parseInts( p )
+			if err != nil {
+				return nil, err
+			}
+			indices = append(indices, values...)
+		}
+		if strings.TrimSpace( primitive.VCount ) != "" {
+			var err error
+			vcount, err = parseInts( primitive.VCount )
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			for i := 0; i < len(indices) / ( stride * 3 ); i ++ {
+				vcount = append(vcount, 3)
+			}
+		}
+	}
+
+	index := func(corner, offset int) (int, error) {
+		i := corner * stride + offset
+		if i < 0 || i >= len(indices) {
+			return 0, fmt.Errorf("THREE.ColladaLoader: <p> too short in %s", geometryDef.Id)
+		}
+		return indices[ i ], nil
+	}
+
+	corner := 0
+	for _, count := range vcount {
+
+		cornerIndex := func(k, offset int) (int, error) {
+			return index( corner + k, offset )
+		}
+
+		// fan triangulation
+		for k := 1; k + 1 < count; k ++ {
+
+			triangle := [3]int{ 0, k, k + 1 }
+
+			var vertex [3]int
+			for n, t := range triangle {
+				v, err := cornerIndex( t, positionOffset )
+				if err != nil {
+					return nil, err
+				}
+				if v < 0 || v >= len(geometry.Vertices) {
+					return nil, fmt.Errorf("THREE.ColladaLoader: vertex index %d out of range in %s", v, geometryDef.Id)
+				}
+				vertex[ n ] = v
+			}
+
+			face := core.NewDefaultFace3( vertex[ 0 ], vertex[ 1 ], vertex[ 2 ] )
+
+			if normalOffset >= 0 {
+				for _, t := range triangle {
+					n, err := cornerIndex( t, normalOffset )
+					if err != nil {
+						return nil, err
+					}
+					n *= normalStride
+					if n < 0 || n + 2 >= len(normals) {
+						return nil, fmt.Errorf("THREE.ColladaLoader: normal index out of range in %s", geometryDef.Id)
+					}
+					face.VertexNormals = append(face.VertexNormals, math3d.NewVector3( normals[ n ], normals[ n + 1 ], normals[ n + 2 ] ))
+				}
+			}
+
+			if colorOffset >= 0 {
+				for _, t := range triangle {
+					c, err := cornerIndex( t, colorOffset )
+					if err != nil {
+						return nil, err
+					}
+					c *= colorStride
+					if c < 0 || c + 2 >= len(colors) {
+						return nil, fmt.Errorf("THREE.ColladaLoader: color index out of range in %s", geometryDef.Id)
+					}
+					face.VertexColors = append(face.VertexColors, math3d.NewColor( colors[ c ], colors[ c + 1 ], colors[ c + 2 ] ))
+				}
+			}
+
+			if uvOffset >= 0 {
+				faceUvs := make([]*math3d.Vector2, 0, 3)
+				for _, t := range triangle {
+					u, err := cornerIndex( t, uvOffset )
+					if err != nil {
+						return nil, err
+					}
+					u *= uvStride
+					if u < 0 || u + 1 >= len(uvs) {
+						return nil, fmt.Errorf("THREE.ColladaLoader: uv index out of range in %s", geometryDef.Id)
+					}
+					faceUvs = append(faceUvs, math3d.NewVector2( uvs[ u ], uvs[ u + 1 ] ))
+				}
+				geometry.FaceVertexUvs[ 0 ] = append(geometry.FaceVertexUvs[ 0 ], faceUvs)
+			}
+
+			geometry.Faces = append(geometry.Faces, face)
+		}
+
+		corner += count
+	}
+
+	geometry.ComputeFaceNormals()
+	if normalOffset < 0 {
+		geometry.ComputeVertexNormals( false )
+	}
+	geometry.ComputeBoundingSphere()
+
+	return geometry, nil
+}
+
+func parseUrl(url string) string {
+	return strings.TrimPrefix( url, "#" )
+}
+
+func parseFloats(text string) ([]float64, error) {
+	fields := strings.Fields( text )
+	values := make([]float64, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseFloat( field, 64 )
+		if err != nil {
+			return nil, fmt.Errorf("THREE.ColladaLoader: %v", err)
+		}
+		values[ i ] = value
+	}
+	return values, nil
+}
+
+func parseInts(text string) ([]int, error) {
+	fields := strings.Fields( text )
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		value, err := strconv.Atoi( field )
+		if err != nil {
+			return nil, fmt.Errorf("THREE.ColladaLoader: %v", err)
+		}
+		values[ i ] = value
+	}
+	return values, nil
+}
+
+func parseColor(text string) (*math3d.Color, bool) {
+	values, err := parseFloats( text )
+	if err != nil || len(values) < 3 {
+		return nil, false
+	}
+	return math3d.NewColor( values[ 0 ], values[ 1 ], values[ 2 ] ), true
+}
@@ -0,0 +1,75 @@
+package loaders
+
+import (
+	"strings"
+	"testing"
+)
+
+// colladaTriangle is a document with one triangle instanced by a library node,
+// with {p} replaced by the triangle's <p> indices and {node} by the library node's children.
+const colladaTriangle = `<COLLADA>
+	<library_geometries>
+		<geometry id="triangle">
+			<mesh>
+				<source id="positions"><float_array>0 0 0 1 0 0 0 1 0</float_array></source>
+				<source id="normals"><float_array>0 0 1</float_array></source>
+				<vertices id="vertices"><input semantic="POSITION" source="#positions"/></vertices>
+				<triangles count="1">
+					<input semantic="VERTEX" source="#vertices" offset="0"/>
+					<input semantic="NORMAL" source="#normals" offset="1"/>
+					<p>{p}</p>
+				</triangles>
+			</mesh>
+		</geometry>
+	</library_geometries>
+	<library_nodes>
+		<node id="part">
+			<instance_geometry url="#triangle"/>
+			{node}
+		</node>
+	</library_nodes>
+	<library_visual_scenes>
+		<visual_scene id="scene">
+			<node id="root"><instance_node url="#part"/></node>
+		</visual_scene>
+	</library_visual_scenes>
+</COLLADA>`
+
+func triangleDocument(p, node string) ([]byte) {
+	return []byte(strings.NewReplacer( "{p}", p, "{node}", node ).Replace( colladaTriangle ))
+}
+
+func TestColladaLoaderParse(t *testing.T) {
+	scene, err := NewColladaLoader().Parse( triangleDocument( "0 0 1 0 2 0", "" ) )
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := scene.Children[ 0 ]
+	if len(root.Children) != 1 || len(root.Children[ 0 ].Children) != 1 {
+		t.Fatal("part node or its mesh is missing")
+	}
+}
+
+func TestColladaLoaderRejectsNegativeIndices(t *testing.T) {
+	for _, p := range []string{ "0 0 -1 0 2 0", "0 0 1 -1 2 0" } {
+		if _, err := NewColladaLoader().Parse( triangleDocument( p, "" ) ); err == nil {
+			t.Errorf("<p>%s</p>: no error", p)
+		}
+	}
+}
+
+func TestColladaLoaderSkipsInstanceNodeCycles(t *testing.T) {
+	scene, err := NewColladaLoader().Parse( triangleDocument( "0 0 1 0 2 0", `<node id="child"><instance_node url="#part"/></node>` ) )
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part := scene.Children[ 0 ].Children[ 0 ]
+	if len(part.Children) != 2 {
+		t.Fatalf("got %d children of the part node, want its mesh and child node", len(part.Children))
+	}
+	if child := part.Children[ 1 ]; len(child.Children) != 0 {
+		t.Errorf("child node has %d children, want the cyclic instance skipped", len(child.Children))
+	}
+}
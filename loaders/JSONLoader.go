@@ -0,0 +1,348 @@
+package loaders
+
+import (
+	"fmt"
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+type JSONLoader struct {
+}
+
+func NewJSONLoader() (*JSONLoader) {
+	return &JSONLoader{}
+}
+
+func (loader *JSONLoader) Load(path string) (*core.Geometry, error) {
+	json, err := readJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return loader.Parse(json)
+}
+
+// Parse reads a geometry in the three.js JSON model format. It fails if the
+// faces refer to vertices, normals, colors or uvs that aren't there.
+func (loader *JSONLoader) Parse(json map[string]interface{}) (*core.Geometry, error) {
+	geometry := core.NewGeometry()
+	scale := getFloat(json, "scale", 1.0)
+
+	if err := loader.parseModel(json, geometry, 1.0 / scale); err != nil {
+		return nil, err
+	}
+	loader.parseSkin(json, geometry)
+	loader.parseMorphing(json, geometry, 1.0 / scale)
+
+	geometry.ComputeFaceNormals()
+	geometry.ComputeBoundingSphere()
+
+	return geometry, nil
+}
+
+func (loader *JSONLoader) parseModel(json map[string]interface{}, geometry *core.Geometry, scale float64) error {
+
+	isBitSet := func(value, position int) bool {
+		return value & ( 1 << uint(position) ) != 0
+	}
+
+	faces := getFloatArray(json, "faces")
+	vertices := getFloatArray(json, "vertices")
+	normals := getFloatArray(json, "normals")
+	colors := getFloatArray(json, "colors")
+
+	uvLayers := make([][]float64, 0)
+	if layers, ok := json["uvs"].([]interface{}); ok {
+		for _, layer := range layers {
+			if values, ok := layer.([]interface{}); ok && len(values) > 0 {
+				uvLayer := make([]float64, len(values))
+				for i, value := range values {
+					uvLayer[ i ], _ = value.(float64)
+				}
+				uvLayers = append(uvLayers, uvLayer)
+			}
+		}
+	}
+	// NewGeometry has created layer 0 already
+	for i := len(geometry.FaceVertexUvs); i < len(uvLayers); i ++ {
+		geometry.FaceVertexUvs = append(geometry.FaceVertexUvs, make([][]*math3d.Vector2, 0))
+	}
+
+	for offset := 0; offset + 2 < len(vertices); offset += 3 {
+		vertex := math3d.NewVector3(
+			vertices[ offset ] * scale,
+			vertices[ offset + 1 ] * scale,
+			vertices[ offset + 2 ] * scale,
+		)
+		geometry.Vertices = append(geometry.Vertices, vertex)
+	}
+
+	// the readers below return zero values once err is set, it is checked after each face
+
+	var err error
+
+	next := func(offset *int) int {
+		if *offset >= len(faces) {
+			if err == nil {
+				err = fmt.Errorf("THREE.JSONLoader: faces end in the middle of a face")
+			}
+			return 0
+		}
+		value := int(faces[ *offset ])
+		*offset++
+		return value
+	}
+
+	getVertex := func(offset *int) int {
+		index := next(offset)
+		if err == nil && ( index < 0 || index >= len(geometry.Vertices) ) {
+			err = fmt.Errorf("THREE.JSONLoader: vertex index %d out of range", index)
+		}
+		return index
+	}
+
+	getNormal := func(index int) (*math3d.Vector3) {
+		if index < 0 || index * 3 + 2 >= len(normals) {
+			if err == nil {
+				err = fmt.Errorf("THREE.JSONLoader: normal index %d out of range", index)
+			}
+			return math3d.NewEmptyVector3()
+		}
+		return math3d.NewVector3( normals[ index * 3 ], normals[ index * 3 + 1 ], normals[ index * 3 + 2 ] )
+	}
+
+	getColor := func(index int) (*math3d.Color) {
+		if index < 0 || index >= len(colors) {
+			if err == nil {
+				err = fmt.Errorf("THREE.JSONLoader: color index %d out of range", index)
+			}
+			return math3d.NewDefaultColor()
+		}
+		return math3d.NewDefaultColor().SetHex( int(colors[ index ]) )
+	}
+
+	getUv := func(uvLayer []float64, index int) (*math3d.Vector2) {
+		if index < 0 || index * 2 + 1 >= len(uvLayer) {
+			if err == nil {
+				err = fmt.Errorf("THREE.JSONLoader: uv index %d out of range", index)
+			}
+			return math3d.NewEmptyVector2()
+		}
+		return math3d.NewVector2( uvLayer[ index * 2 ], uvLayer[ index * 2 + 1 ] )
+	}
+
+	offset := 0
+	zLength := len(faces)
+
+	for offset < zLength {
+
+		faceType := next(&offset)
+
+		isQuad := isBitSet( faceType, 0 )
+		hasMaterial := isBitSet( faceType, 1 )
+		hasFaceVertexUv := isBitSet( faceType, 3 )
+		hasFaceNormal := isBitSet( faceType, 4 )
+		hasFaceVertexNormal := isBitSet( faceType, 5 )
+		hasFaceColor := isBitSet( faceType, 6 )
+		hasFaceVertexColor := isBitSet( faceType, 7 )
+
+		// to get face <=> uv index correspondence
+		fi := len(geometry.Faces)
+
+		if isQuad {
+
+			a, b, c, d := getVertex(&offset), getVertex(&offset), getVertex(&offset), getVertex(&offset)
+			faceA := core.NewDefaultFace3( a, b, d )
+			faceB := core.NewDefaultFace3( b, c, d )
+
+			if hasMaterial {
+				materialIndex := next(&offset)
+				faceA.MaterialIndex = materialIndex
+				faceB.MaterialIndex = materialIndex
+			}
+
+			if hasFaceVertexUv {
+				for i, uvLayer := range uvLayers {
+					uvsA := make([]*math3d.Vector2, 0, 3)
+					uvsB := make([]*math3d.Vector2, 0, 3)
+					for j := 0; j < 4; j ++ {
+						uv := getUv( uvLayer, next(&offset) )
+						if j != 2 {
+							uvsA = append(uvsA, uv)
+						}
+						if j != 0 {
+							uvsB = append(uvsB, uv)
+						}
+					}
+					geometry.FaceVertexUvs[ i ] = setFaceUvs(geometry.FaceVertexUvs[ i ], fi, uvsA)
+					geometry.FaceVertexUvs[ i ] = setFaceUvs(geometry.FaceVertexUvs[ i ], fi + 1, uvsB)
+				}
+			}
+
+			if hasFaceNormal {
+				faceA.Normal.Copy( getNormal( next(&offset) ) )
+				faceB.Normal.Copy( faceA.Normal )
+			}
+
+			if hasFaceVertexNormal {
+				for i := 0; i < 4; i ++ {
+					normal := getNormal( next(&offset) )
+					if i != 2 {
+						faceA.VertexNormals = append(faceA.VertexNormals, normal)
+					}
+					if i != 0 {
+						faceB.VertexNormals = append(faceB.VertexNormals, normal)
+					}
+				}
+			}
+
+			if hasFaceColor {
+				color := getColor( next(&offset) )
+				faceA.Color.Copy( color )
+				faceB.Color.Copy( color )
+			}
+
+			if hasFaceVertexColor {
+				for i := 0; i < 4; i ++ {
+					color := getColor( next(&offset) )
+					if i != 2 {
+						faceA.VertexColors = append(faceA.VertexColors, color)
+					}
+					if i != 0 {
+						faceB.VertexColors = append(faceB.VertexColors, color.Clone())
+					}
+				}
+			}
+
+			geometry.Faces = append(geometry.Faces, faceA, faceB)
+
+		} else {
+
+			a, b, c := getVertex(&offset), getVertex(&offset), getVertex(&offset)
+			face := core.NewDefaultFace3( a, b, c )
+
+			if hasMaterial {
+				face.MaterialIndex = next(&offset)
+			}
+
+			if hasFaceVertexUv {
+				for i, uvLayer := range uvLayers {
+					uvs := make([]*math3d.Vector2, 0, 3)
+					for j := 0; j < 3; j ++ {
+						uvs = append(uvs, getUv( uvLayer, next(&offset) ))
+					}
+					geometry.FaceVertexUvs[ i ] = setFaceUvs(geometry.FaceVertexUvs[ i ], fi, uvs)
+				}
+			}
+
+			if hasFaceNormal {
+				face.Normal.Copy( getNormal( next(&offset) ) )
+			}
+
+			if hasFaceVertexNormal {
+				for i := 0; i < 3; i ++ {
+					face.VertexNormals = append(face.VertexNormals, getNormal( next(&offset) ))
+				}
+			}
+
+			if hasFaceColor {
+				face.Color.Copy( getColor( next(&offset) ) )
+			}
+
+			if hasFaceVertexColor {
+				for i := 0; i < 3; i ++ {
+					face.VertexColors = append(face.VertexColors, getColor( next(&offset) ))
+				}
+			}
+
+			geometry.Faces = append(geometry.Faces, face)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (loader *JSONLoader) parseSkin(json map[string]interface{}, geometry *core.Geometry) {
+	influencesPerVertex := getInt(json, "influencesPerVertex", 2)
+
+	// each vertex has influencesPerVertex entries, padded to four with zeros
+	toVector4s := func(values []float64) ([]*math3d.Vector4) {
+		vectors := make([]*math3d.Vector4, 0, len(values) / influencesPerVertex)
+		for i := 0; i + influencesPerVertex <= len(values); i += influencesPerVertex {
+			v := math3d.NewVector4( 0, 0, 0, 0 )
+			for j := 0; j < influencesPerVertex && j < 4; j ++ {
+				v.SetComponent( j, values[ i + j ] )
+			}
+			vectors = append(vectors, v)
+		}
+		return vectors
+	}
+
+	geometry.SkinWeights = toVector4s( getFloatArray(json, "skinWeights") )
+	geometry.SkinIndices = toVector4s( getFloatArray(json, "skinIndices") )
+
+	for _, bone := range getObjectArray(json, "bones") {
+		geometryBone := &core.GeometryBone{
+			Name: getString(bone, "name", ""),
+			Parent: getInt(bone, "parent", -1),
+			Position: math3d.NewEmptyVector3(),
+			Quaternion: math3d.NewEmptyQuaternion(),
+			Scale: math3d.NewVector3( 1, 1, 1 ),
+		}
+		if pos := getFloatArray(bone, "pos"); len(pos) == 3 {
+			geometryBone.Position.FromArray( pos, 0 )
+		}
+		if rotq := getFloatArray(bone, "rotq"); len(rotq) == 4 {
+			geometryBone.Quaternion.FromArray( rotq, 0 )
+		}
+		if scl := getFloatArray(bone, "scl"); len(scl) == 3 {
+			geometryBone.Scale.FromArray( scl, 0 )
+		}
+		geometry.Bones = append(geometry.Bones, geometryBone)
+	}
+
+	if len(geometry.Bones) > 0 && ( len(geometry.SkinWeights) != len(geometry.SkinIndices) || len(geometry.SkinIndices) != len(geometry.Vertices) ) {
+		fmt.Println(fmt.Sprintf("THREE.JSONLoader: When skinning, number of vertices (%d), skinIndices (%d), and skinWeights (%d) should match.",
+			len(geometry.Vertices), len(geometry.SkinIndices), len(geometry.SkinWeights)))
+	}
+}
+
+func (loader *JSONLoader) parseMorphing(json map[string]interface{}, geometry *core.Geometry, scale float64) {
+	for _, morphTarget := range getObjectArray(json, "morphTargets") {
+		srcVertices := getFloatArray(morphTarget, "vertices")
+		dstVertices := make([]*math3d.Vector3, 0, len(srcVertices) / 3)
+
+		for v := 0; v + 2 < len(srcVertices); v += 3 {
+			dstVertices = append(dstVertices, math3d.NewVector3(
+				srcVertices[ v ] * scale,
+				srcVertices[ v + 1 ] * scale,
+				srcVertices[ v + 2 ] * scale,
+			))
+		}
+
+		name := getString(morphTarget, "name", "")
+
+		if len(dstVertices) != len(geometry.Vertices) {
+			fmt.Println(fmt.Sprintf("THREE.JSONLoader: Skipping morph target %s, it has %d vertices instead of %d.",
+				name, len(dstVertices), len(geometry.Vertices)))
+			continue
+		}
+
+		geometry.MorphTargets = append(geometry.MorphTargets, &core.MorphTarget{
+			Name: name,
+			Vertices: dstVertices,
+		})
+	}
+}
+
+// setFaceUvs stores the uvs of face index fi, padding the layer if earlier faces had no uvs.
+func setFaceUvs(layer [][]*math3d.Vector2, fi int, uvs []*math3d.Vector2) ([][]*math3d.Vector2) {
+	for len(layer) <= fi {
+		layer = append(layer, nil)
+	}
+	layer[ fi ] = uvs
+	return layer
+}
@@ -0,0 +1,124 @@
+package loaders
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, text string) (map[string]interface{}) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// a triangle and a quad, both with uvs and the quad with a face normal
+const jsonModel = `{
+	"vertices": [ 0,0,0, 1,0,0, 1,1,0, 0,1,0 ],
+	"normals": [ 0,0,1 ],
+	"uvs": [ [ 0,0, 1,0, 1,1, 0,1 ] ],
+	"faces": [
+		8, 0,1,2, 0,1,2,
+		25, 0,1,2,3, 0,1,2,3, 0
+	]
+}`
+
+func TestJSONLoaderParse(t *testing.T) {
+	geometry, err := NewJSONLoader().Parse( decodeJSON(t, jsonModel) )
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(geometry.Vertices) != 4 {
+		t.Fatalf("got %d vertices, want 4", len(geometry.Vertices))
+	}
+	if len(geometry.Faces) != 3 {
+		t.Fatalf("got %d faces, want 3", len(geometry.Faces))
+	}
+
+	quadB := geometry.Faces[ 2 ]
+	if quadB.A != 1 || quadB.B != 2 || quadB.C != 3 {
+		t.Errorf("second quad triangle is %d %d %d, want 1 2 3", quadB.A, quadB.B, quadB.C)
+	}
+
+	if len(geometry.FaceVertexUvs) != 1 {
+		t.Fatalf("got %d uv layers, want 1", len(geometry.FaceVertexUvs))
+	}
+	uvs := geometry.FaceVertexUvs[ 0 ]
+	if len(uvs) != 3 {
+		t.Fatalf("got uvs for %d faces, want 3", len(uvs))
+	}
+	if uv := uvs[ 2 ][ 1 ]; uv.X != 1 || uv.Y != 1 {
+		t.Errorf("got uv %v, want ( 1, 1 )", uv)
+	}
+}
+
+func TestJSONLoaderRejectsBadModels(t *testing.T) {
+	models := map[string]string{
+		"truncated face": `{ "vertices": [ 0,0,0, 1,0,0, 1,1,0 ], "faces": [ 0, 0,1 ] }`,
+		"vertex index": `{ "vertices": [ 0,0,0, 1,0,0, 1,1,0 ], "faces": [ 0, 0,1,7 ] }`,
+		"negative index": `{ "vertices": [ 0,0,0, 1,0,0, 1,1,0 ], "faces": [ 0, 0,-1,2 ] }`,
+		"normal index": `{ "vertices": [ 0,0,0, 1,0,0, 1,1,0 ], "normals": [ 0,0,1 ], "faces": [ 16, 0,1,2, 3 ] }`,
+		"color index": `{ "vertices": [ 0,0,0, 1,0,0, 1,1,0 ], "faces": [ 64, 0,1,2, 0 ] }`,
+		"uv index": `{ "vertices": [ 0,0,0, 1,0,0, 1,1,0 ], "uvs": [ [ 0,0 ] ], "faces": [ 8, 0,1,2, 0,0,4 ] }`,
+	}
+
+	for name, model := range models {
+		if _, err := NewJSONLoader().Parse( decodeJSON(t, model) ); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestBufferGeometryLoaderParse(t *testing.T) {
+	data := decodeJSON(t, `{
+		"attributes": {
+			"position": { "itemSize": 3, "type": "Float32Array", "array": [ 0,0,0, 1,0,0, 1,1,0, 0,1,0 ] },
+			"uv": { "itemSize": 2, "type": "Float32Array", "array": [ 0,0, 1,0, 1,1, 0,1 ] }
+		},
+		"index": { "type": "Uint16Array", "array": [ 0,1,2, 0,2,3 ] },
+		"groups": [ { "start": 3, "count": 3, "materialIndex": 1 } ]
+	}`)
+
+	geometry, err := NewBufferGeometryLoader().Parse( data )
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(geometry.Vertices) != 4 || len(geometry.Faces) != 2 {
+		t.Fatalf("got %d vertices and %d faces, want 4 and 2", len(geometry.Vertices), len(geometry.Faces))
+	}
+	if face := geometry.Faces[ 1 ]; face.A != 0 || face.B != 2 || face.C != 3 || face.MaterialIndex != 1 {
+		t.Errorf("second face is %d %d %d material %d, want 0 2 3 material 1", face.A, face.B, face.C, face.MaterialIndex)
+	}
+	if len(geometry.FaceVertexUvs[ 0 ]) != 2 {
+		t.Errorf("got uvs for %d faces, want 2", len(geometry.FaceVertexUvs[ 0 ]))
+	}
+
+	data = decodeJSON(t, `{
+		"attributes": { "position": { "itemSize": 3, "array": [ 0,0,0, 1,0,0, 1,1,0 ] } },
+		"index": { "array": [ 0,1,3 ] }
+	}`)
+	if _, err := NewBufferGeometryLoader().Parse( data ); err == nil {
+		t.Error("index out of range: no error")
+	}
+}
+
+func TestJSONLoaderSkipsShortMorphTargets(t *testing.T) {
+	geometry, err := NewJSONLoader().Parse( decodeJSON(t, `{
+		"vertices": [ 0,0,0, 1,0,0, 0,1,0 ],
+		"faces": [ 0, 0,1,2 ],
+		"morphTargets": [
+			{ "name": "short", "vertices": [ 0,0,1, 1,0,1 ] },
+			{ "name": "raised", "vertices": [ 0,0,1, 1,0,1, 0,1,1 ] }
+		]
+	}`) )
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(geometry.MorphTargets) != 1 || geometry.MorphTargets[ 0 ].Name != "raised" {
+		t.Errorf("got %d morph targets, want only raised", len(geometry.MorphTargets))
+	}
+}
@@ -0,0 +1,74 @@
+package loaders
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// helpers for reading values out of decoded json documents
+
+func readJSONFile(path string) (map[string]interface{}, error) {
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(text, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func getFloat(data map[string]interface{}, key string, defaultValue float64) float64 {
+	if value, ok := data[key].(float64); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func getInt(data map[string]interface{}, key string, defaultValue int) int {
+	if value, ok := data[key].(float64); ok {
+		return int(value)
+	}
+	return defaultValue
+}
+
+func getBool(data map[string]interface{}, key string, defaultValue bool) bool {
+	if value, ok := data[key].(bool); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func getString(data map[string]interface{}, key string, defaultValue string) string {
+	if value, ok := data[key].(string); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func getFloatArray(data map[string]interface{}, key string) []float64 {
+	values, ok := data[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	array := make([]float64, len(values))
+	for i, value := range values {
+		array[ i ], _ = value.(float64)
+	}
+	return array
+}
+
+func getObjectArray(data map[string]interface{}, key string) []map[string]interface{} {
+	values, ok := data[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	array := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		if object, ok := value.(map[string]interface{}); ok {
+			array = append(array, object)
+		}
+	}
+	return array
+}
@@ -0,0 +1,98 @@
+package loaders
+
+import (
+	"fmt"
+	"github.com/uzudil/three.go/materials"
+)
+
+type MaterialLoader struct {
+}
+
+func NewMaterialLoader() (*MaterialLoader) {
+	return &MaterialLoader{}
+}
+
+func (loader *MaterialLoader) Load(path string) (*materials.Material, error) {
+	json, err := readJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return loader.Parse(json), nil
+}
+
+func (loader *MaterialLoader) Parse(json map[string]interface{}) (*materials.Material) {
+
+	var material *materials.Material
+
+	switch getString(json, "type", "") {
+	case "MeshBasicMaterial", "MeshLambertMaterial", "MeshPhongMaterial", "MeshStandardMaterial":
+		// lit materials are drawn unlit, keeping their color
+		basic := materials.NewMeshBasicMaterial(nil)
+		if color, ok := json["color"].(float64); ok {
+			basic.Color.SetHex( int(color) )
+		}
+		basic.VertexColors = getInt(json, "vertexColors", basic.VertexColors)
+		basic.Shading = getInt(json, "shading", basic.Shading)
+		basic.Wireframe = getBool(json, "wireframe", basic.Wireframe)
+		basic.WireframeLinewidth = getInt(json, "wireframeLinewidth", basic.WireframeLinewidth)
+		material = basic.Material
+	case "LineBasicMaterial":
+		line := materials.NewLineBasicMaterial(nil)
+		if color, ok := json["color"].(float64); ok {
+			line.Color.SetHex( int(color) )
+		}
+		line.VertexColors = getInt(json, "vertexColors", line.VertexColors)
+		line.Linewidth = getFloat(json, "linewidth", line.Linewidth)
+		material = line.Material
+	case "LineDashedMaterial":
+		dashed := materials.NewLineDashedMaterial(nil)
+		if color, ok := json["color"].(float64); ok {
+			dashed.Color.SetHex( int(color) )
+		}
+		dashed.VertexColors = getInt(json, "vertexColors", dashed.VertexColors)
+		dashed.Linewidth = getFloat(json, "linewidth", dashed.Linewidth)
+		dashed.Scale = getFloat(json, "scale", dashed.Scale)
+		dashed.DashSize = getFloat(json, "dashSize", dashed.DashSize)
+		dashed.GapSize = getFloat(json, "gapSize", dashed.GapSize)
+		material = dashed.Material
+	case "PointsMaterial":
+		points := materials.NewPointsMaterial(nil)
+		if color, ok := json["color"].(float64); ok {
+			points.Color.SetHex( int(color) )
+		}
+		points.VertexColors = getInt(json, "vertexColors", points.VertexColors)
+		points.Size = getFloat(json, "size", points.Size)
+		points.SizeAttenuation = getBool(json, "sizeAttenuation", points.SizeAttenuation)
+		material = points.Material
+	case "SpriteMaterial":
+		sprite := materials.NewSpriteMaterial(nil)
+		if color, ok := json["color"].(float64); ok {
+			sprite.Color.SetHex( int(color) )
+		}
+		sprite.Rotation = getFloat(json, "rotation", sprite.Rotation)
+		sprite.SizeAttenuation = getBool(json, "sizeAttenuation", sprite.SizeAttenuation)
+		material = sprite.Material
+	case "MultiMaterial":
+		multi := materials.NewMultiMaterial(nil)
+		for _, data := range getObjectArray(json, "materials") {
+			multi.Materials = append(multi.Materials, loader.Parse( data ))
+		}
+		material = multi.Material
+	default:
+		fmt.Println("THREE.MaterialLoader: Unsupported material type", json["type"])
+		material = materials.NewMaterial()
+	}
+
+	material.Uuid = getString(json, "uuid", material.Uuid)
+	material.Name = getString(json, "name", material.Name)
+	material.Blending = getInt(json, "blending", material.Blending)
+	material.Side = getInt(json, "side", material.Side)
+	material.Opacity = getFloat(json, "opacity", material.Opacity)
+	material.Transparent = getBool(json, "transparent", material.Transparent)
+	material.AlphaTest = getFloat(json, "alphaTest", material.AlphaTest)
+	material.DepthTest = getBool(json, "depthTest", material.DepthTest)
+	material.DepthWrite = getBool(json, "depthWrite", material.DepthWrite)
+	material.Visible = getBool(json, "visible", material.Visible)
+
+	return material
+}
@@ -0,0 +1,432 @@
+package loaders
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/uzudil/three.go/cameras"
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/extras/geometries"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/objects"
+	"github.com/uzudil/three.go/scenes"
+)
+
+// ObjectLoader reads object hierarchies written in the three.js JSON object
+// format (version 4.4), as produced by Object3D.ToJSON or three.js itself.
+type ObjectLoader struct {
+	matrix *math3d.Matrix4
+}
+
+func NewObjectLoader() (*ObjectLoader) {
+	return &ObjectLoader{
+		matrix: math3d.NewMatrix4(),
+	}
+}
+
+// Load reads and parses the file at path. The result is the parsed root
+// object, e.g. a *scenes.Scene, *objects.Mesh or *core.Object3D.
+func (loader *ObjectLoader) Load(path string) (interface{}, error) {
+	json, err := readJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return loader.Parse(json), nil
+}
+
+func (loader *ObjectLoader) Parse(json map[string]interface{}) (interface{}) {
+	geometryMap := loader.ParseGeometries( getObjectArray(json, "geometries") )
+	materialMap := loader.ParseMaterials( getObjectArray(json, "materials") )
+
+	object, _ := json["object"].(map[string]interface{})
+	return loader.ParseObject( object, geometryMap, materialMap )
+}
+
+func (loader *ObjectLoader) ParseGeometries(json []map[string]interface{}) (map[string]*core.Geometry) {
+	geometryMap := make(map[string]*core.Geometry)
+
+	jsonLoader := NewJSONLoader()
+	bufferGeometryLoader := NewBufferGeometryLoader()
+
+	for _, data := range json {
+
+		var geometry *core.Geometry
+
+		switch getString(data, "type", "") {
+		case "BoxGeometry":
+			geometry = geometries.NewBoxGeometry(
+				getFloat(data, "width", 1),
+				getFloat(data, "height", 1),
+				getFloat(data, "depth", 1),
+				getInt(data, "widthSegments", 1),
+				getInt(data, "heightSegments", 1),
+				getInt(data, "depthSegments", 1),
+			).Geometry
+		case "PlaneGeometry":
+			geometry = geometries.NewPlaneGeometry(
+				getFloat(data, "width", 1),
+				getFloat(data, "height", 1),
+				getInt(data, "widthSegments", 1),
+				getInt(data, "heightSegments", 1),
+			).Geometry
+		case "CircleGeometry":
+			geometry = geometries.NewCircleGeometry(
+				getFloat(data, "radius", 50),
+				getInt(data, "segments", 8),
+				getFloat(data, "thetaStart", 0),
+				getFloat(data, "thetaLength", math.Pi * 2),
+			).Geometry
+		case "CylinderGeometry":
+			geometry = geometries.NewCylinderGeometry(
+				getFloat(data, "radiusTop", 20),
+				getFloat(data, "radiusBottom", 20),
+				getFloat(data, "height", 100),
+				getInt(data, "radialSegments", 8),
+				getInt(data, "heightSegments", 1),
+				getBool(data, "openEnded", false),
+				getFloat(data, "thetaStart", 0),
+				getFloat(data, "thetaLength", math.Pi * 2),
+			).Geometry
+		case "SphereGeometry":
+			geometry = geometries.NewSphereGeometry(
+				getFloat(data, "radius", 50),
+				getInt(data, "widthSegments", 8),
+				getInt(data, "heightSegments", 6),
+				getFloat(data, "phiStart", 0),
+				getFloat(data, "phiLength", math.Pi * 2),
+				getFloat(data, "thetaStart", 0),
+				getFloat(data, "thetaLength", math.Pi),
+			).Geometry
+		case "PolyhedronGeometry":
+			vertices := getFloatArray(data, "vertices")
+			indices := make([]int, 0)
+			valid := true
+			for _, index := range getFloatArray(data, "indices") {
+				if index < 0 || int(index) >= len(vertices) / 3 {
+					fmt.Println("THREE.ObjectLoader: Skipping geometry", data["uuid"], "with vertex index", index, "out of range")
+					valid = false
+					break
+				}
+				indices = append(indices, int(index))
+			}
+			if !valid {
+				continue
+			}
+			geometry = geometries.NewPolyhedronGeometry(
+				vertices,
+				indices,
+				getFloat(data, "radius", 1),
+				getInt(data, "detail", 0),
+			).Geometry
+		case "TetrahedronGeometry":
+			geometry = geometries.NewTetrahedronGeometry( getFloat(data, "radius", 1), getInt(data, "detail", 0) ).Geometry
+		case "OctahedronGeometry":
+			geometry = geometries.NewOctahedronGeometry( getFloat(data, "radius", 1), getInt(data, "detail", 0) ).Geometry
+		case "IcosahedronGeometry":
+			geometry = geometries.NewIcosahedronGeometry( getFloat(data, "radius", 1), getInt(data, "detail", 0) ).Geometry
+		case "DodecahedronGeometry":
+			geometry = geometries.NewDodecahedronGeometry( getFloat(data, "radius", 1), getInt(data, "detail", 0) ).Geometry
+		case "TorusGeometry":
+			geometry = geometries.NewTorusGeometry(
+				getFloat(data, "radius", 100),
+				getFloat(data, "tube", 40),
+				getInt(data, "radialSegments", 8),
+				getInt(data, "tubularSegments", 6),
+				getFloat(data, "arc", math.Pi * 2),
+			).Geometry
+		case "TorusKnotGeometry":
+			geometry = geometries.NewTorusKnotGeometry(
+				getFloat(data, "radius", 100),
+				getFloat(data, "tube", 40),
+				getInt(data, "radialSegments", 64),
+				getInt(data, "tubularSegments", 8),
+				getInt(data, "p", 2),
+				getInt(data, "q", 3),
+				getFloat(data, "heightScale", 1),
+			).Geometry
+		case "LatheGeometry":
+			points := make([]*math3d.Vector2, 0)
+			for _, point := range getObjectArray(data, "points") {
+				points = append(points, math3d.NewVector2( getFloat(point, "x", 0), getFloat(point, "y", 0) ))
+			}
+			geometry = geometries.NewLatheGeometry(
+				points,
+				getInt(data, "segments", 12),
+				getFloat(data, "phiStart", 0),
+				getFloat(data, "phiLength", math.Pi * 2),
+			).Geometry
+		case "Geometry":
+			geometryData, _ := data["data"].(map[string]interface{})
+			var err error
+			if geometry, err = jsonLoader.Parse( geometryData ); err != nil {
+				fmt.Println("THREE.ObjectLoader: Skipping geometry", data["uuid"], err)
+				continue
+			}
+		case "BufferGeometry":
+			geometryData, _ := data["data"].(map[string]interface{})
+			var err error
+			if geometry, err = bufferGeometryLoader.Parse( geometryData ); err != nil {
+				fmt.Println("THREE.ObjectLoader: Skipping geometry", data["uuid"], err)
+				continue
+			}
+		default:
+			// geometries without parameters, e.g. ShapeGeometry, are stored as plain geometry data
+			geometryData, ok := data["data"].(map[string]interface{})
+			if !ok {
+				fmt.Println("THREE.ObjectLoader: Unsupported geometry type", data["type"])
+				continue
+			}
+			var err error
+			if geometry, err = jsonLoader.Parse( geometryData ); err != nil {
+				fmt.Println("THREE.ObjectLoader: Skipping geometry", data["uuid"], err)
+				continue
+			}
+			geometry.Type = getString(data, "type", geometry.Type)
+		}
+
+		geometry.Uuid = getString(data, "uuid", geometry.Uuid)
+		geometry.Name = getString(data, "name", geometry.Name)
+
+		geometryMap[ geometry.Uuid ] = geometry
+	}
+
+	return geometryMap
+}
+
+func (loader *ObjectLoader) ParseMaterials(json []map[string]interface{}) (map[string]*materials.Material) {
+	materialMap := make(map[string]*materials.Material)
+
+	materialLoader := NewMaterialLoader()
+
+	for _, data := range json {
+		material := materialLoader.Parse( data )
+		materialMap[ material.Uuid ] = material
+	}
+
+	return materialMap
+}
+
+func (loader *ObjectLoader) ParseObject(data map[string]interface{}, geometryMap map[string]*core.Geometry, materialMap map[string]*materials.Material) (interface{}) {
+
+	getGeometry := func(name string) (*core.Geometry) {
+		geometry, ok := geometryMap[ name ]
+		if !ok {
+			fmt.Println("THREE.ObjectLoader: Undefined geometry", name)
+			return core.NewGeometry()
+		}
+		return geometry
+	}
+
+	getMaterial := func(name string) (*materials.Material) {
+		if name == "" {
+			return nil
+		}
+		material, ok := materialMap[ name ]
+		if !ok {
+			fmt.Println("THREE.ObjectLoader: Undefined material", name)
+		}
+		return material
+	}
+
+	var object interface{}
+
+	switch getString(data, "type", "") {
+	case "Scene":
+		object = scenes.NewScene()
+	case "PerspectiveCamera":
+		camera := cameras.NewPerspectiveCamera(
+			getFloat(data, "fov", 50),
+			getFloat(data, "aspect", 1),
+			getFloat(data, "near", 0.1),
+			getFloat(data, "far", 2000),
+		)
+		camera.Zoom = getFloat(data, "zoom", camera.Zoom)
+		camera.UpdateProjectionMatrix()
+		object = camera
+	case "Mesh":
+		object = objects.NewMesh( getGeometry( getString(data, "geometry", "") ), getMaterial( getString(data, "material", "") ) )
+	case "Line":
+		object = objects.NewLine( getGeometry( getString(data, "geometry", "") ), getMaterial( getString(data, "material", "") ) )
+	case "LineSegments":
+		object = objects.NewLineSegments( getGeometry( getString(data, "geometry", "") ), getMaterial( getString(data, "material", "") ) )
+	case "LineLoop":
+		object = objects.NewLineLoop( getGeometry( getString(data, "geometry", "") ), getMaterial( getString(data, "material", "") ) )
+	case "InstancedMesh":
+		count := getInt(data, "count", 0)
+		if count < 0 {
+			fmt.Println("THREE.ObjectLoader: Negative instance count", count, "in", data["uuid"])
+			count = 0
+		}
+		mesh := objects.NewInstancedMesh( getGeometry( getString(data, "geometry", "") ), getMaterial( getString(data, "material", "") ), count )
+		if instanceMatrix := getFloatArray(data, "instanceMatrix"); len(instanceMatrix) == count * 16 {
+			for i := 0; i < count; i ++ {
+				mesh.SetMatrixAt( i, loader.matrix.FromArray( instanceMatrix[ i * 16 : ( i + 1 ) * 16 ] ) )
+			}
+		}
+		if instanceColor := getFloatArray(data, "instanceColor"); len(instanceColor) == count {
+			color := math3d.NewDefaultColor()
+			for i := 0; i < count; i ++ {
+				mesh.SetColorAt( i, color.SetHex( int(instanceColor[ i ]) ) )
+			}
+		}
+		object = mesh
+	case "SkinnedMesh":
+		mesh := objects.NewSkinnedMesh( getGeometry( getString(data, "geometry", "") ), getMaterial( getString(data, "material", "") ) )
+		// the bones are read from the children below, drop the ones built from the geometry
+		for _, child := range append([]*core.Object3D{}, mesh.Children...) {
+			if _, ok := child.Self.(*objects.Bone); ok {
+				mesh.Remove( child )
+			}
+		}
+		object = mesh
+	case "Bone":
+		object = objects.NewBone( nil )
+	case "Points":
+		object = objects.NewPoints( getGeometry( getString(data, "geometry", "") ), getMaterial( getString(data, "material", "") ) )
+	case "Sprite":
+		object = objects.NewSprite( getMaterial( getString(data, "material", "") ) )
+	case "Group":
+		object = objects.NewGroup()
+	case "LOD":
+		object = objects.NewLOD()
+	default:
+		object = core.NewObject3D()
+	}
+
+	o := GetObject3D( object )
+
+	o.Uuid = getString(data, "uuid", o.Uuid)
+	o.Name = getString(data, "name", o.Name)
+
+	if matrix := getFloatArray(data, "matrix"); len(matrix) == 16 {
+		loader.matrix.FromArray( matrix )
+		loader.matrix.Decompose( o.Position, o.Quaternion, o.Scale )
+	} else {
+		if position := getFloatArray(data, "position"); len(position) == 3 {
+			o.Position.FromArray( position, 0 )
+		}
+		if quaternion := getFloatArray(data, "quaternion"); len(quaternion) == 4 {
+			o.Quaternion.FromArray( quaternion, 0 )
+		}
+		if scale := getFloatArray(data, "scale"); len(scale) == 3 {
+			o.Scale.FromArray( scale, 0 )
+		}
+	}
+
+	o.CastShadow = getBool(data, "castShadow", o.CastShadow)
+	o.ReceiveShadow = getBool(data, "receiveShadow", o.ReceiveShadow)
+	o.Visible = getBool(data, "visible", o.Visible)
+
+	if userData, ok := data["userData"].(map[string]interface{}); ok {
+		for key, value := range userData {
+			o.UserData[ key ] = value
+		}
+	}
+
+	for _, child := range getObjectArray(data, "children") {
+		o.Add( GetObject3D( loader.ParseObject( child, geometryMap, materialMap ) ) )
+	}
+
+	loader.LinkObject( object, data, o )
+
+	return object
+}
+
+// LinkObject resolves the references object has to other objects by uuid:
+// the levels of a LOD and the bones of a SkinnedMesh. They are looked up
+// below root, so it has to be called once the objects referenced are in
+// place; ParseObject does so with the children of object.
+func (loader *ObjectLoader) LinkObject(object interface{}, data map[string]interface{}, root *core.Object3D) {
+	if lod, ok := object.(*objects.LOD); ok {
+		for _, level := range getObjectArray(data, "levels") {
+			uuid := getString(level, "object", "")
+			if child := findObjectByUuid( root, uuid ); child != nil {
+				lod.AddLevel( child, getFloat(level, "distance", 0) )
+			} else {
+				fmt.Println("THREE.ObjectLoader: Undefined LOD level object", uuid)
+			}
+		}
+	}
+
+	if mesh, ok := object.(*objects.SkinnedMesh); ok {
+		uuids, _ := data["bones"].([]interface{})
+		bones := make([]*objects.Bone, len(uuids))
+		for b, uuid := range uuids {
+			if child := findObjectByUuid( root, fmt.Sprint(uuid) ); child != nil {
+				if bone, ok := child.Self.(*objects.Bone); ok {
+					bone.Skin = mesh
+					bones[ b ] = bone
+				}
+			}
+			if bones[ b ] == nil {
+				fmt.Println("THREE.ObjectLoader: Undefined bone", uuid)
+			}
+		}
+
+		var boneInverses []*math3d.Matrix4
+		if inverses := getFloatArray(data, "boneInverses"); len(inverses) == len(bones) * 16 {
+			boneInverses = make([]*math3d.Matrix4, len(bones))
+			for b := range bones {
+				boneInverses[ b ] = math3d.NewMatrix4().FromArray( inverses[ b * 16 : ( b + 1 ) * 16 ] )
+			}
+		}
+
+		var bindMatrix *math3d.Matrix4
+		if matrix := getFloatArray(data, "bindMatrix"); len(matrix) == 16 {
+			bindMatrix = math3d.NewMatrix4().FromArray( matrix )
+		}
+
+		mesh.BindMode = getString(data, "bindMode", mesh.BindMode)
+		mesh.UpdateMatrixWorld( true )
+		mesh.Bind( objects.NewSkeleton( bones, boneInverses ), bindMatrix )
+	}
+}
+
+// GetObject3D returns the Object3D embedded in one of the object types created by ParseObject.
+func GetObject3D(object interface{}) (*core.Object3D) {
+	switch o := object.(type) {
+	case *scenes.Scene:
+		return o.Object3D
+	case *cameras.PerspectiveCamera:
+		return o.Object3D
+	case *objects.Mesh:
+		return o.Object3D
+	case *objects.Line:
+		return o.Object3D
+	case *objects.LineSegments:
+		return o.Object3D
+	case *objects.LineLoop:
+		return o.Object3D
+	case *objects.InstancedMesh:
+		return o.Object3D
+	case *objects.SkinnedMesh:
+		return o.Object3D
+	case *objects.Bone:
+		return o.Object3D
+	case *objects.Points:
+		return o.Object3D
+	case *objects.Sprite:
+		return o.Object3D
+	case *objects.Group:
+		return o.Object3D
+	case *objects.LOD:
+		return o.Object3D
+	case *core.Object3D:
+		return o
+	}
+	panic(fmt.Sprintf("THREE.ObjectLoader: %T is not an object", object))
+}
+
+// findObjectByUuid returns the descendant of object, or object itself, with uuid.
+func findObjectByUuid(object *core.Object3D, uuid string) (*core.Object3D) {
+	if object.Uuid == uuid {
+		return object
+	}
+	for _, child := range object.Children {
+		if found := findObjectByUuid( child, uuid ); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package loaders
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/objects"
+	"github.com/uzudil/three.go/scenes"
+)
+
+// roundTrip writes object as JSON text and reads it back with an ObjectLoader.
+func roundTrip(t *testing.T, object *core.Object3D) (interface{}) {
+	text, err := json.Marshal( object.ToJSON( nil ) )
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewObjectLoader().Parse( decodeJSON(t, string(text)) )
+}
+
+func TestObjectLoaderRoundTrip(t *testing.T) {
+	geometry := core.NewGeometry()
+	geometry.Vertices = append(geometry.Vertices,
+		math3d.NewVector3( 0, 0, 0 ),
+		math3d.NewVector3( 1, 0, 0 ),
+		math3d.NewVector3( 0, 1, 0 ),
+	)
+	geometry.Faces = append(geometry.Faces, core.NewDefaultFace3( 0, 1, 2 ))
+	geometry.FaceVertexUvs[ 0 ] = append(geometry.FaceVertexUvs[ 0 ], []*math3d.Vector2{
+		math3d.NewVector2( 0, 0 ), math3d.NewVector2( 1, 0 ), math3d.NewVector2( 0, 1 ),
+	})
+	geometry.ComputeFaceNormals()
+
+	material := materials.NewMeshBasicMaterial(nil)
+	material.Color.SetHex( 0x336699 )
+	material.AlphaTest = 0.5
+
+	mesh := objects.NewMesh( geometry, material.Material )
+	mesh.Name = "triangle"
+	mesh.Position.Set( 1, 2, 3 )
+	mesh.UserData[ "count" ] = 5.0
+	mesh.UserData[ "tags" ] = []interface{}{ "a", "b" }
+
+	scene := scenes.NewScene()
+	scene.Add( mesh.Object3D )
+
+	loaded, ok := roundTrip(t, scene.Object3D).(*scenes.Scene)
+	if !ok {
+		t.Fatalf("got %T, want *scenes.Scene", loaded)
+	}
+	if len(loaded.Children) != 1 {
+		t.Fatalf("got %d children, want 1", len(loaded.Children))
+	}
+
+	loadedMesh, ok := loaded.Children[ 0 ].Self.(*objects.Mesh)
+	if !ok {
+		t.Fatalf("got %T, want *objects.Mesh", loaded.Children[ 0 ].Self)
+	}
+	if loadedMesh.Name != "triangle" || !loadedMesh.Position.Equals( mesh.Position ) {
+		t.Errorf("got %q at %v, want %q at %v", loadedMesh.Name, loadedMesh.Position, mesh.Name, mesh.Position)
+	}
+
+	if count, _ := loadedMesh.UserData[ "count" ].(float64); count != 5 {
+		t.Errorf("userData count is %v, want 5", loadedMesh.UserData[ "count" ])
+	}
+	if tags, _ := loadedMesh.UserData[ "tags" ].([]interface{}); len(tags) != 2 {
+		t.Errorf("userData tags is %v, want [ a b ]", loadedMesh.UserData[ "tags" ])
+	}
+
+	loadedGeometry := loadedMesh.Geometry
+	if len(loadedGeometry.Vertices) != 3 || len(loadedGeometry.Faces) != 1 {
+		t.Fatalf("got %d vertices and %d faces, want 3 and 1", len(loadedGeometry.Vertices), len(loadedGeometry.Faces))
+	}
+	if len(loadedGeometry.FaceVertexUvs) != 1 || len(loadedGeometry.FaceVertexUvs[ 0 ]) != 1 {
+		t.Errorf("got uv layers %v, want one layer with one face", loadedGeometry.FaceVertexUvs)
+	}
+
+	loadedMaterial, ok := loadedMesh.Material.Self.(*materials.MeshBasicMaterial)
+	if !ok {
+		t.Fatalf("got %T, want *materials.MeshBasicMaterial", loadedMesh.Material.Self)
+	}
+	if loadedMaterial.Color.GetHex() != 0x336699 || loadedMaterial.AlphaTest != 0.5 {
+		t.Errorf("got color %x alphaTest %v, want 336699 and 0.5", loadedMaterial.Color.GetHex(), loadedMaterial.AlphaTest)
+	}
+}
+
+func TestMaterialLoaderLitMaterials(t *testing.T) {
+	for _, materialType := range []string{ "MeshLambertMaterial", "MeshPhongMaterial", "MeshStandardMaterial" } {
+		material := NewMaterialLoader().Parse( decodeJSON(t, `{ "type": "` + materialType + `", "color": 16711680, "wireframe": true }`) )
+
+		basic, ok := material.Self.(*materials.MeshBasicMaterial)
+		if !ok {
+			t.Errorf("%s: got %T, want *materials.MeshBasicMaterial", materialType, material.Self)
+			continue
+		}
+		if basic.Color.GetHex() != 0xff0000 || !basic.Wireframe {
+			t.Errorf("%s: got color %x wireframe %v, want ff0000 and true", materialType, basic.Color.GetHex(), basic.Wireframe)
+		}
+	}
+}
+
+func TestObjectLoaderBufferGeometry(t *testing.T) {
+	data := decodeJSON(t, `{
+		"geometries": [ {
+			"uuid": "G",
+			"type": "BufferGeometry",
+			"data": { "attributes": { "position": { "itemSize": 3, "array": [ 0,0,0, 1,0,0, 0,1,0 ] } } }
+		} ],
+		"materials": [ { "uuid": "M", "type": "MeshPhongMaterial", "color": 255 } ],
+		"object": { "uuid": "O", "type": "Mesh", "geometry": "G", "material": "M" }
+	}`)
+
+	mesh, ok := NewObjectLoader().Parse( data ).(*objects.Mesh)
+	if !ok {
+		t.Fatal("no mesh")
+	}
+	if mesh.Geometry == nil || len(mesh.Geometry.Faces) != 1 {
+		t.Errorf("got geometry %v, want one face", mesh.Geometry)
+	}
+}
+
+func TestObjectLoaderInvalidReferences(t *testing.T) {
+	data := decodeJSON(t, `{
+		"geometries": [ {
+			"uuid": "P",
+			"type": "PolyhedronGeometry",
+			"vertices": [ 0,0,0, 1,0,0, 0,1,0 ],
+			"indices": [ 0, 1, 3 ]
+		} ],
+		"materials": [ { "uuid": "M", "type": "MeshBasicMaterial" } ],
+		"object": { "uuid": "S", "type": "Scene", "children": [
+			{ "uuid": "A", "type": "Mesh", "geometry": "P", "material": "M" },
+			{ "uuid": "B", "type": "Mesh", "geometry": "missing", "material": "M" },
+			{ "uuid": "C", "type": "InstancedMesh", "geometry": "missing", "material": "M", "count": -4 }
+		] }
+	}`)
+
+	scene, ok := NewObjectLoader().Parse( data ).(*scenes.Scene)
+	if !ok {
+		t.Fatal("no scene")
+	}
+	if len(scene.Children) != 3 {
+		t.Fatalf("got %d children, want 3", len(scene.Children))
+	}
+	for _, child := range scene.Children {
+		if child.Geometry == nil {
+			t.Errorf("%s has no geometry", child.Uuid)
+		}
+	}
+	if mesh, ok := scene.Children[ 2 ].Self.(*objects.InstancedMesh); !ok || mesh.Count != 0 {
+		t.Errorf("got %T, want an *objects.InstancedMesh with no instances", scene.Children[ 2 ].Self)
+	}
+}
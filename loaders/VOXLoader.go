@@ -0,0 +1,358 @@
+package loaders
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/extras/geometries"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// VOXLoader reads MagicaVoxel .vox files.
+// See https://github.com/ephtracy/voxel-model/blob/master/MagicaVoxel-file-format-vox.txt
+type VOXLoader struct {
+	// ConvertUpAxis maps MagicaVoxel's Z up to Y up.
+	ConvertUpAxis bool
+}
+
+func NewVOXLoader() (*VOXLoader) {
+	return &VOXLoader{
+		ConvertUpAxis: true,
+	}
+}
+
+// voxMaxSize is the largest model MagicaVoxel makes along each axis.
+const voxMaxSize = 256
+
+type Voxel struct {
+	X, Y, Z int
+	ColorIndex int
+}
+
+type VOXModel struct {
+	SizeX, SizeY, SizeZ int
+	Voxels []Voxel
+	// Palette is shared by all models of a file; index 0 is unused.
+	Palette []*math3d.Color
+	convertUpAxis bool
+}
+
+func (loader *VOXLoader) Load(path string) ([]*VOXModel, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return loader.Parse(data)
+}
+
+func (loader *VOXLoader) Parse(data []byte) ([]*VOXModel, error) {
+
+	if len(data) < 8 || string(data[:4]) != "VOX " {
+		return nil, fmt.Errorf("THREE.VOXLoader: not a vox file")
+	}
+
+	palette := make([]*math3d.Color, 256)
+	for i, abgr := range voxDefaultPalette {
+		palette[ i ] = voxColor( abgr )
+	}
+
+	models := make([]*VOXModel, 0)
+	var model *VOXModel
+
+	// the MAIN chunk's children follow its (empty) content directly,
+	// so the chunks can be read as a flat list
+	offset := 8
+	for offset + 12 <= len(data) {
+		id := string(data[ offset : offset + 4 ])
+		contentSize := int(binary.LittleEndian.Uint32(data[ offset + 4 : ]))
+		offset += 12
+
+		if offset + contentSize > len(data) {
+			return nil, fmt.Errorf("THREE.VOXLoader: chunk %s is truncated", id)
+		}
+		content := data[ offset : offset + contentSize ]
+		offset += contentSize
+
+		switch id {
+		case "SIZE":
+			if len(content) < 12 {
+				return nil, fmt.Errorf("THREE.VOXLoader: bad SIZE chunk")
+			}
+			model = &VOXModel{
+				SizeX: int(binary.LittleEndian.Uint32(content[ 0 : ])),
+				SizeY: int(binary.LittleEndian.Uint32(content[ 4 : ])),
+				SizeZ: int(binary.LittleEndian.Uint32(content[ 8 : ])),
+				Palette: palette,
+				convertUpAxis: loader.ConvertUpAxis,
+			}
+			for _, size := range []int{ model.SizeX, model.SizeY, model.SizeZ } {
+				if size < 1 || size > voxMaxSize {
+					return nil, fmt.Errorf("THREE.VOXLoader: model size %d %d %d is not between 1 and %d", model.SizeX, model.SizeY, model.SizeZ, voxMaxSize)
+				}
+			}
+			models = append(models, model)
+
+		case "XYZI":
+			if model == nil || len(content) < 4 {
+				return nil, fmt.Errorf("THREE.VOXLoader: XYZI chunk without SIZE")
+			}
+			numVoxels := int(binary.LittleEndian.Uint32(content))
+			if 4 + numVoxels * 4 > len(content) {
+				return nil, fmt.Errorf("THREE.VOXLoader: bad XYZI chunk")
+			}
+			model.Voxels = make([]Voxel, numVoxels)
+			for i := 0; i < numVoxels; i ++ {
+				v := content[ 4 + i * 4 : ]
+				model.Voxels[ i ] = Voxel{ int(v[ 0 ]), int(v[ 1 ]), int(v[ 2 ]), int(v[ 3 ]) }
+			}
+
+		case "RGBA":
+			if len(content) < 256 * 4 {
+				return nil, fmt.Errorf("THREE.VOXLoader: bad RGBA chunk")
+			}
+			// color i of the chunk is palette index i + 1
+			for i := 0; i < 255; i ++ {
+				palette[ i + 1 ] = voxColor( binary.LittleEndian.Uint32(content[ i * 4 : ]) )
+			}
+		}
+	}
+
+	return models, nil
+}
+
+func voxColor(abgr uint32) (*math3d.Color) {
+	return math3d.NewColor(
+		float64( abgr & 0xff ) / 255,
+		float64( ( abgr >> 8 ) & 0xff ) / 255,
+		float64( ( abgr >> 16 ) & 0xff ) / 255,
+	)
+}
+
+// grid returns the palette index of every cell, 0 for empty cells.
+func (model *VOXModel) grid() ([]int) {
+	grid := make([]int, model.SizeX * model.SizeY * model.SizeZ)
+	for _, voxel := range model.Voxels {
+		if voxel.X < model.SizeX && voxel.Y < model.SizeY && voxel.Z < model.SizeZ {
+			grid[ voxel.X + model.SizeX * ( voxel.Y + model.SizeY * voxel.Z ) ] = voxel.ColorIndex
+		}
+	}
+	return grid
+}
+
+func (model *VOXModel) vertex(x, y, z float64) (*math3d.Vector3) {
+	if model.convertUpAxis {
+		return math3d.NewVector3( x, z, - y )
+	}
+	return math3d.NewVector3( x, y, z )
+}
+
+// GreedyGeometry meshes the visible voxel faces, merging coplanar neighbours of
+// the same color into larger quads. Each face's Color is set from the palette.
+// Based on https://0fps.net/2012/06/30/meshing-in-a-minecraft-game/
+func (model *VOXModel) GreedyGeometry() (*core.Geometry) {
+
+	geometry := core.NewGeometry()
+
+	dims := [3]int{ model.SizeX, model.SizeY, model.SizeZ }
+	grid := model.grid()
+
+	get := func(x [3]int) int {
+		for d := 0; d < 3; d ++ {
+			if x[ d ] < 0 || x[ d ] >= dims[ d ] {
+				return 0
+			}
+		}
+		return grid[ x[ 0 ] + dims[ 0 ] * ( x[ 1 ] + dims[ 1 ] * x[ 2 ] ) ]
+	}
+
+	for d := 0; d < 3; d ++ {
+
+		u := ( d + 1 ) % 3
+		v := ( d + 2 ) % 3
+
+		var x, q [3]int
+		q[ d ] = 1
+
+		// positive values face along +d, negative values along -d
+		mask := make([]int, dims[ u ] * dims[ v ])
+
+		for x[ d ] = -1; x[ d ] < dims[ d ]; {
+
+			// compute the mask
+			n := 0
+			for x[ v ] = 0; x[ v ] < dims[ v ]; x[ v ] ++ {
+				for x[ u ] = 0; x[ u ] < dims[ u ]; x[ u ] ++ {
+					a := get( x )
+					b := get( [3]int{ x[ 0 ] + q[ 0 ], x[ 1 ] + q[ 1 ], x[ 2 ] + q[ 2 ] } )
+					switch {
+					case a != 0 && b == 0:
+						mask[ n ] = a
+					case a == 0 && b != 0:
+						mask[ n ] = - b
+					default:
+						mask[ n ] = 0
+					}
+					n ++
+				}
+			}
+
+			x[ d ] ++
+
+			// generate quads from the mask
+			n = 0
+			for j := 0; j < dims[ v ]; j ++ {
+				for i := 0; i < dims[ u ]; {
+
+					c := mask[ n ]
+					if c == 0 {
+						i ++
+						n ++
+						continue
+					}
+
+					// compute width
+					w := 1
+					for i + w < dims[ u ] && mask[ n + w ] == c {
+						w ++
+					}
+
+					// compute height
+					h := 1
+					for ; j + h < dims[ v ]; h ++ {
+						done := false
+						for k := 0; k < w; k ++ {
+							if mask[ n + k + h * dims[ u ] ] != c {
+								done = true
+								break
+							}
+						}
+						if done {
+							break
+						}
+					}
+
+					x[ u ] = i
+					x[ v ] = j
+					var du, dv [3]int
+					du[ u ] = w
+					dv[ v ] = h
+
+					model.addQuad( geometry, d, x, du, dv, c )
+
+					// zero out the mask
+					for l := 0; l < h; l ++ {
+						for k := 0; k < w; k ++ {
+							mask[ n + k + l * dims[ u ] ] = 0
+						}
+					}
+
+					i += w
+					n += w
+				}
+			}
+		}
+	}
+
+	geometry.ComputeBoundingSphere()
+
+	return geometry
+}
+
+// addQuad adds the two faces of a greedy quad. c is the signed palette index from the mask.
+func (model *VOXModel) addQuad(geometry *core.Geometry, d int, x, du, dv [3]int, c int) {
+
+	corner := func(a, b [3]int) (*math3d.Vector3) {
+		return model.vertex( float64( x[ 0 ] + a[ 0 ] + b[ 0 ] ), float64( x[ 1 ] + a[ 1 ] + b[ 1 ] ), float64( x[ 2 ] + a[ 2 ] + b[ 2 ] ) )
+	}
+
+	var zero [3]int
+	offset := len(geometry.Vertices)
+	geometry.Vertices = append(geometry.Vertices,
+		corner( zero, zero ),
+		corner( du, zero ),
+		corner( du, dv ),
+		corner( zero, dv ),
+	)
+
+	var n [3]int
+	colorIndex := c
+	if c > 0 {
+		n[ d ] = 1
+	} else {
+		n[ d ] = -1
+		colorIndex = - c
+	}
+	normal := model.vertex( float64( n[ 0 ] ), float64( n[ 1 ] ), float64( n[ 2 ] ) )
+	color := model.Palette[ colorIndex ]
+
+	w := float64( du[ 0 ] + du[ 1 ] + du[ 2 ] )
+	h := float64( dv[ 0 ] + dv[ 1 ] + dv[ 2 ] )
+	uvs := []*math3d.Vector2{
+		math3d.NewVector2( 0, 0 ),
+		math3d.NewVector2( w, 0 ),
+		math3d.NewVector2( w, h ),
+		math3d.NewVector2( 0, h ),
+	}
+
+	// the corners run counter clockwise seen from +d, reverse them for back faces
+	triangles := [][3]int{ { 0, 1, 2 }, { 0, 2, 3 } }
+	if c < 0 {
+		triangles = [][3]int{ { 0, 2, 1 }, { 0, 3, 2 } }
+	}
+
+	for _, t := range triangles {
+		face := core.NewDefaultFace3( offset + t[ 0 ], offset + t[ 1 ], offset + t[ 2 ] )
+		face.Normal.Copy( normal )
+		face.VertexNormals = append( face.VertexNormals, normal.Clone(), normal.Clone(), normal.Clone() )
+		face.Color.Copy( color )
+
+		geometry.Faces = append(geometry.Faces, face)
+		geometry.FaceVertexUvs[ 0 ] = append(geometry.FaceVertexUvs[ 0 ], []*math3d.Vector2{ uvs[ t[ 0 ] ].Clone(), uvs[ t[ 1 ] ].Clone(), uvs[ t[ 2 ] ].Clone() })
+	}
+}
+
+// NaiveGeometry merges one unit BoxGeometry per voxel, without removing hidden faces.
+func (model *VOXModel) NaiveGeometry() (*core.Geometry) {
+
+	geometry := core.NewGeometry()
+	matrix := math3d.NewMatrix4()
+
+	for _, voxel := range model.Voxels {
+
+		box := geometries.NewDefaultBoxGeometry( 1, 1, 1 )
+		for _, face := range box.Faces {
+			face.Color.Copy( model.Palette[ voxel.ColorIndex ] )
+		}
+
+		center := model.vertex( float64( voxel.X ) + 0.5, float64( voxel.Y ) + 0.5, float64( voxel.Z ) + 0.5 )
+		matrix.MakeTranslation( center.X, center.Y, center.Z )
+
+		geometry.Merge( box.Geometry, matrix, 0 )
+	}
+
+	geometry.ComputeBoundingSphere()
+
+	return geometry
+}
+
+// the palette used by files without an RGBA chunk, as 0xAABBGGRR
+var voxDefaultPalette = [256]uint32{
+	0x00000000, 0xffffffff, 0xffccffff, 0xff99ffff, 0xff66ffff, 0xff33ffff, 0xff00ffff, 0xffffccff, 0xffccccff, 0xff99ccff, 0xff66ccff, 0xff33ccff, 0xff00ccff, 0xffff99ff, 0xffcc99ff, 0xff9999ff,
+	0xff6699ff, 0xff3399ff, 0xff0099ff, 0xffff66ff, 0xffcc66ff, 0xff9966ff, 0xff6666ff, 0xff3366ff, 0xff0066ff, 0xffff33ff, 0xffcc33ff, 0xff9933ff, 0xff6633ff, 0xff3333ff, 0xff0033ff, 0xffff00ff,
+	0xffcc00ff, 0xff9900ff, 0xff6600ff, 0xff3300ff, 0xff0000ff, 0xffffffcc, 0xffccffcc, 0xff99ffcc, 0xff66ffcc, 0xff33ffcc, 0xff00ffcc, 0xffffcccc, 0xffcccccc, 0xff99cccc, 0xff66cccc, 0xff33cccc,
+	0xff00cccc, 0xffff99cc, 0xffcc99cc, 0xff9999cc, 0xff6699cc, 0xff3399cc, 0xff0099cc, 0xffff66cc, 0xffcc66cc, 0xff9966cc, 0xff6666cc, 0xff3366cc, 0xff0066cc, 0xffff33cc, 0xffcc33cc, 0xff9933cc,
+	0xff6633cc, 0xff3333cc, 0xff0033cc, 0xffff00cc, 0xffcc00cc, 0xff9900cc, 0xff6600cc, 0xff3300cc, 0xff0000cc, 0xffffff99, 0xffccff99, 0xff99ff99, 0xff66ff99, 0xff33ff99, 0xff00ff99, 0xffffcc99,
+	0xffcccc99, 0xff99cc99, 0xff66cc99, 0xff33cc99, 0xff00cc99, 0xffff9999, 0xffcc9999, 0xff999999, 0xff669999, 0xff339999, 0xff009999, 0xffff6699, 0xffcc6699, 0xff996699, 0xff666699, 0xff336699,
+	0xff006699, 0xffff3399, 0xffcc3399, 0xff993399, 0xff663399, 0xff333399, 0xff003399, 0xffff0099, 0xffcc0099, 0xff990099, 0xff660099, 0xff330099, 0xff000099, 0xffffff66, 0xffccff66, 0xff99ff66,
+	0xff66ff66, 0xff33ff66, 0xff00ff66, 0xffffcc66, 0xffcccc66, 0xff99cc66, 0xff66cc66, 0xff33cc66, 0xff00cc66, 0xffff9966, 0xffcc9966, 0xff999966, 0xff669966, 0xff339966, 0xff009966, 0xffff6666,
+	0xffcc6666, 0xff996666, 0xff666666, 0xff336666, 0xff006666, 0xffff3366, 0xffcc3366, 0xff993366, 0xff663366, 0xff333366, 0xff003366, 0xffff0066, 0xffcc0066, 0xff990066, 0xff660066, 0xff330066,
+	0xff000066, 0xffffff33, 0xffccff33, 0xff99ff33, 0xff66ff33, 0xff33ff33, 0xff00ff33, 0xffffcc33, 0xffcccc33, 0xff99cc33, 0xff66cc33, 0xff33cc33, 0xff00cc33, 0xffff9933, 0xffcc9933, 0xff999933,
+	0xff669933, 0xff339933, 0xff009933, 0xffff6633, 0xffcc6633, 0xff996633, 0xff666633, 0xff336633, 0xff006633, 0xffff3333, 0xffcc3333, 0xff993333, 0xff663333, 0xff333333, 0xff003333, 0xffff0033,
+	0xffcc0033, 0xff990033, 0xff660033, 0xff330033, 0xff000033, 0xffffff00, 0xffccff00, 0xff99ff00, 0xff66ff00, 0xff33ff00, 0xff00ff00, 0xffffcc00, 0xffcccc00, 0xff99cc00, 0xff66cc00, 0xff33cc00,
+	0xff00cc00, 0xffff9900, 0xffcc9900, 0xff999900, 0xff669900, 0xff339900, 0xff009900, 0xffff6600, 0xffcc6600, 0xff996600, 0xff666600, 0xff336600, 0xff006600, 0xffff3300, 0xffcc3300, 0xff993300,
+	0xff663300, 0xff333300, 0xff003300, 0xffff0000, 0xffcc0000, 0xff990000, 0xff660000, 0xff330000, 0xff0000ee, 0xff0000dd, 0xff0000bb, 0xff0000aa, 0xff000088, 0xff000077, 0xff000055, 0xff000044,
+	0xff000022, 0xff000011, 0xff00ee00, 0xff00dd00, 0xff00bb00, 0xff00aa00, 0xff008800, 0xff007700, 0xff005500, 0xff004400, 0xff002200, 0xff001100, 0xffee0000, 0xffdd0000, 0xffbb0000, 0xffaa0000,
+	0xff880000, 0xff770000, 0xff550000, 0xff440000, 0xff220000, 0xff110000, 0xffeeeeee, 0xffdddddd, 0xffbbbbbb, 0xffaaaaaa, 0xff888888, 0xff777777, 0xff555555, 0xff444444, 0xff222222, 0xff111111,
+}
@@ -0,0 +1,105 @@
+package loaders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// voxFile returns a .vox file with one model of size x, y, z holding voxels,
+// each given as x, y, z and palette index.
+func voxFile(x, y, z int, voxels ...[4]byte) ([]byte) {
+	chunk := func(id string, content, children []byte) ([]byte) {
+		var buffer bytes.Buffer
+		buffer.WriteString(id)
+		binary.Write(&buffer, binary.LittleEndian, uint32(len(content)))
+		binary.Write(&buffer, binary.LittleEndian, uint32(len(children)))
+		buffer.Write(content)
+		buffer.Write(children)
+		return buffer.Bytes()
+	}
+
+	var size, xyzi bytes.Buffer
+	binary.Write(&size, binary.LittleEndian, [3]uint32{ uint32(x), uint32(y), uint32(z) })
+	binary.Write(&xyzi, binary.LittleEndian, uint32(len(voxels)))
+	for _, voxel := range voxels {
+		xyzi.Write(voxel[:])
+	}
+
+	children := append(chunk( "SIZE", size.Bytes(), nil ), chunk( "XYZI", xyzi.Bytes(), nil )...)
+
+	var file bytes.Buffer
+	file.WriteString("VOX ")
+	binary.Write(&file, binary.LittleEndian, uint32(150))
+	file.Write(chunk( "MAIN", nil, children ))
+	return file.Bytes()
+}
+
+func TestVOXLoaderParse(t *testing.T) {
+	models, err := NewVOXLoader().Parse( voxFile( 2, 1, 1, [4]byte{ 0, 0, 0, 5 }, [4]byte{ 1, 0, 0, 5 } ) )
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 1 {
+		t.Fatalf("got %d models, want 1", len(models))
+	}
+
+	model := models[ 0 ]
+	if model.SizeX != 2 || model.SizeY != 1 || model.SizeZ != 1 || len(model.Voxels) != 2 {
+		t.Errorf("got size %d %d %d with %d voxels, want 2 1 1 with 2", model.SizeX, model.SizeY, model.SizeZ, len(model.Voxels))
+	}
+	if model.Voxels[ 1 ].X != 1 || model.Voxels[ 1 ].ColorIndex != 5 {
+		t.Errorf("got second voxel %v, want x 1 color 5", model.Voxels[ 1 ])
+	}
+
+	if _, err := NewVOXLoader().Parse( voxFile( 1, 1, 1 )[ :36 ] ); err == nil {
+		t.Error("truncated file: no error")
+	}
+	for _, size := range [][3]int{ { 0, 1, 1 }, { 1, 257, 1 }, { 1 << 31, 1 << 31, 4 } } {
+		if _, err := NewVOXLoader().Parse( voxFile( size[ 0 ], size[ 1 ], size[ 2 ] ) ); err == nil {
+			t.Errorf("size %v: no error", size)
+		}
+	}
+}
+
+func TestVOXGreedyGeometry(t *testing.T) {
+	cases := []struct {
+		name string
+		voxels [][4]byte
+		faces int
+	}{
+		// the box is merged into one quad per side
+		{ "same color", [][4]byte{ { 0, 0, 0, 5 }, { 1, 0, 0, 5 } }, 12 },
+		// the four long sides are split by color, the two ends are not
+		{ "two colors", [][4]byte{ { 0, 0, 0, 5 }, { 1, 0, 0, 6 } }, 20 },
+	}
+
+	for _, c := range cases {
+		models, err := NewVOXLoader().Parse( voxFile( 2, 1, 1, c.voxels... ) )
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		geometry := models[ 0 ].GreedyGeometry()
+		if len(geometry.Faces) != c.faces {
+			t.Errorf("%s: got %d faces, want %d", c.name, len(geometry.Faces), c.faces)
+		}
+		if len(geometry.FaceVertexUvs[ 0 ]) != len(geometry.Faces) {
+			t.Errorf("%s: got uvs for %d faces, want %d", c.name, len(geometry.FaceVertexUvs[ 0 ]), len(geometry.Faces))
+		}
+
+		// no face between the two voxels
+		for _, face := range geometry.Faces {
+			vertices := geometry.Vertices
+			if vertices[ face.A ].X == 1 && vertices[ face.B ].X == 1 && vertices[ face.C ].X == 1 {
+				t.Errorf("%s: hidden face between the voxels", c.name)
+				break
+			}
+		}
+	}
+
+	models, _ := NewVOXLoader().Parse( voxFile( 2, 1, 1, [4]byte{ 0, 0, 0, 5 }, [4]byte{ 1, 0, 0, 5 } ) )
+	if naive := models[ 0 ].NaiveGeometry(); len(naive.Faces) != 24 {
+		t.Errorf("naive geometry has %d faces, want 24", len(naive.Faces))
+	}
+}
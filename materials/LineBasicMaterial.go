@@ -0,0 +1,84 @@
+package materials
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+	three "github.com/uzudil/three.go"
+	"github.com/uzudil/three.go/core"
+)
+
+/**
+ * @author mrdoob / http://mrdoob.com/
+ * @author alteredq / http://alteredqualia.com/
+ *
+ * parameters = {
+ *  color: <hex>,
+ *  opacity: <float>,
+ *
+ *  blending: THREE.NormalBlending,
+ *  depthTest: <bool>,
+ *  depthWrite: <bool>,
+ *
+ *  linewidth: <float>,
+ *  linecap: "round",
+ *  linejoin: "round",
+ *
+ *  vertexColors: <bool>
+ *
+ *  fog: <bool>
+ * }
+ */
+
+type LineBasicMaterial struct {
+	*Material
+	Color *math3d.Color
+	Linewidth float64
+	Linecap, Linejoin string
+	VertexColors int
+	Fog bool
+}
+
+func NewLineBasicMaterial(parameters map[string]interface{}) (*LineBasicMaterial) {
+	m := &LineBasicMaterial{
+		Material: NewMaterial(),
+	}
+	m.Type = "LineBasicMaterial"
+	m.Self = m
+	m.Color = math3d.NewColor(1.0, 1.0, 1.0)
+	m.Linewidth = 1
+	m.Linecap = "round"
+	m.Linejoin = "round"
+	m.Blending = three.NormalBlending
+	m.VertexColors = three.NoColors
+	m.Fog = true
+	m.Material.ToJSON = m.toJSON
+
+	m.SetValues( parameters )
+
+	return m
+}
+
+func (m *LineBasicMaterial) Copy(source *LineBasicMaterial) (*LineBasicMaterial) {
+	m.Material.Copy(source.Material)
+	m.Color.Copy(source.Color)
+	m.Linewidth = source.Linewidth
+	m.Linecap = source.Linecap
+	m.Linejoin = source.Linejoin
+	m.VertexColors = source.VertexColors
+	m.Fog = source.Fog
+	return m
+}
+
+func (m *LineBasicMaterial) toJSON(meta *core.JSONMeta) (map[string]interface{}) {
+	data := m.Material.BaseToJSON(meta)
+
+	data["color"] = m.Color.GetHex()
+
+	if m.VertexColors != three.NoColors {
+		data["vertexColors"] = m.VertexColors
+	}
+	if m.Linewidth != 1 {
+		data["linewidth"] = m.Linewidth
+	}
+
+	return data
+}
@@ -0,0 +1,94 @@
+package materials
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+	three "github.com/uzudil/three.go"
+	"github.com/uzudil/three.go/core"
+)
+
+/**
+ * @author alteredq / http://alteredqualia.com/
+ *
+ * parameters = {
+ *  color: <hex>,
+ *  opacity: <float>,
+ *
+ *  blending: THREE.NormalBlending,
+ *  depthTest: <bool>,
+ *  depthWrite: <bool>,
+ *
+ *  linewidth: <float>,
+ *
+ *  scale: <float>,
+ *  dashSize: <float>,
+ *  gapSize: <float>,
+ *
+ *  vertexColors: <bool>
+ *
+ *  fog: <bool>
+ * }
+ */
+
+// LineDashedMaterial draws dashes of DashSize separated by gaps of GapSize, both
+// divided by Scale. It needs the line distances of the geometry, see
+// Geometry.ComputeLineDistances.
+type LineDashedMaterial struct {
+	*Material
+	Color *math3d.Color
+	Linewidth float64
+	Scale float64
+	DashSize float64
+	GapSize float64
+	VertexColors int
+	Fog bool
+}
+
+func NewLineDashedMaterial(parameters map[string]interface{}) (*LineDashedMaterial) {
+	m := &LineDashedMaterial{
+		Material: NewMaterial(),
+	}
+	m.Type = "LineDashedMaterial"
+	m.Self = m
+	m.Color = math3d.NewColor(1.0, 1.0, 1.0)
+	m.Linewidth = 1
+	m.Scale = 1
+	m.DashSize = 3
+	m.GapSize = 1
+	m.VertexColors = three.NoColors
+	m.Fog = true
+	m.Material.ToJSON = m.toJSON
+
+	m.SetValues( parameters )
+
+	return m
+}
+
+func (m *LineDashedMaterial) Copy(source *LineDashedMaterial) (*LineDashedMaterial) {
+	m.Material.Copy(source.Material)
+	m.Color.Copy(source.Color)
+	m.Linewidth = source.Linewidth
+	m.Scale = source.Scale
+	m.DashSize = source.DashSize
+	m.GapSize = source.GapSize
+	m.VertexColors = source.VertexColors
+	m.Fog = source.Fog
+	return m
+}
+
+func (m *LineDashedMaterial) toJSON(meta *core.JSONMeta) (map[string]interface{}) {
+	data := m.Material.BaseToJSON(meta)
+
+	data["color"] = m.Color.GetHex()
+
+	if m.VertexColors != three.NoColors {
+		data["vertexColors"] = m.VertexColors
+	}
+	if m.Linewidth != 1 {
+		data["linewidth"] = m.Linewidth
+	}
+	data["scale"] = m.Scale
+	data["dashSize"] = m.DashSize
+	data["gapSize"] = m.GapSize
+
+	return data
+}
@@ -26,10 +26,16 @@ type Material struct {
 	Precision int
 	PolygonOffset bool
 	PolygonOffsetFactor, PolygonOffsetUnits int
-	AlphaTest int
+	AlphaTest float64
 	Overdraw int
 	Visible bool
 	needsUpdate bool
+
+	// Self is the value embedding this Material, e.g. a *MeshBasicMaterial, so
+	// code holding a *Material can get back to it. It is the Material itself otherwise.
+	Self interface{}
+
+	ToJSON func(*core.JSONMeta) (map[string]interface{})
 }
 
 var MaterialIdCount int = 0
@@ -64,6 +70,8 @@ func NewMaterial() (*Material) {
 		Visible: true,
 		needsUpdate: true,
 	}
+	m.ToJSON = m.BaseToJSON
+	m.Self = m
 	return m
 }
 
@@ -107,75 +115,54 @@ func (m *Material) SetValues(values map[string]interface{}) {
 	}
 }
 
-/*
-	toJSON: function ( meta ) {
-
-		var data = {
-			metadata: {
-				version: 4.4,
-				type: 'Material',
-				generator: 'Material.toJSON'
-			}
-		};
-
-		// standard Material serialization
-		data.uuid = m.uuid;
-		data.type = m.type;
-		if ( m.name !== '' ) data.name = m.name;
-
-		if ( m.color instanceof THREE.Color ) data.color = m.color.getHex();
-		if ( m.emissive instanceof THREE.Color ) data.emissive = m.emissive.getHex();
-		if ( m.specular instanceof THREE.Color ) data.specular = m.specular.getHex();
-		if ( m.shininess !== undefined ) data.shininess = m.shininess;
-
-		if ( m.map instanceof THREE.Texture ) data.map = m.map.toJSON( meta ).uuid;
-		if ( m.alphaMap instanceof THREE.Texture ) data.alphaMap = m.alphaMap.toJSON( meta ).uuid;
-		if ( m.lightMap instanceof THREE.Texture ) data.lightMap = m.lightMap.toJSON( meta ).uuid;
-		if ( m.bumpMap instanceof THREE.Texture ) {
-
-			data.bumpMap = m.bumpMap.toJSON( meta ).uuid;
-			data.bumpScale = m.bumpScale;
+// BaseToJSON is the default ToJSON: it writes the fields shared by all materials.
+// Material types replace ToJSON with a function that calls this and adds their own fields.
+func (m *Material) BaseToJSON(meta *core.JSONMeta) (map[string]interface{}) {
 
-		}
-		if ( m.normalMap instanceof THREE.Texture ) {
-
-			data.normalMap = m.normalMap.toJSON( meta ).uuid;
-			data.normalScale = m.normalScale; // Removed for now, causes issue in editor ui.js
-
-		}
-		if ( m.displacementMap instanceof THREE.Texture ) {
-
-			data.displacementMap = m.displacementMap.toJSON( meta ).uuid;
-			data.displacementScale = m.displacementScale;
-			data.displacementBias = m.displacementBias;
-
-		}
-		if ( m.specularMap instanceof THREE.Texture ) data.specularMap = m.specularMap.toJSON( meta ).uuid;
-		if ( m.envMap instanceof THREE.Texture ) {
-
-			data.envMap = m.envMap.toJSON( meta ).uuid;
-			data.reflectivity = m.reflectivity; // Scale behind envMap
-
-		}
+	data := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"version": 4.4,
+			"type": "Material",
+			"generator": "Material.toJSON",
+		},
+	}
 
-		if ( m.size !== undefined ) data.size = m.size;
-		if ( m.sizeAttenuation !== undefined ) data.sizeAttenuation = m.sizeAttenuation;
+	// standard Material serialization
+	data["uuid"] = m.Uuid
+	data["type"] = m.Type
+	if m.Name != "" {
+		data["name"] = m.Name
+	}
 
-		if ( m.vertexColors !== undefined && m.vertexColors !== THREE.NoColors ) data.vertexColors = m.vertexColors;
-		if ( m.shading !== undefined && m.shading !== THREE.SmoothShading ) data.shading = m.shading;
-		if ( m.blending !== undefined && m.blending !== THREE.NormalBlending ) data.blending = m.blending;
-		if ( m.side !== undefined && m.side !== THREE.FrontSide ) data.side = m.side;
+	if m.Blending != three.NormalBlending {
+		data["blending"] = m.Blending
+	}
+	if m.Side != three.FrontSide {
+		data["side"] = m.Side
+	}
 
-		if ( m.opacity < 1 ) data.opacity = m.opacity;
-		if ( m.transparent === true ) data.transparent = m.transparent;
-		if ( m.alphaTest > 0 ) data.alphaTest = m.alphaTest;
-		if ( m.wireframe === true ) data.wireframe = m.wireframe;
-		if ( m.wireframeLinewidth > 1 ) data.wireframeLinewidth = m.wireframeLinewidth;
+	if m.Opacity < 1 {
+		data["opacity"] = m.Opacity
+	}
+	if m.Transparent {
+		data["transparent"] = m.Transparent
+	}
+	if m.AlphaTest > 0 {
+		data["alphaTest"] = m.AlphaTest
+	}
+	if !m.DepthTest {
+		data["depthTest"] = m.DepthTest
+	}
+	if !m.DepthWrite {
+		data["depthWrite"] = m.DepthWrite
+	}
+	if !m.Visible {
+		data["visible"] = m.Visible
+	}
 
-		return data;
+	return data
+}
 
-	},
-*/
 func (m *Material) Clone() (*Material) {
 	return NewMaterial().Copy(m)
 }
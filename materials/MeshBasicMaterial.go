@@ -3,6 +3,7 @@ package materials
 import (
 	math3d "github.com/uzudil/three.go/math"
 	three "github.com/uzudil/three.go"
+	"github.com/uzudil/three.go/core"
 )
 
 /**
@@ -68,6 +69,7 @@ func NewMeshBasicMaterial(parameters map[string]interface{}) (*MeshBasicMaterial
 		NewMaterial(),
 	}
 	m.Type = "MeshBasicMaterial"
+	m.Self = m
 	m.Color = math3d.NewColor(1.0, 1.0, 1.0) // emissive
 	m.Map = nil
 	m.AoMap = nil
@@ -87,6 +89,7 @@ func NewMeshBasicMaterial(parameters map[string]interface{}) (*MeshBasicMaterial
 	m.VertexColors = three.NoColors
 	m.Skinning = false
 	m.MorphTargets = false
+	m.Material.ToJSON = m.toJSON
 
 	m.SetValues( parameters )
 
@@ -116,3 +119,24 @@ func (m *MeshBasicMaterial) Copy(source *MeshBasicMaterial) (*MeshBasicMaterial)
 	m.MorphTargets = source.MorphTargets
 	return m
 }
+
+func (m *MeshBasicMaterial) toJSON(meta *core.JSONMeta) (map[string]interface{}) {
+	data := m.Material.BaseToJSON(meta)
+
+	data["color"] = m.Color.GetHex()
+
+	if m.VertexColors != three.NoColors {
+		data["vertexColors"] = m.VertexColors
+	}
+	if m.Shading != three.SmoothShading {
+		data["shading"] = m.Shading
+	}
+	if m.Wireframe {
+		data["wireframe"] = m.Wireframe
+	}
+	if m.WireframeLinewidth > 1 {
+		data["wireframeLinewidth"] = m.WireframeLinewidth
+	}
+
+	return data
+}
@@ -0,0 +1,64 @@
+package materials
+
+import (
+	"github.com/uzudil/three.go/core"
+)
+
+/**
+ * @author mrdoob / http://mrdoob.com/
+ */
+
+// MultiMaterial gives each face of a mesh the material at its
+// Face3.MaterialIndex. Attach it with Mesh.Material = multi.Material.
+type MultiMaterial struct {
+	*Material
+	Materials []*Material
+}
+
+func NewMultiMaterial(materials []*Material) (*MultiMaterial) {
+	if materials == nil {
+		materials = make([]*Material, 0)
+	}
+	m := &MultiMaterial{
+		Material: NewMaterial(),
+		Materials: materials,
+	}
+	m.Type = "MultiMaterial"
+	m.Self = m
+	m.Material.ToJSON = m.toJSON
+	return m
+}
+
+// GetMaterial returns the material for faces with materialIndex, or nil if
+// there is none.
+func (m *MultiMaterial) GetMaterial(materialIndex int) (*Material) {
+	if materialIndex < 0 || materialIndex >= len(m.Materials) {
+		return nil
+	}
+	return m.Materials[ materialIndex ]
+}
+
+func (m *MultiMaterial) toJSON(meta *core.JSONMeta) (map[string]interface{}) {
+	materials := make([]interface{}, 0, len(m.Materials))
+	for _, material := range m.Materials {
+		materials = append(materials, material.ToJSON( meta ))
+	}
+
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"version": 4.4,
+			"type": "Material",
+			"generator": "MultiMaterial.toJSON",
+		},
+		"uuid": m.Uuid,
+		"type": m.Type,
+		"materials": materials,
+	}
+}
+
+// Clone returns a MultiMaterial that shares the materials of m.
+func (m *MultiMaterial) Clone() (*MultiMaterial) {
+	materials := make([]*Material, len(m.Materials))
+	copy(materials, m.Materials)
+	return NewMultiMaterial( materials )
+}
@@ -0,0 +1,83 @@
+package materials
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+	three "github.com/uzudil/three.go"
+	"github.com/uzudil/three.go/core"
+)
+
+/**
+ * @author mrdoob / http://mrdoob.com/
+ * @author alteredq / http://alteredqualia.com/
+ *
+ * parameters = {
+ *  color: <hex>,
+ *  opacity: <float>,
+ *  map: new THREE.Texture( <Image> ),
+ *
+ *  size: <float>,
+ *  sizeAttenuation: <bool>,
+ *
+ *  blending: THREE.NormalBlending,
+ *  depthTest: <bool>,
+ *  depthWrite: <bool>,
+ *
+ *  vertexColors: <bool>,
+ *
+ *  fog: <bool>
+ * }
+ */
+
+type PointsMaterial struct {
+	*Material
+	Color *math3d.Color
+	Map string
+	Size float64
+	SizeAttenuation bool
+	VertexColors int
+	Fog bool
+}
+
+func NewPointsMaterial(parameters map[string]interface{}) (*PointsMaterial) {
+	m := &PointsMaterial{
+		Material: NewMaterial(),
+	}
+	m.Type = "PointsMaterial"
+	m.Self = m
+	m.Color = math3d.NewColor(1.0, 1.0, 1.0)
+	m.Map = ""
+	m.Size = 1
+	m.SizeAttenuation = true
+	m.VertexColors = three.NoColors
+	m.Fog = true
+	m.Material.ToJSON = m.toJSON
+
+	m.SetValues( parameters )
+
+	return m
+}
+
+func (m *PointsMaterial) Copy(source *PointsMaterial) (*PointsMaterial) {
+	m.Material.Copy(source.Material)
+	m.Color.Copy(source.Color)
+	m.Map = source.Map
+	m.Size = source.Size
+	m.SizeAttenuation = source.SizeAttenuation
+	m.VertexColors = source.VertexColors
+	m.Fog = source.Fog
+	return m
+}
+
+func (m *PointsMaterial) toJSON(meta *core.JSONMeta) (map[string]interface{}) {
+	data := m.Material.BaseToJSON(meta)
+
+	data["color"] = m.Color.GetHex()
+	data["size"] = m.Size
+	data["sizeAttenuation"] = m.SizeAttenuation
+
+	if m.VertexColors != three.NoColors {
+		data["vertexColors"] = m.VertexColors
+	}
+
+	return data
+}
@@ -0,0 +1,79 @@
+package materials
+
+import (
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/core"
+)
+
+/**
+ * @author alteredq / http://alteredqualia.com/
+ *
+ * parameters = {
+ *  color: <hex>,
+ *  opacity: <float>,
+ *  map: new THREE.Texture( <Image> ),
+ *
+ *  blending: THREE.NormalBlending,
+ *  depthTest: <bool>,
+ *  depthWrite: <bool>,
+ *
+ *  uvOffset: new THREE.Vector2(),
+ *  uvScale: new THREE.Vector2(),
+ *
+ *  sizeAttenuation: <bool>,
+ *
+ *  fog: <bool>
+ * }
+ */
+
+type SpriteMaterial struct {
+	*Material
+	Color *math3d.Color
+	Map string
+	Rotation float64 // in radians, around the view direction
+	SizeAttenuation bool // false keeps the sprite the same size on screen at any distance
+	Fog bool
+}
+
+func NewSpriteMaterial(parameters map[string]interface{}) (*SpriteMaterial) {
+	m := &SpriteMaterial{
+		Material: NewMaterial(),
+	}
+	m.Type = "SpriteMaterial"
+	m.Self = m
+	m.Color = math3d.NewColor(1.0, 1.0, 1.0)
+	m.Map = ""
+	m.Rotation = 0
+	m.SizeAttenuation = true
+	m.Fog = false
+	m.Material.ToJSON = m.toJSON
+
+	m.SetValues( parameters )
+
+	return m
+}
+
+func (m *SpriteMaterial) Copy(source *SpriteMaterial) (*SpriteMaterial) {
+	m.Material.Copy(source.Material)
+	m.Color.Copy(source.Color)
+	m.Map = source.Map
+	m.Rotation = source.Rotation
+	m.SizeAttenuation = source.SizeAttenuation
+	m.Fog = source.Fog
+	return m
+}
+
+func (m *SpriteMaterial) toJSON(meta *core.JSONMeta) (map[string]interface{}) {
+	data := m.Material.BaseToJSON(meta)
+
+	data["color"] = m.Color.GetHex()
+
+	if m.Rotation != 0 {
+		data["rotation"] = m.Rotation
+	}
+	if !m.SizeAttenuation {
+		data["sizeAttenuation"] = m.SizeAttenuation
+	}
+
+	return data
+}
@@ -1,5 +1,8 @@
 package math
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 type Matrix4 struct {
 	Elements [16]float64
@@ -254,11 +257,11 @@ func (m *Matrix4) Equals(matrix *Matrix4) bool {
 }
 
 func (m *Matrix4) FromArray(array []float64) (*Matrix4) {
-	m.Elements = append(m.Elements, array[:]...)
+	copy(m.Elements[:], array)
 	return m
 }
 
-func (m *Matrix4) ToArray() {
+func (m *Matrix4) ToArray() ([]float64) {
 	var te = m.Elements
 	return []float64{
 		te[ 0 ], te[ 1 ], te[ 2 ], te[ 3 ],
@@ -492,6 +495,55 @@ func (m *Matrix4) Determinant() float64 {
 	return p1 + p2 + p3 + p4
 }
 
+func (m *Matrix4) GetInverse(matrix *Matrix4, panicOnInvertible bool) (*Matrix4) {
+
+	// based on http://www.euclideanspace.com/maths/algebra/matrix/functions/inverse/fourD/index.htm
+	te := &m.Elements
+	me := matrix.Elements
+
+	n11 := me[ 0 ]; n12 := me[ 4 ]; n13 := me[ 8 ]; n14 := me[ 12 ]
+	n21 := me[ 1 ]; n22 := me[ 5 ]; n23 := me[ 9 ]; n24 := me[ 13 ]
+	n31 := me[ 2 ]; n32 := me[ 6 ]; n33 := me[ 10 ]; n34 := me[ 14 ]
+	n41 := me[ 3 ]; n42 := me[ 7 ]; n43 := me[ 11 ]; n44 := me[ 15 ]
+
+	te[ 0 ] = n23 * n34 * n42 - n24 * n33 * n42 + n24 * n32 * n43 - n22 * n34 * n43 - n23 * n32 * n44 + n22 * n33 * n44
+	te[ 4 ] = n14 * n33 * n42 - n13 * n34 * n42 - n14 * n32 * n43 + n12 * n34 * n43 + n13 * n32 * n44 - n12 * n33 * n44
+	te[ 8 ] = n13 * n24 * n42 - n14 * n23 * n42 + n14 * n22 * n43 - n12 * n24 * n43 - n13 * n22 * n44 + n12 * n23 * n44
+	te[ 12 ] = n14 * n23 * n32 - n13 * n24 * n32 - n14 * n22 * n33 + n12 * n24 * n33 + n13 * n22 * n34 - n12 * n23 * n34
+	te[ 1 ] = n24 * n33 * n41 - n23 * n34 * n41 - n24 * n31 * n43 + n21 * n34 * n43 + n23 * n31 * n44 - n21 * n33 * n44
+	te[ 5 ] = n13 * n34 * n41 - n14 * n33 * n41 + n14 * n31 * n43 - n11 * n34 * n43 - n13 * n31 * n44 + n11 * n33 * n44
+	te[ 9 ] = n14 * n23 * n41 - n13 * n24 * n41 - n14 * n21 * n43 + n11 * n24 * n43 + n13 * n21 * n44 - n11 * n23 * n44
+	te[ 13 ] = n13 * n24 * n31 - n14 * n23 * n31 + n14 * n21 * n33 - n11 * n24 * n33 - n13 * n21 * n34 + n11 * n23 * n34
+	te[ 2 ] = n22 * n34 * n41 - n24 * n32 * n41 + n24 * n31 * n42 - n21 * n34 * n42 - n22 * n31 * n44 + n21 * n32 * n44
+	te[ 6 ] = n14 * n32 * n41 - n12 * n34 * n41 - n14 * n31 * n42 + n11 * n34 * n42 + n12 * n31 * n44 - n11 * n32 * n44
+	te[ 10 ] = n12 * n24 * n41 - n14 * n22 * n41 + n14 * n21 * n42 - n11 * n24 * n42 - n12 * n21 * n44 + n11 * n22 * n44
+	te[ 14 ] = n14 * n22 * n31 - n12 * n24 * n31 - n14 * n21 * n32 + n11 * n24 * n32 + n12 * n21 * n34 - n11 * n22 * n34
+	te[ 3 ] = n23 * n32 * n41 - n22 * n33 * n41 - n23 * n31 * n42 + n21 * n33 * n42 + n22 * n31 * n43 - n21 * n32 * n43
+	te[ 7 ] = n12 * n33 * n41 - n13 * n32 * n41 + n13 * n31 * n42 - n11 * n33 * n42 - n12 * n31 * n43 + n11 * n32 * n43
+	te[ 11 ] = n13 * n22 * n41 - n12 * n23 * n41 - n13 * n21 * n42 + n11 * n23 * n42 + n12 * n21 * n43 - n11 * n22 * n43
+	te[ 15 ] = n12 * n23 * n31 - n13 * n22 * n31 + n13 * n21 * n32 - n11 * n23 * n32 - n12 * n21 * n33 + n11 * n22 * n33
+
+	det := n11 * te[ 0 ] + n21 * te[ 4 ] + n31 * te[ 8 ] + n41 * te[ 12 ]
+
+	// no inverse
+	if det == 0 {
+		msg := "THREE.Matrix4.getInverse(): can't invert matrix, determinant is 0"
+		if panicOnInvertible {
+			panic( msg )
+		} else {
+			fmt.Println( msg )
+		}
+		m.Identity()
+		return m
+	}
+
+	for i := range te {
+		te[ i ] *= 1 / det
+	}
+
+	return m
+}
+
 func (m *Matrix4) MultiplyMatrices( a, b *Matrix4) (*Matrix4) {
 
 	ae := a.Elements
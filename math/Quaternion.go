@@ -340,7 +340,7 @@ func (q *Quaternion) MultiplyQuaternions( a, b *Quaternion) (*Quaternion) {
 	return q
 }
 
-func (q *Quaternion) Slerp(qb *Quaternion, t int) (*Quaternion) {
+func (q *Quaternion) Slerp(qb *Quaternion, t float64) (*Quaternion) {
 
 	if t == 0 {
 		return q
@@ -440,6 +440,67 @@ func (q *Quaternion) OnChange(callback func()) (*Quaternion) {
 	return q
 }
 
-func SlerpQuaternions( qa, qb, qm *Quaternion, t int) (*Quaternion) {
+func SlerpQuaternions( qa, qb, qm *Quaternion, t float64) (*Quaternion) {
 	return qm.Copy( qa ).Slerp( qb, t )
 }
+
+// SlerpFlat interpolates between the quaternions stored as x, y, z, w at
+// srcOffset0 in src0 and srcOffset1 in src1, writing the result at dstOffset in dst.
+func SlerpFlat(dst []float64, dstOffset int, src0 []float64, srcOffset0 int, src1 []float64, srcOffset1 int, t float64) {
+
+	// fuzz-free, array-based Quaternion SLERP operation
+
+	x0 := src0[ srcOffset0 + 0 ]
+	y0 := src0[ srcOffset0 + 1 ]
+	z0 := src0[ srcOffset0 + 2 ]
+	w0 := src0[ srcOffset0 + 3 ]
+
+	x1 := src1[ srcOffset1 + 0 ]
+	y1 := src1[ srcOffset1 + 1 ]
+	z1 := src1[ srcOffset1 + 2 ]
+	w1 := src1[ srcOffset1 + 3 ]
+
+	if w0 != w1 || x0 != x1 || y0 != y1 || z0 != z1 {
+
+		s := 1 - t
+		cos := x0 * x1 + y0 * y1 + z0 * z1 + w0 * w1
+		dir := 1.0
+		if cos < 0 {
+			dir = -1
+		}
+		sqrSin := 1 - cos * cos
+
+		// Skip the Slerp for tiny steps to avoid numeric problems:
+		lerp := true
+		if sqrSin > 2.220446049250313e-16 {
+			sin := math.Sqrt( sqrSin )
+			angle := math.Atan2( sin, cos * dir )
+
+			s = math.Sin( s * angle ) / sin
+			t = math.Sin( t * angle ) / sin
+			lerp = false
+		}
+
+		tDir := t * dir
+
+		x0 = x0 * s + x1 * tDir
+		y0 = y0 * s + y1 * tDir
+		z0 = z0 * s + z1 * tDir
+		w0 = w0 * s + w1 * tDir
+
+		// Normalize in case we just did a lerp:
+		if lerp {
+			f := 1 / math.Sqrt( x0 * x0 + y0 * y0 + z0 * z0 + w0 * w0 )
+
+			x0 *= f
+			y0 *= f
+			z0 *= f
+			w0 *= f
+		}
+	}
+
+	dst[ dstOffset ] = x0
+	dst[ dstOffset + 1 ] = y0
+	dst[ dstOffset + 2 ] = z0
+	dst[ dstOffset + 3 ] = w0
+}
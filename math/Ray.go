@@ -0,0 +1,511 @@
+package math
+
+import "math"
+
+type Ray struct {
+	Origin, Direction *Vector3
+
+	Recast func(float64) (*Ray)
+	DistanceSqToPoint func(*Vector3) float64
+	DistanceSqToSegment func(*Vector3, *Vector3, *Vector3, *Vector3) float64
+	IntersectSphere func(*Sphere, *Vector3) (*Vector3)
+	IsIntersectionBox func(*Box3) bool
+	IntersectTriangle func(*Vector3, *Vector3, *Vector3, bool, *Vector3) (*Vector3)
+}
+
+func NewDefaultRay() (*Ray) {
+	return NewRay(NewEmptyVector3(), NewEmptyVector3())
+}
+
+func NewRay(origin, direction *Vector3) (*Ray) {
+	r := &Ray{
+		Origin: origin,
+		Direction: direction,
+	}
+
+	r.Recast = r.buildRecast()
+	r.DistanceSqToPoint = r.buildDistanceSqToPoint()
+	r.DistanceSqToSegment = r.buildDistanceSqToSegment()
+	r.IntersectSphere = r.buildIntersectSphere()
+	r.IsIntersectionBox = r.buildIsIntersectionBox()
+	r.IntersectTriangle = r.buildIntersectTriangle()
+
+	return r
+}
+
+func (r *Ray) Set(origin, direction *Vector3) (*Ray) {
+	r.Origin.Copy( origin )
+	r.Direction.Copy( direction )
+
+	return r
+}
+
+func (r *Ray) Clone() (*Ray) {
+	return NewDefaultRay().Copy(r)
+}
+
+func (r *Ray) Copy(ray *Ray) (*Ray) {
+	r.Origin.Copy( ray.Origin )
+	r.Direction.Copy( ray.Direction )
+
+	return r
+}
+
+func (r *Ray) At(t float64, optionalTarget *Vector3) (*Vector3) {
+	result := optionalTarget
+	if result == nil {
+		result = NewEmptyVector3()
+	}
+	return result.Copy( r.Direction ).MultiplyScalar( t ).Add( r.Origin )
+}
+
+func (r *Ray) LookAt(v *Vector3) (*Ray) {
+	r.Direction.Copy( v ).Sub( *r.Origin ).Normalize()
+
+	return r
+}
+
+func (r *Ray) buildRecast() (func(float64) (*Ray)) {
+
+	v1 := NewEmptyVector3()
+
+	return func(t float64) (*Ray) {
+		r.Origin.Copy( r.At( t, v1 ) )
+
+		return r
+	}
+}
+
+func (r *Ray) ClosestPointToPoint(point, optionalTarget *Vector3) (*Vector3) {
+	result := optionalTarget
+	if result == nil {
+		result = NewEmptyVector3()
+	}
+	result.SubVectors( *point, *r.Origin )
+	directionDistance := result.Dot( r.Direction )
+
+	if directionDistance < 0 {
+		return result.Copy( r.Origin )
+	}
+
+	return result.Copy( r.Direction ).MultiplyScalar( directionDistance ).Add( r.Origin )
+}
+
+func (r *Ray) DistanceToPoint(point *Vector3) float64 {
+	return math.Sqrt( r.DistanceSqToPoint( point ) )
+}
+
+func (r *Ray) buildDistanceSqToPoint() (func(*Vector3) float64) {
+
+	v1 := NewEmptyVector3()
+
+	return func(point *Vector3) float64 {
+
+		directionDistance := v1.SubVectors( *point, *r.Origin ).Dot( r.Direction )
+
+		// point behind the ray
+
+		if directionDistance < 0 {
+			return r.Origin.DistanceToSquared( point )
+		}
+
+		v1.Copy( r.Direction ).MultiplyScalar( directionDistance ).Add( r.Origin )
+
+		return v1.DistanceToSquared( point )
+	}
+}
+
+func (r *Ray) buildDistanceSqToSegment() (func(*Vector3, *Vector3, *Vector3, *Vector3) float64) {
+
+	segCenter := NewEmptyVector3()
+	segDir := NewEmptyVector3()
+	diff := NewEmptyVector3()
+
+	return func(v0, v1, optionalPointOnRay, optionalPointOnSegment *Vector3) float64 {
+
+		// from http://www.geometrictools.com/LibMathematics/Distance/Wm5DistRay3Segment3.cpp
+		// It returns the min distance between the ray and the segment
+		// defined by v0 and v1
+		// It can also set two optional targets :
+		// - The closest point on the ray
+		// - The closest point on the segment
+
+		segCenter.Copy( v0 ).Add( v1 ).MultiplyScalar( 0.5 )
+		segDir.Copy( v1 ).Sub( *v0 ).Normalize()
+		diff.Copy( r.Origin ).Sub( *segCenter )
+
+		segExtent := v0.DistanceTo( v1 ) * 0.5
+		a01 := - r.Direction.Dot( segDir )
+		b0 := diff.Dot( r.Direction )
+		b1 := - diff.Dot( segDir )
+		c := diff.LengthSq()
+		det := math.Abs( 1 - a01 * a01 )
+		var s0, s1, sqrDist, extDet float64
+
+		if det > 0 {
+
+			// The ray and segment are not parallel.
+
+			s0 = a01 * b1 - b0
+			s1 = a01 * b0 - b1
+			extDet = segExtent * det
+
+			if s0 >= 0 {
+
+				if s1 >= - extDet {
+
+					if s1 <= extDet {
+
+						// region 0
+						// Minimum at interior points of ray and segment.
+
+						invDet := 1 / det
+						s0 *= invDet
+						s1 *= invDet
+						sqrDist = s0 * ( s0 + a01 * s1 + 2 * b0 ) + s1 * ( a01 * s0 + s1 + 2 * b1 ) + c
+
+					} else {
+
+						// region 1
+
+						s1 = segExtent
+						s0 = math.Max( 0, - ( a01 * s1 + b0 ) )
+						sqrDist = - s0 * s0 + s1 * ( s1 + 2 * b1 ) + c
+					}
+
+				} else {
+
+					// region 5
+
+					s1 = - segExtent
+					s0 = math.Max( 0, - ( a01 * s1 + b0 ) )
+					sqrDist = - s0 * s0 + s1 * ( s1 + 2 * b1 ) + c
+				}
+
+			} else {
+
+				if s1 <= - extDet {
+
+					// region 4
+
+					s0 = math.Max( 0, - ( - a01 * segExtent + b0 ) )
+					if s0 > 0 {
+						s1 = - segExtent
+					} else {
+						s1 = math.Min( math.Max( - segExtent, - b1 ), segExtent )
+					}
+					sqrDist = - s0 * s0 + s1 * ( s1 + 2 * b1 ) + c
+
+				} else if s1 <= extDet {
+
+					// region 3
+
+					s0 = 0
+					s1 = math.Min( math.Max( - segExtent, - b1 ), segExtent )
+					sqrDist = s1 * ( s1 + 2 * b1 ) + c
+
+				} else {
+
+					// region 2
+
+					s0 = math.Max( 0, - ( a01 * segExtent + b0 ) )
+					if s0 > 0 {
+						s1 = segExtent
+					} else {
+						s1 = math.Min( math.Max( - segExtent, - b1 ), segExtent )
+					}
+					sqrDist = - s0 * s0 + s1 * ( s1 + 2 * b1 ) + c
+				}
+			}
+
+		} else {
+
+			// Ray and segment are parallel.
+
+			if a01 > 0 {
+				s1 = - segExtent
+			} else {
+				s1 = segExtent
+			}
+			s0 = math.Max( 0, - ( a01 * s1 + b0 ) )
+			sqrDist = - s0 * s0 + s1 * ( s1 + 2 * b1 ) + c
+		}
+
+		if optionalPointOnRay != nil {
+			optionalPointOnRay.Copy( r.Direction ).MultiplyScalar( s0 ).Add( r.Origin )
+		}
+
+		if optionalPointOnSegment != nil {
+			optionalPointOnSegment.Copy( segDir ).MultiplyScalar( s1 ).Add( segCenter )
+		}
+
+		return sqrDist
+	}
+}
+
+// IntersectSphere returns the first point where the ray enters sphere, or nil if it misses.
+func (r *Ray) buildIntersectSphere() (func(*Sphere, *Vector3) (*Vector3)) {
+
+	v1 := NewEmptyVector3()
+
+	return func(sphere *Sphere, optionalTarget *Vector3) (*Vector3) {
+
+		v1.SubVectors( *sphere.Center, *r.Origin )
+		tca := v1.Dot( r.Direction )
+		d2 := v1.Dot( v1 ) - tca * tca
+		radius2 := sphere.Radius * sphere.Radius
+
+		if d2 > radius2 {
+			return nil
+		}
+
+		thc := math.Sqrt( radius2 - d2 )
+
+		// t0 = first intersect point - entrance on front of sphere
+		t0 := tca - thc
+
+		// t1 = second intersect point - exit point on back of sphere
+		t1 := tca + thc
+
+		// test to see if both t0 and t1 are behind the ray - if so, return nil
+		if t0 < 0 && t1 < 0 {
+			return nil
+		}
+
+		// test to see if t0 is behind the ray:
+		// if it is, the ray is inside the sphere, so return the second exit point scaled by t1,
+		// in order to always return an intersect point that is in front of the ray.
+		if t0 < 0 {
+			return r.At( t1, optionalTarget )
+		}
+
+		// else t0 is in front of the ray, so return the first collision point scaled by t0
+		return r.At( t0, optionalTarget )
+	}
+}
+
+func (r *Ray) IsIntersectionSphere(sphere *Sphere) bool {
+	return r.DistanceToPoint( sphere.Center ) <= sphere.Radius
+}
+
+// DistanceToPlane returns the distance along the ray to plane. ok is false if
+// the ray never reaches it.
+func (r *Ray) DistanceToPlane(plane *Plane) (distance float64, ok bool) {
+	denominator := plane.Normal.Dot( r.Direction )
+
+	if denominator == 0 {
+
+		// line is coplanar, return origin
+		if plane.DistanceToPoint( r.Origin ) == 0 {
+			return 0, true
+		}
+
+		return 0, false
+	}
+
+	t := - ( r.Origin.Dot( plane.Normal ) + plane.Constant ) / denominator
+
+	// Return if the ray never intersects the plane
+	return t, t >= 0
+}
+
+func (r *Ray) IntersectPlane(plane *Plane, optionalTarget *Vector3) (*Vector3) {
+	t, ok := r.DistanceToPlane( plane )
+
+	if !ok {
+		return nil
+	}
+
+	return r.At( t, optionalTarget )
+}
+
+func (r *Ray) IsIntersectionPlane(plane *Plane) bool {
+
+	// check if the ray lies on the plane first
+
+	distToPoint := plane.DistanceToPoint( r.Origin )
+
+	if distToPoint == 0 {
+		return true
+	}
+
+	denominator := plane.Normal.Dot( r.Direction )
+
+	if denominator * distToPoint < 0 {
+		return true
+	}
+
+	// ray origin is behind the plane (and is pointing behind it)
+
+	return false
+}
+
+// IntersectBox returns the point where the ray enters box, or nil if it misses.
+func (r *Ray) IntersectBox(box *Box3, optionalTarget *Vector3) (*Vector3) {
+
+	// http://www.scratchapixel.com/lessons/3d-basic-lessons/lesson-7-intersecting-simple-shapes/ray-box-intersection/
+
+	var tmin, tmax, tymin, tymax, tzmin, tzmax float64
+
+	invdirx := 1 / r.Direction.X
+	invdiry := 1 / r.Direction.Y
+	invdirz := 1 / r.Direction.Z
+
+	origin := r.Origin
+
+	if invdirx >= 0 {
+		tmin = ( box.Min.X - origin.X ) * invdirx
+		tmax = ( box.Max.X - origin.X ) * invdirx
+	} else {
+		tmin = ( box.Max.X - origin.X ) * invdirx
+		tmax = ( box.Min.X - origin.X ) * invdirx
+	}
+
+	if invdiry >= 0 {
+		tymin = ( box.Min.Y - origin.Y ) * invdiry
+		tymax = ( box.Max.Y - origin.Y ) * invdiry
+	} else {
+		tymin = ( box.Max.Y - origin.Y ) * invdiry
+		tymax = ( box.Min.Y - origin.Y ) * invdiry
+	}
+
+	if tmin > tymax || tymin > tmax {
+		return nil
+	}
+
+	// These lines also handle the case where tmin or tmax is NaN
+	// (result of 0 * Infinity)
+
+	if tymin > tmin || math.IsNaN( tmin ) {
+		tmin = tymin
+	}
+
+	if tymax < tmax || math.IsNaN( tmax ) {
+		tmax = tymax
+	}
+
+	if invdirz >= 0 {
+		tzmin = ( box.Min.Z - origin.Z ) * invdirz
+		tzmax = ( box.Max.Z - origin.Z ) * invdirz
+	} else {
+		tzmin = ( box.Max.Z - origin.Z ) * invdirz
+		tzmax = ( box.Min.Z - origin.Z ) * invdirz
+	}
+
+	if tmin > tzmax || tzmin > tmax {
+		return nil
+	}
+
+	if tzmin > tmin || math.IsNaN( tmin ) {
+		tmin = tzmin
+	}
+
+	if tzmax < tmax || math.IsNaN( tmax ) {
+		tmax = tzmax
+	}
+
+	//return point closest to the ray (positive side)
+
+	if tmax < 0 {
+		return nil
+	}
+
+	if tmin >= 0 {
+		return r.At( tmin, optionalTarget )
+	}
+	return r.At( tmax, optionalTarget )
+}
+
+func (r *Ray) buildIsIntersectionBox() (func(*Box3) bool) {
+
+	v := NewEmptyVector3()
+
+	return func(box *Box3) bool {
+		return r.IntersectBox( box, v ) != nil
+	}
+}
+
+// IntersectTriangle returns the point where the ray crosses the triangle a, b, c,
+// or nil if it misses. With backfaceCulling, triangles facing away from the ray
+// are never hit.
+func (r *Ray) buildIntersectTriangle() (func(*Vector3, *Vector3, *Vector3, bool, *Vector3) (*Vector3)) {
+
+	// Compute the offset origin, edges, and normal.
+	diff := NewEmptyVector3()
+	edge1 := NewEmptyVector3()
+	edge2 := NewEmptyVector3()
+	normal := NewEmptyVector3()
+
+	return func(a, b, c *Vector3, backfaceCulling bool, optionalTarget *Vector3) (*Vector3) {
+
+		// from http://www.geometrictools.com/LibMathematics/Intersection/Wm5IntrRay3Triangle3.cpp
+
+		edge1.SubVectors( *b, *a )
+		edge2.SubVectors( *c, *a )
+		normal.CrossVectors( edge1, edge2 )
+
+		// Solve Q + t*D = b1*E1 + b2*E2 (Q = kDiff, D = ray direction,
+		// E1 = kEdge1, E2 = kEdge2, N = Cross(E1,E2)) by
+		//   |Dot(D,N)|*b1 = sign(Dot(D,N))*Dot(D,Cross(Q,E2))
+		//   |Dot(D,N)|*b2 = sign(Dot(D,N))*Dot(D,Cross(E1,Q))
+		//   |Dot(D,N)|*t = -sign(Dot(D,N))*Dot(Q,N)
+		DdN := r.Direction.Dot( normal )
+		var sign float64
+
+		if DdN > 0 {
+			if backfaceCulling {
+				return nil
+			}
+			sign = 1
+		} else if DdN < 0 {
+			sign = - 1
+			DdN = - DdN
+		} else {
+			return nil
+		}
+
+		diff.SubVectors( *r.Origin, *a )
+		DdQxE2 := sign * r.Direction.Dot( edge2.CrossVectors( diff, edge2 ) )
+
+		// b1 < 0, no intersection
+		if DdQxE2 < 0 {
+			return nil
+		}
+
+		DdE1xQ := sign * r.Direction.Dot( edge1.Cross( diff ) )
+
+		// b2 < 0, no intersection
+		if DdE1xQ < 0 {
+			return nil
+		}
+
+		// b1+b2 > 1, no intersection
+		if DdQxE2 + DdE1xQ > DdN {
+			return nil
+		}
+
+		// Line intersects triangle, check if ray does.
+		QdN := - sign * diff.Dot( normal )
+
+		// t < 0, no intersection
+		if QdN < 0 {
+			return nil
+		}
+
+		// Ray intersects triangle.
+		return r.At( QdN / DdN, optionalTarget )
+	}
+}
+
+func (r *Ray) ApplyMatrix4(matrix4 *Matrix4) (*Ray) {
+	r.Direction.Add( r.Origin ).ApplyMatrix4( matrix4 )
+	r.Origin.ApplyMatrix4( matrix4 )
+	r.Direction.Sub( *r.Origin )
+	r.Direction.Normalize()
+
+	return r
+}
+
+func (r *Ray) Equals(ray *Ray) bool {
+	return ray.Origin.Equals( r.Origin ) && ray.Direction.Equals( r.Direction )
+}
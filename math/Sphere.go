@@ -104,6 +104,40 @@ func (c *Sphere) ApplyMatrix4(matrix *Matrix4) (*Sphere) {
 	return c
 }
 
+// ExpandByPoint grows the sphere as little as possible to contain point.
+func (c *Sphere) ExpandByPoint(point *Vector3) (*Sphere) {
+	var v Vector3
+	v.SubVectors( *point, *c.Center )
+
+	lengthSq := v.LengthSq()
+
+	if lengthSq > ( c.Radius * c.Radius ) {
+		length := math.Sqrt( lengthSq )
+		delta := ( length - c.Radius ) * 0.5
+
+		c.Center.AddScaledVector( v, delta / length )
+		c.Radius += delta
+	}
+
+	return c
+}
+
+// Union grows the sphere to also contain sphere.
+func (c *Sphere) Union(sphere *Sphere) (*Sphere) {
+	if c.Center.Equals( sphere.Center ) {
+		c.Radius = math.Max( c.Radius, sphere.Radius )
+		return c
+	}
+
+	var offset, point Vector3
+	offset.SubVectors( *sphere.Center, *c.Center ).SetLength( sphere.Radius )
+
+	c.ExpandByPoint( point.Copy( sphere.Center ).Add( &offset ) )
+	c.ExpandByPoint( point.Copy( sphere.Center ).Sub( offset ) )
+
+	return c
+}
+
 func (c *Sphere) Translate(offset *Vector3) (*Sphere) {
 	c.Center.Add( offset )
 	return c
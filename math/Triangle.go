@@ -0,0 +1,38 @@
+package math
+
+// TriangleBarycoordFromPoint returns the barycentric coordinates of point
+// relative to the triangle a, b, c.
+// based on: http://www.blackpawn.com/texts/pointinpoly/default.html
+func TriangleBarycoordFromPoint(point, a, b, c, optionalTarget *Vector3) (*Vector3) {
+	result := optionalTarget
+	if result == nil {
+		result = NewEmptyVector3()
+	}
+
+	var v0, v1, v2 Vector3
+
+	v0.SubVectors( *c, *a )
+	v1.SubVectors( *b, *a )
+	v2.SubVectors( *point, *a )
+
+	dot00 := v0.Dot( &v0 )
+	dot01 := v0.Dot( &v1 )
+	dot02 := v0.Dot( &v2 )
+	dot11 := v1.Dot( &v1 )
+	dot12 := v1.Dot( &v2 )
+
+	denom := dot00 * dot11 - dot01 * dot01
+
+	// collinear or singular triangle
+	if denom == 0 {
+		// arbitrary location outside of triangle
+		return result.Set( - 2, - 1, - 1 )
+	}
+
+	invDenom := 1 / denom
+	u := ( dot11 * dot02 - dot01 * dot12 ) * invDenom
+	v := ( dot00 * dot12 - dot01 * dot02 ) * invDenom
+
+	// barycentric coordinates must always sum to 1
+	return result.Set( 1 - u - v, v, u )
+}
@@ -1,5 +1,8 @@
 package math
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 type Vector2 struct {
 	X, Y float64
@@ -70,347 +73,205 @@ func (v *Vector2) Copy(vector *Vector2) (*Vector2) {
 	v.Y = vector.Y
 	return v
 }
-/*
-add: function ( v, w ) {
-
-	if ( w !== undefined ) {
-
-		console.warn( 'THREE.Vector2: .add() now only accepts one argument. Use .addVectors( a, b ) instead.' );
-		return this.addVectors( v, w );
-
-	}
-
-	v.X += v.x;
-	v.Y += v.y;
-
+func (v *Vector2) Add(other *Vector2) (*Vector2) {
+	v.X += other.X
+	v.Y += other.Y
 	return v
+}
 
-},
-
-addScalar: function ( s ) {
-
-	v.X += s;
-	v.Y += s;
-
+func (v *Vector2) AddScalar(s float64) (*Vector2) {
+	v.X += s
+	v.Y += s
 	return v
+}
 
-},
-
-addVectors: function ( a, b ) {
-
-	v.X = a.x + b.x;
-	v.Y = a.y + b.y;
-
+func (v *Vector2) AddVectors(a, b *Vector2) (*Vector2) {
+	v.X = a.X + b.X
+	v.Y = a.Y + b.Y
 	return v
+}
 
-},
-
-addScaledVector: function ( v, s ) {
-
-	v.X += v.x * s;
-	v.Y += v.y * s;
-
+func (v *Vector2) AddScaledVector(other *Vector2, s float64) (*Vector2) {
+	v.X += other.X * s
+	v.Y += other.Y * s
 	return v
+}
 
-},
-
-sub: function ( v, w ) {
-
-	if ( w !== undefined ) {
-
-		console.warn( 'THREE.Vector2: .sub() now only accepts one argument. Use .subVectors( a, b ) instead.' );
-		return this.subVectors( v, w );
-
-	}
-
-	v.X -= v.x;
-	v.Y -= v.y;
-
+func (v *Vector2) Sub(other *Vector2) (*Vector2) {
+	v.X -= other.X
+	v.Y -= other.Y
 	return v
+}
 
-},
-
-subScalar: function ( s ) {
-
-	v.X -= s;
-	v.Y -= s;
-
+func (v *Vector2) SubScalar(s float64) (*Vector2) {
+	v.X -= s
+	v.Y -= s
 	return v
+}
 
-},
-
-subVectors: function ( a, b ) {
-
-	v.X = a.x - b.x;
-	v.Y = a.y - b.y;
-
+func (v *Vector2) SubVectors(a, b *Vector2) (*Vector2) {
+	v.X = a.X - b.X
+	v.Y = a.Y - b.Y
 	return v
+}
 
-},
-
-multiply: function ( v ) {
-
-	v.X *= v.x;
-	v.Y *= v.y;
-
+func (v *Vector2) Multiply(other *Vector2) (*Vector2) {
+	v.X *= other.X
+	v.Y *= other.Y
 	return v
+}
 
-},
-
-multiplyScalar: function ( scalar ) {
-
-	if ( isFinite( scalar ) ) {
-		v.X *= scalar;
-		v.Y *= scalar;
+func (v *Vector2) MultiplyScalar(scalar float64) (*Vector2) {
+	if !math.IsInf( scalar, 0 ) && !math.IsNaN( scalar ) {
+		v.X *= scalar
+		v.Y *= scalar
 	} else {
-		v.X = 0;
-		v.Y = 0;
+		v.X = 0
+		v.Y = 0
 	}
-
 	return v
+}
 
-},
-
-divide: function ( v ) {
-
-	v.X /= v.x;
-	v.Y /= v.y;
-
+func (v *Vector2) Divide(other *Vector2) (*Vector2) {
+	v.X /= other.X
+	v.Y /= other.Y
 	return v
+}
 
-},
-
-divideScalar: function ( scalar ) {
-
-	return this.multiplyScalar( 1 / scalar );
-
-},
-
-min: function ( v ) {
-
-	v.X = Math.min( v.X, v.x );
-	v.Y = Math.min( v.Y, v.y );
+func (v *Vector2) DivideScalar(scalar float64) (*Vector2) {
+	return v.MultiplyScalar( 1 / scalar )
+}
 
+func (v *Vector2) Min(other *Vector2) (*Vector2) {
+	v.X = math.Min( v.X, other.X )
+	v.Y = math.Min( v.Y, other.Y )
 	return v
+}
 
-},
-
-max: function ( v ) {
-
-	v.X = Math.max( v.X, v.x );
-	v.Y = Math.max( v.Y, v.y );
-
+func (v *Vector2) Max(other *Vector2) (*Vector2) {
+	v.X = math.Max( v.X, other.X )
+	v.Y = math.Max( v.Y, other.Y )
 	return v
+}
 
-},
-
-clamp: function ( min, max ) {
-
-	// This function assumes min < max, if this assumption isn't true it will not operate correctly
-
-	v.X = Math.max( min.x, Math.min( max.x, v.X ) );
-	v.Y = Math.max( min.y, Math.min( max.y, v.Y ) );
-
+// Clamp assumes min < max, if this assumption isn't true it will not operate correctly
+func (v *Vector2) Clamp(min, max *Vector2) (*Vector2) {
+	v.X = math.Max( min.X, math.Min( max.X, v.X ) )
+	v.Y = math.Max( min.Y, math.Min( max.Y, v.Y ) )
 	return v
+}
 
-},
-
-clampScalar: function () {
-
-	var min, max;
-
-	return function clampScalar( minVal, maxVal ) {
-
-		if ( min === undefined ) {
-
-			min = new THREE.Vector2();
-			max = new THREE.Vector2();
-
-		}
-
-		min.set( minVal, minVal );
-		max.set( maxVal, maxVal );
-
-		return this.clamp( min, max );
-
-	};
-
-}(),
-
-clampLength: function ( min, max ) {
-
-	var length = this.length();
-
-	this.multiplyScalar( Math.max( min, Math.min( max, length ) ) / length );
-
+func (v *Vector2) ClampScalar(minVal, maxVal float64) (*Vector2) {
+	v.X = math.Max( minVal, math.Min( maxVal, v.X ) )
+	v.Y = math.Max( minVal, math.Min( maxVal, v.Y ) )
 	return v
+}
 
-},
-
-floor: function () {
-
-	v.X = Math.floor( v.X );
-	v.Y = Math.floor( v.Y );
-
+func (v *Vector2) ClampLength(min, max float64) (*Vector2) {
+	length := v.Length()
+	v.MultiplyScalar( math.Max( min, math.Min( max, length ) ) / length )
 	return v
+}
 
-},
-
-ceil: function () {
-
-	v.X = Math.ceil( v.X );
-	v.Y = Math.ceil( v.Y );
-
+func (v *Vector2) Floor() (*Vector2) {
+	v.X = math.Floor( v.X )
+	v.Y = math.Floor( v.Y )
 	return v
+}
 
-},
-
-round: function () {
-
-	v.X = Math.round( v.X );
-	v.Y = Math.round( v.Y );
-
+func (v *Vector2) Ceil() (*Vector2) {
+	v.X = math.Ceil( v.X )
+	v.Y = math.Ceil( v.Y )
 	return v
+}
 
-},
-
-roundToZero: function () {
-
-	v.X = ( v.X < 0 ) ? Math.ceil( v.X ) : Math.floor( v.X );
-	v.Y = ( v.Y < 0 ) ? Math.ceil( v.Y ) : Math.floor( v.Y );
-
+func (v *Vector2) Round() (*Vector2) {
+	v.X = Round( v.X )
+	v.Y = Round( v.Y )
 	return v
+}
 
-},
-
-negate: function () {
-
-	v.X = - v.X;
-	v.Y = - v.Y;
-
+func (v *Vector2) RoundToZero() (*Vector2) {
+	v.X = math.Trunc( v.X )
+	v.Y = math.Trunc( v.Y )
 	return v
+}
 
-},
-
-dot: function ( v ) {
-
-	return v.X * v.x + v.Y * v.y;
-
-},
-
-lengthSq: function () {
-
-	return v.X * v.X + v.Y * v.Y;
-
-},
-
-length: function () {
-
-	return Math.sqrt( v.X * v.X + v.Y * v.Y );
-
-},
-
-lengthManhattan: function() {
-
-	return Math.abs( v.X ) + Math.abs( v.Y );
-
-},
-
-normalize: function () {
-
-	return this.divideScalar( this.length() );
-
-},
-
-distanceTo: function ( v ) {
-
-	return Math.sqrt( this.distanceToSquared( v ) );
-
-},
-
-distanceToSquared: function ( v ) {
-
-	var dx = v.X - v.x, dy = v.Y - v.y;
-	return dx * dx + dy * dy;
-
-},
-
-setLength: function ( length ) {
-
-	return this.multiplyScalar( length / this.length() );
-
-},
-
-lerp: function ( v, alpha ) {
-
-	v.X += ( v.x - v.X ) * alpha;
-	v.Y += ( v.y - v.Y ) * alpha;
-
+func (v *Vector2) Negate() (*Vector2) {
+	v.X = - v.X
+	v.Y = - v.Y
 	return v
+}
 
-},
-
-lerpVectors: function ( v1, v2, alpha ) {
-
-	this.subVectors( v2, v1 ).multiplyScalar( alpha ).add( v1 );
-
-	return v
+func (v *Vector2) Dot(other *Vector2) float64 {
+	return v.X * other.X + v.Y * other.Y
+}
 
-},
+func (v *Vector2) LengthSq() float64 {
+	return v.X * v.X + v.Y * v.Y
+}
 
-equals: function ( v ) {
+func (v *Vector2) Length() float64 {
+	return math.Sqrt( v.X * v.X + v.Y * v.Y )
+}
 
-	return ( ( v.x === v.X ) && ( v.y === v.Y ) );
+func (v *Vector2) LengthManhattan() float64 {
+	return math.Abs( v.X ) + math.Abs( v.Y )
+}
 
-},
+func (v *Vector2) Normalize() (*Vector2) {
+	return v.DivideScalar( v.Length() )
+}
 
-fromArray: function ( array, offset ) {
+func (v *Vector2) DistanceTo(other *Vector2) float64 {
+	return math.Sqrt( v.DistanceToSquared( other ) )
+}
 
-	if ( offset === undefined ) offset = 0;
+func (v *Vector2) DistanceToSquared(other *Vector2) float64 {
+	dx := v.X - other.X
+	dy := v.Y - other.Y
+	return dx * dx + dy * dy
+}
 
-	v.X = array[ offset ];
-	v.Y = array[ offset + 1 ];
+func (v *Vector2) SetLength(length float64) (*Vector2) {
+	return v.MultiplyScalar( length / v.Length() )
+}
 
+func (v *Vector2) Lerp(other *Vector2, alpha float64) (*Vector2) {
+	v.X += ( other.X - v.X ) * alpha
+	v.Y += ( other.Y - v.Y ) * alpha
 	return v
+}
 
-},
-
-toArray: function ( array, offset ) {
-
-	if ( array === undefined ) array = [];
-	if ( offset === undefined ) offset = 0;
-
-	array[ offset ] = v.X;
-	array[ offset + 1 ] = v.Y;
-
-	return array;
-
-},
-
-fromAttribute: function ( attribute, index, offset ) {
-
-	if ( offset === undefined ) offset = 0;
-
-	index = index * attribute.itemSize + offset;
+func (v *Vector2) LerpVectors(v1, v2 *Vector2, alpha float64) (*Vector2) {
+	return v.SubVectors( v2, v1 ).MultiplyScalar( alpha ).Add( v1 )
+}
 
-	v.X = attribute.array[ index ];
-	v.Y = attribute.array[ index + 1 ];
+func (v *Vector2) Equals(other *Vector2) bool {
+	return v.X == other.X && v.Y == other.Y
+}
 
+func (v *Vector2) FromArray(array []float64, offset int) (*Vector2) {
+	v.X = array[ offset ]
+	v.Y = array[ offset + 1 ]
 	return v
+}
 
-},
-
-rotateAround: function ( center, angle ) {
+func (v *Vector2) ToArray(array []float64, offset int) ([]float64) {
+	array[ offset ] = v.X
+	array[ offset + 1 ] = v.Y
+	return array
+}
 
-	var c = Math.cos( angle ), s = Math.sin( angle );
+func (v *Vector2) RotateAround(center *Vector2, angle float64) (*Vector2) {
+	c := math.Cos( angle )
+	s := math.Sin( angle )
 
-	var x = v.X - center.x;
-	var y = v.Y - center.y;
+	x := v.X - center.X
+	y := v.Y - center.Y
 
-	v.X = x * c - y * s + center.x;
-	v.Y = x * s + y * c + center.y;
+	v.X = x * c - y * s + center.X
+	v.Y = x * s + y * c + center.Y
 
 	return v
-
 }
-*/
\ No newline at end of file
@@ -0,0 +1,114 @@
+package math
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * @author supereggbert / http://www.paulbrunt.co.uk/
+ * @author philogb / http://blog.thejit.org/
+ * @author mikael emtinger / http://gomo.se/
+ * @author egraether / http://egraether.com/
+ * @author WestLangley / http://github.com/WestLangley
+ */
+
+type Vector4 struct {
+	X, Y, Z, W float64
+}
+
+func NewEmptyVector4() (*Vector4) {
+	return NewVector4(0.0, 0.0, 0.0, 1.0)
+}
+
+func NewVector4(x, y, z, w float64) (*Vector4) {
+	return &Vector4{x, y, z, w}
+}
+
+func (v *Vector4) Set(x, y, z, w float64) (*Vector4) {
+	v.X = x
+	v.Y = y
+	v.Z = z
+	v.W = w
+
+	return v
+}
+
+func (v *Vector4) SetComponent(index int, value float64) {
+	switch ( index ) {
+		case 0: v.X = value
+		case 1: v.Y = value
+		case 2: v.Z = value
+		case 3: v.W = value
+		default: panic(fmt.Sprintf("index is out of range: %d", index))
+	}
+}
+
+func (v *Vector4) GetComponent(index int) float64 {
+	switch ( index ) {
+		case 0: return v.X
+		case 1: return v.Y
+		case 2: return v.Z
+		case 3: return v.W
+		default: panic(fmt.Sprintf("index is out of range: %d", index))
+	}
+}
+
+func (v *Vector4) Clone() (*Vector4) {
+	return NewVector4(v.X, v.Y, v.Z, v.W)
+}
+
+func (v *Vector4) Copy(other *Vector4) (*Vector4) {
+	v.X = other.X
+	v.Y = other.Y
+	v.Z = other.Z
+	v.W = other.W
+
+	return v
+}
+
+func (v *Vector4) MultiplyScalar(scalar float64) (*Vector4) {
+	v.X *= scalar
+	v.Y *= scalar
+	v.Z *= scalar
+	v.W *= scalar
+
+	return v
+}
+
+func (v *Vector4) Dot(other *Vector4) float64 {
+	return v.X * other.X + v.Y * other.Y + v.Z * other.Z + v.W * other.W
+}
+
+func (v *Vector4) LengthSq() float64 {
+	return v.Dot( v )
+}
+
+func (v *Vector4) Length() float64 {
+	return math.Sqrt( v.LengthSq() )
+}
+
+func (v *Vector4) LengthManhattan() float64 {
+	return math.Abs( v.X ) + math.Abs( v.Y ) + math.Abs( v.Z ) + math.Abs( v.W )
+}
+
+func (v *Vector4) Equals(other *Vector4) bool {
+	return ( ( other.X == v.X ) && ( other.Y == v.Y ) && ( other.Z == v.Z ) && ( other.W == v.W ) )
+}
+
+func (v *Vector4) FromArray(array []float64, offset int) (*Vector4) {
+	v.X = array[ offset ]
+	v.Y = array[ offset + 1 ]
+	v.Z = array[ offset + 2 ]
+	v.W = array[ offset + 3 ]
+
+	return v
+}
+
+func (v *Vector4) ToArray(array []float64, offset int) ([]float64) {
+	array[ offset ] = v.X
+	array[ offset + 1 ] = v.Y
+	array[ offset + 2 ] = v.Z
+	array[ offset + 3 ] = v.W
+
+	return array
+}
@@ -0,0 +1,34 @@
+package objects
+
+import (
+	"github.com/uzudil/three.go/core"
+)
+
+/**
+ * @author mikael emtinger / http://gomo.se/
+ * @author alteredq / http://alteredqualia.com/
+ * @author ikerr / http://verold.com
+ */
+
+// Bone is a joint of a Skeleton. Moving, rotating or scaling a bone carries its
+// child bones, and the vertices they skin, along with it.
+type Bone struct {
+	*core.Object3D
+	Skin *SkinnedMesh
+}
+
+func NewBone(skin *SkinnedMesh) (*Bone) {
+	b := &Bone{
+		Object3D: core.NewObject3D(),
+		Skin: skin,
+	}
+	b.Type = "Bone"
+	b.Self = b
+	return b
+}
+
+func (b *Bone) Copy(source *Bone) (*Bone) {
+	b.Object3D.Copy( source.Object3D, true )
+	b.Skin = source.Skin
+	return b
+}
@@ -0,0 +1,25 @@
+package objects
+
+import (
+	"github.com/uzudil/three.go/core"
+)
+
+// Group is an object that only holds other objects, to move or hide them together.
+type Group struct {
+	*core.Object3D
+}
+
+func NewGroup() (*Group) {
+	g := &Group{
+		Object3D: core.NewObject3D(),
+	}
+	g.Type = "Group"
+	g.Self = g
+	return g
+}
+
+func (g *Group) Clone() (*Group) {
+	group := NewGroup()
+	group.Copy(g.Object3D, true)
+	return group
+}
@@ -0,0 +1,170 @@
+package objects
+
+import (
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// InstancedMesh draws Count copies of its geometry in one call, each placed by
+// its own matrix, relative to the InstancedMesh, and optionally tinted by its
+// own color.
+type InstancedMesh struct {
+	*Mesh
+	Count int
+	InstanceMatrix []*math3d.Matrix4
+	InstanceColor []*math3d.Color // nil until SetColorAt is first called
+
+	// BoundingSphere contains all instances. It is recomputed when needed after
+	// an instance matrix changes.
+	BoundingSphere *math3d.Sphere
+
+	InstanceMatrixNeedsUpdate bool
+	InstanceColorNeedsUpdate bool
+}
+
+func NewInstancedMesh(geometry *core.Geometry, material *materials.Material, count int) (*InstancedMesh) {
+	m := &InstancedMesh{
+		Mesh: NewMesh(geometry, material),
+		Count: count,
+		InstanceMatrix: make([]*math3d.Matrix4, count),
+	}
+	for i := range m.InstanceMatrix {
+		m.InstanceMatrix[ i ] = math3d.NewMatrix4()
+	}
+	m.Type = "InstancedMesh"
+	m.Self = m
+	m.ToJSONObject = m.toJSONObject
+	m.Raycast = m.buildRaycast()
+	return m
+}
+
+// GetMatrixAt copies the matrix of instance index into matrix.
+func (m *InstancedMesh) GetMatrixAt(index int, matrix *math3d.Matrix4) (*math3d.Matrix4) {
+	return matrix.Copy( m.InstanceMatrix[ index ] )
+}
+
+// SetMatrixAt sets the matrix of instance index, relative to the InstancedMesh.
+func (m *InstancedMesh) SetMatrixAt(index int, matrix *math3d.Matrix4) {
+	m.InstanceMatrix[ index ].Copy( matrix )
+	m.InstanceMatrixNeedsUpdate = true
+	m.BoundingSphere = nil
+}
+
+// GetColorAt copies the color of instance index into color. Instances are
+// white until given a color.
+func (m *InstancedMesh) GetColorAt(index int, color *math3d.Color) (*math3d.Color) {
+	if m.InstanceColor == nil {
+		return color.SetRGB( 1, 1, 1 )
+	}
+	return color.Copy( m.InstanceColor[ index ] )
+}
+
+func (m *InstancedMesh) SetColorAt(index int, color *math3d.Color) {
+	if m.InstanceColor == nil {
+		m.InstanceColor = make([]*math3d.Color, m.Count)
+		for i := range m.InstanceColor {
+			m.InstanceColor[ i ] = math3d.NewColor( 1, 1, 1 )
+		}
+	}
+	m.InstanceColor[ index ].Copy( color )
+	m.InstanceColorNeedsUpdate = true
+}
+
+// ComputeBoundingSphere sets BoundingSphere to contain the geometry at every instance.
+func (m *InstancedMesh) ComputeBoundingSphere() {
+	geometry := m.Geometry
+
+	if geometry.BoundingSphere == nil {
+		geometry.ComputeBoundingSphere()
+	}
+
+	if m.BoundingSphere == nil {
+		m.BoundingSphere = math3d.NewDefaultSphere()
+	}
+	m.BoundingSphere.Set( math3d.NewEmptyVector3(), 0 )
+
+	sphere := math3d.NewDefaultSphere()
+
+	for i := 0; i < m.Count; i ++ {
+		sphere.Copy( geometry.BoundingSphere ).ApplyMatrix4( m.InstanceMatrix[ i ] )
+
+		if i == 0 {
+			m.BoundingSphere.Copy( sphere )
+		} else {
+			m.BoundingSphere.Union( sphere )
+		}
+	}
+}
+
+// buildRaycast builds the Raycast of m, which tests every instance like a Mesh.
+// Intersections have InstanceId set to the instance hit.
+func (m *InstancedMesh) buildRaycast() (func(*core.Raycaster, []*core.Intersection) ([]*core.Intersection)) {
+
+	sphere := math3d.NewDefaultSphere()
+
+	// the instance being tested, placed in the world
+	instanceMesh := NewMesh(nil, nil)
+	instanceIntersects := make([]*core.Intersection, 0)
+
+	return func(raycaster *core.Raycaster, intersects []*core.Intersection) ([]*core.Intersection) {
+
+		if m.Material == nil {
+			return intersects
+		}
+
+		// test with bounding sphere first
+
+		if m.BoundingSphere == nil {
+			m.ComputeBoundingSphere()
+		}
+
+		sphere.Copy( m.BoundingSphere )
+		sphere.ApplyMatrix4( m.MatrixWorld )
+
+		if !raycaster.Ray.IsIntersectionSphere( sphere ) {
+			return intersects
+		}
+
+		instanceMesh.Geometry = m.Geometry
+		instanceMesh.Material = m.Material
+		instanceMesh.MorphTargetInfluences = m.MorphTargetInfluences
+
+		for instanceId := 0; instanceId < m.Count; instanceId ++ {
+
+			instanceMesh.MatrixWorld.MultiplyMatrices( m.MatrixWorld, m.InstanceMatrix[ instanceId ] )
+
+			instanceIntersects = instanceMesh.Raycast( raycaster, instanceIntersects[ :0 ] )
+
+			for _, intersect := range instanceIntersects {
+				intersect.InstanceId = instanceId
+				intersect.Object = m.Object3D
+				intersects = append(intersects, intersect)
+			}
+		}
+
+		return intersects
+	}
+}
+
+func (m *InstancedMesh) toJSONObject(meta *core.JSONMeta) (map[string]interface{}) {
+	object := m.Mesh.toJSONObject( meta )
+
+	object["count"] = m.Count
+
+	instanceMatrix := make([]float64, 0, m.Count * 16)
+	for _, matrix := range m.InstanceMatrix {
+		instanceMatrix = append(instanceMatrix, matrix.ToArray()...)
+	}
+	object["instanceMatrix"] = instanceMatrix
+
+	if m.InstanceColor != nil {
+		instanceColor := make([]int, 0, m.Count)
+		for _, color := range m.InstanceColor {
+			instanceColor = append(instanceColor, color.GetHex())
+		}
+		object["instanceColor"] = instanceColor
+	}
+
+	return object
+}
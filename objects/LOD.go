@@ -0,0 +1,184 @@
+package objects
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// LODLevel is an object shown from Distance to the camera onwards, up to
+// the distance of the next level.
+type LODLevel struct {
+	Distance float64
+	Object *core.Object3D
+}
+
+// LOD (level of detail) shows one of several versions of an object depending
+// on its distance to the camera, e.g. a detailed mesh close up and a coarse
+// one far away. The levels are children of the LOD.
+type LOD struct {
+	*core.Object3D
+	Levels []*LODLevel // sorted by distance
+
+	// AutoUpdate makes the renderer call Update with its camera every frame.
+	AutoUpdate bool
+
+	Update func(*core.Object3D)
+}
+
+func NewLOD() (*LOD) {
+	l := &LOD{
+		Object3D: core.NewObject3D(),
+		Levels: make([]*LODLevel, 0),
+		AutoUpdate: true,
+	}
+	l.Type = "LOD"
+	l.Self = l
+	l.Update = l.buildUpdate()
+	l.Raycast = l.buildRaycast()
+	l.ToJSONObject = l.toJSONObject
+	return l
+}
+
+// AddLevel adds object as a child, shown from distance to the camera onwards.
+func (l *LOD) AddLevel(object *core.Object3D, distance float64) {
+
+	distance = math.Abs( distance )
+
+	index := 0
+	for ; index < len(l.Levels); index ++ {
+		if distance < l.Levels[ index ].Distance {
+			break
+		}
+	}
+
+	l.Levels = append(l.Levels, nil)
+	copy(l.Levels[ index + 1: ], l.Levels[ index: ])
+	l.Levels[ index ] = &LODLevel{
+		Distance: distance,
+		Object: object,
+	}
+
+	l.Add( object )
+}
+
+// GetObjectForDistance returns the level shown at distance from the camera,
+// or nil if there are no levels.
+func (l *LOD) GetObjectForDistance(distance float64) (*core.Object3D) {
+
+	if len(l.Levels) == 0 {
+		return nil
+	}
+
+	i := 1
+	for ; i < len(l.Levels); i ++ {
+		if distance < l.Levels[ i ].Distance {
+			break
+		}
+	}
+
+	return l.Levels[ i - 1 ].Object
+}
+
+// buildRaycast builds the Raycast of l, which tests the level shown at the
+// distance of the ray origin.
+func (l *LOD) buildRaycast() (func(*core.Raycaster, []*core.Intersection) ([]*core.Intersection)) {
+	return func(raycaster *core.Raycaster, intersects []*core.Intersection) ([]*core.Intersection) {
+
+		object := l.raycastLevel( raycaster )
+		if object == nil {
+			return intersects
+		}
+
+		return object.Raycast( raycaster, intersects )
+	}
+}
+
+// raycastLevel returns the level shown at the distance of the ray origin.
+func (l *LOD) raycastLevel(raycaster *core.Raycaster) (*core.Object3D) {
+
+	position := math3d.NewEmptyVector3().SetFromMatrixPosition( l.MatrixWorld )
+
+	return l.GetObjectForDistance( raycaster.Ray.Origin.DistanceTo( position ) )
+}
+
+// RaycastChildren returns the children a recursive raycast visits after
+// Raycast: the children of the level Raycast tested, and the children of l
+// that are not levels. The other levels are never tested, whatever their
+// Visible flags.
+func (l *LOD) RaycastChildren(raycaster *core.Raycaster) ([]*core.Object3D) {
+
+	children := make([]*core.Object3D, 0)
+
+	if object := l.raycastLevel( raycaster ); object != nil {
+		children = append(children, object.Children...)
+	}
+
+	for _, child := range l.Children {
+		isLevel := false
+		for _, level := range l.Levels {
+			if level.Object == child {
+				isLevel = true
+				break
+			}
+		}
+		if !isLevel {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+// buildUpdate builds the Update of l, which makes only the level for the
+// distance to camera visible.
+func (l *LOD) buildUpdate() (func(*core.Object3D)) {
+
+	v1 := math3d.NewEmptyVector3()
+	v2 := math3d.NewEmptyVector3()
+
+	return func(camera *core.Object3D) {
+
+		levels := l.Levels
+
+		if len(levels) > 1 {
+
+			v1.SetFromMatrixPosition( camera.MatrixWorld )
+			v2.SetFromMatrixPosition( l.MatrixWorld )
+
+			distance := v1.DistanceTo( v2 )
+
+			levels[ 0 ].Object.Visible = true
+
+			i := 1
+			for ; i < len(levels); i ++ {
+				if distance >= levels[ i ].Distance {
+					levels[ i - 1 ].Object.Visible = false
+					levels[ i ].Object.Visible = true
+				} else {
+					break
+				}
+			}
+
+			for ; i < len(levels); i ++ {
+				levels[ i ].Object.Visible = false
+			}
+		}
+	}
+}
+
+func (l *LOD) toJSONObject(meta *core.JSONMeta) (map[string]interface{}) {
+	object := l.Object3D.BaseToJSONObject( meta )
+
+	levels := make([]interface{}, 0, len(l.Levels))
+	for _, level := range l.Levels {
+		levels = append(levels, map[string]interface{}{
+			"object": level.Object.Uuid,
+			"distance": level.Distance,
+		})
+	}
+	object["levels"] = levels
+
+	return object
+}
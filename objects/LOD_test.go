@@ -0,0 +1,61 @@
+package objects
+
+import (
+	"testing"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// triangleMesh returns a mesh of one triangle in the z = 0 plane, facing +z.
+func triangleMesh() (*Mesh) {
+	geometry := core.NewGeometry()
+	geometry.Vertices = append(geometry.Vertices,
+		math3d.NewVector3( 0, 0, 0 ),
+		math3d.NewVector3( 1, 0, 0 ),
+		math3d.NewVector3( 0, 1, 0 ),
+	)
+	geometry.Faces = append(geometry.Faces, core.NewDefaultFace3( 0, 1, 2 ))
+	geometry.ComputeFaceNormals()
+	return NewMesh( geometry, materials.NewMeshBasicMaterial(nil).Material )
+}
+
+func TestLODRecursiveRaycast(t *testing.T) {
+	near := triangleMesh()
+	middle := triangleMesh()
+
+	// a level whose meshes are its children
+	far := core.NewObject3D()
+	farMesh := triangleMesh()
+	far.Add( farMesh.Object3D )
+
+	lod := NewLOD()
+	lod.AddLevel( near.Object3D, 0 )
+	lod.AddLevel( middle.Object3D, 50 )
+	lod.AddLevel( far, 100 )
+	lod.UpdateMatrixWorld( true )
+
+	// all levels are still visible, as Update has not run
+
+	cases := []struct {
+		z float64
+		want *core.Object3D
+	}{
+		{ 10, near.Object3D },
+		{ 60, middle.Object3D },
+		{ 150, farMesh.Object3D },
+	}
+	for _, c := range cases {
+		raycaster := core.NewRaycaster( math3d.NewVector3( 0.2, 0.2, c.z ), math3d.NewVector3( 0, 0, -1 ), 0, 1000 )
+
+		intersects := raycaster.IntersectObject( lod.Object3D, true )
+		if len(intersects) != 1 {
+			t.Errorf("from %v: got %d hits, want 1", c.z, len(intersects))
+			continue
+		}
+		if intersects[ 0 ].Object != c.want {
+			t.Errorf("from %v: hit the wrong level", c.z)
+		}
+	}
+}
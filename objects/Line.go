@@ -0,0 +1,55 @@
+package objects
+
+import (
+	"math/rand"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+)
+
+// Line draws a polyline through the vertices of its geometry.
+type Line struct {
+	*core.Object3D
+	Material *materials.Material
+}
+
+func NewDefaultLine() (*Line) {
+	return NewLine(core.NewGeometry(), materials.NewLineBasicMaterial(map[string]interface{}{ "color": rand.Intn(0xffffff) }).Material)
+}
+
+func NewLine(geometry *core.Geometry, material *materials.Material) (*Line) {
+	o := core.NewObject3D()
+	o.Geometry = geometry
+	l := &Line{
+		Object3D: o,
+		Material: material,
+	}
+	l.Type = "Line"
+	l.Self = l
+	l.ToJSONObject = l.toJSONObject
+	return l
+}
+
+// ComputeLineDistances computes the distances along the line needed by LineDashedMaterial.
+func (l *Line) ComputeLineDistances() {
+	l.Geometry.ComputeLineDistances()
+}
+
+func (l *Line) toJSONObject(meta *core.JSONMeta) (map[string]interface{}) {
+	object := l.Object3D.BaseToJSONObject( meta )
+
+	if l.Material != nil {
+		if _, ok := meta.Materials[ l.Material.Uuid ]; !ok {
+			meta.Materials[ l.Material.Uuid ] = l.Material.ToJSON( meta )
+		}
+		object["material"] = l.Material.Uuid
+	}
+
+	return object
+}
+
+func (l *Line) Clone() (*Line) {
+	line := NewLine(l.Geometry, l.Material)
+	line.Copy(l.Object3D, true)
+	return line
+}
@@ -0,0 +1,26 @@
+package objects
+
+import (
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+)
+
+// LineLoop is a Line that also connects its last vertex to the first one.
+type LineLoop struct {
+	*Line
+}
+
+func NewLineLoop(geometry *core.Geometry, material *materials.Material) (*LineLoop) {
+	l := &LineLoop{
+		Line: NewLine(geometry, material),
+	}
+	l.Type = "LineLoop"
+	l.Self = l
+	return l
+}
+
+func (l *LineLoop) Clone() (*LineLoop) {
+	line := NewLineLoop(l.Geometry, l.Material)
+	line.Copy(l.Object3D, true)
+	return line
+}
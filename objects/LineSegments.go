@@ -0,0 +1,44 @@
+package objects
+
+import (
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+)
+
+// LineSegments draws a separate line between each pair of vertices of its
+// geometry, e.g. of an EdgesGeometry.
+type LineSegments struct {
+	*Line
+}
+
+func NewLineSegments(geometry *core.Geometry, material *materials.Material) (*LineSegments) {
+	l := &LineSegments{
+		Line: NewLine(geometry, material),
+	}
+	l.Type = "LineSegments"
+	l.Self = l
+	return l
+}
+
+// ComputeLineDistances computes the distances along the segments needed by
+// LineDashedMaterial. The distance carries on from one segment to the next.
+func (l *LineSegments) ComputeLineDistances() {
+	vertices := l.Geometry.Vertices
+	lineDistances := make([]float64, len(vertices))
+
+	for i := 0; i + 1 < len(vertices); i += 2 {
+		if i > 0 {
+			lineDistances[ i ] = lineDistances[ i - 1 ]
+		}
+		lineDistances[ i + 1 ] = lineDistances[ i ] + vertices[ i ].DistanceTo( vertices[ i + 1 ] )
+	}
+
+	l.Geometry.LineDistances = lineDistances
+	l.Geometry.LineDistancesNeedUpdate = true
+}
+
+func (l *LineSegments) Clone() (*LineSegments) {
+	line := NewLineSegments(l.Geometry, l.Material)
+	line.Copy(l.Object3D, true)
+	return line
+}
@@ -1,13 +1,21 @@
 package objects
 import (
+	"fmt"
+	three "github.com/uzudil/three.go"
 	"github.com/uzudil/three.go/core"
 	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
 	"math/rand"
 )
 
 type Mesh struct {
 	*core.Object3D
 	Material *materials.Material
+
+	// MorphTargetInfluences holds the weight of each of Geometry.MorphTargets,
+	// usually between 0 and 1. MorphTargetDictionary maps target names to indices.
+	MorphTargetInfluences []float64
+	MorphTargetDictionary map[string]int
 }
 
 func NewDefaultMesh() (*Mesh) {
@@ -18,316 +26,233 @@ func NewMesh(geometry *core.Geometry, material *materials.Material) (*Mesh) {
 	o := core.NewObject3D()
 	o.Geometry = geometry
 	m := &Mesh{
-		o,
+		Object3D: o,
 		Material: material,
 	}
-	// m.UpdateMorphTargets()
+	m.Type = "Mesh"
+	m.Self = m
+	m.UpdateMorphTargets()
+	m.ToJSONObject = m.toJSONObject
+	m.Raycast = m.buildRaycast()
 	return m
 }
 
-/*
-THREE.Mesh.prototype.updateMorphTargets = function () {
-
-	if ( this.geometry.morphTargets !== undefined && this.geometry.morphTargets.length > 0 ) {
-
-		this.morphTargetBase = - 1;
-		this.morphTargetInfluences = [];
-		this.morphTargetDictionary = {};
-
-		for ( var m = 0, ml = this.geometry.morphTargets.length; m < ml; m ++ ) {
-
-			this.morphTargetInfluences.push( 0 );
-			this.morphTargetDictionary[ this.geometry.morphTargets[ m ].name ] = m;
+// UpdateMorphTargets resets MorphTargetInfluences to zero and rebuilds
+// MorphTargetDictionary from the morph targets of the geometry.
+func (m *Mesh) UpdateMorphTargets() {
+	if m.Geometry != nil && len(m.Geometry.MorphTargets) > 0 {
+		m.MorphTargetInfluences = make([]float64, len(m.Geometry.MorphTargets))
+		m.MorphTargetDictionary = make(map[string]int)
 
+		for i, morphTarget := range m.Geometry.MorphTargets {
+			m.MorphTargetDictionary[ morphTarget.Name ] = i
 		}
-
 	}
+}
 
-};
-
-THREE.Mesh.prototype.getMorphTargetIndexByName = function ( name ) {
-
-	if ( this.morphTargetDictionary[ name ] !== undefined ) {
-
-		return this.morphTargetDictionary[ name ];
-
+func (m *Mesh) GetMorphTargetIndexByName(name string) int {
+	if index, ok := m.MorphTargetDictionary[ name ]; ok {
+		return index
 	}
 
-	console.warn( 'THREE.Mesh.getMorphTargetIndexByName: morph target ' + name + ' does not exist. Returning 0.' );
-
-	return 0;
+	fmt.Println("THREE.Mesh.getMorphTargetIndexByName: morph target " + name + " does not exist. Returning 0.")
 
-};
-
-THREE.Mesh.prototype.raycast = ( function () {
+	return 0
+}
 
-	var inverseMatrix = new THREE.Matrix4();
-	var ray = new THREE.Ray();
-	var sphere = new THREE.Sphere();
+func (m *Mesh) buildRaycast() (func(*core.Raycaster, []*core.Intersection) ([]*core.Intersection)) {
 
-	var vA = new THREE.Vector3();
-	var vB = new THREE.Vector3();
-	var vC = new THREE.Vector3();
+	inverseMatrix := math3d.NewMatrix4()
+	ray := math3d.NewDefaultRay()
+	sphere := math3d.NewDefaultSphere()
 
-	var tempA = new THREE.Vector3();
-	var tempB = new THREE.Vector3();
-	var tempC = new THREE.Vector3();
+	uvA := math3d.NewEmptyVector2()
+	uvB := math3d.NewEmptyVector2()
+	uvC := math3d.NewEmptyVector2()
 
-	var uvA = new THREE.Vector2();
-	var uvB = new THREE.Vector2();
-	var uvC = new THREE.Vector2();
+	barycoord := math3d.NewEmptyVector3()
 
-	var barycoord = new THREE.Vector3();
+	vA := math3d.NewEmptyVector3()
+	vB := math3d.NewEmptyVector3()
+	vC := math3d.NewEmptyVector3()
 
-	var intersectionPoint = new THREE.Vector3();
-	var intersectionPointWorld = new THREE.Vector3();
+	tempA := math3d.NewEmptyVector3()
+	tempB := math3d.NewEmptyVector3()
+	tempC := math3d.NewEmptyVector3()
 
-	function uvIntersection( point, p1, p2, p3, uv1, uv2, uv3 ) {
+	intersectionPoint := math3d.NewEmptyVector3()
+	intersectionPointWorld := math3d.NewEmptyVector3()
 
-		THREE.Triangle.barycoordFromPoint( point, p1, p2, p3, barycoord );
+	uvIntersection := func(point, p1, p2, p3 *math3d.Vector3, uv1, uv2, uv3 *math3d.Vector2) (*math3d.Vector2) {
 
-		uv1.multiplyScalar( barycoord.x );
-		uv2.multiplyScalar( barycoord.y );
-		uv3.multiplyScalar( barycoord.z );
+		math3d.TriangleBarycoordFromPoint( point, p1, p2, p3, barycoord )
 
-		uv1.add( uv2 ).add( uv3 );
+		uv1.MultiplyScalar( barycoord.X )
+		uv2.MultiplyScalar( barycoord.Y )
+		uv3.MultiplyScalar( barycoord.Z )
 
-		return uv1.clone();
+		uv1.Add( uv2 ).Add( uv3 )
 
+		return uv1.Clone()
 	}
 
-	function checkIntersection( object, raycaster, ray, pA, pB, pC, point ){
-
-		var intersect;
-		var material = object.material;
+	checkIntersection := func(raycaster *core.Raycaster, material *materials.Material, pA, pB, pC, point *math3d.Vector3) (*core.Intersection) {
 
-		if ( material.side === THREE.BackSide ) {
-
-			intersect = ray.intersectTriangle( pC, pB, pA, true, point );
+		var intersect *math3d.Vector3
 
+		if material.Side == three.BackSide {
+			intersect = ray.IntersectTriangle( pC, pB, pA, true, point )
 		} else {
-
-			intersect = ray.intersectTriangle( pA, pB, pC, material.side !== THREE.DoubleSide, point );
-
+			intersect = ray.IntersectTriangle( pA, pB, pC, material.Side != three.DoubleSide, point )
 		}
 
-		if ( intersect === null ) return null;
-
-		intersectionPointWorld.copy( point );
-		intersectionPointWorld.applyMatrix4( object.matrixWorld );
-
-		var distance = raycaster.ray.origin.distanceTo( intersectionPointWorld );
-
-		if ( distance < raycaster.near || distance > raycaster.far ) return null;
-
-		return {
-			distance: distance,
-			point: intersectionPointWorld.clone(),
-			object: object
-		};
-
-	}
-
-	function checkBufferGeometryIntersection( object, raycaster, ray, positions, uvs, a, b, c ) {
-
-		vA.fromArray( positions, a * 3 );
-		vB.fromArray( positions, b * 3 );
-		vC.fromArray( positions, c * 3 );
-
-		var intersection = checkIntersection( object, raycaster, ray, vA, vB, vC, intersectionPoint );
-
-		if ( intersection ) {
-
-			if ( uvs ) {
-
-				uvA.fromArray( uvs, a * 2 );
-				uvB.fromArray( uvs, b * 2 );
-				uvC.fromArray( uvs, c * 2 );
+		if intersect == nil {
+			return nil
+		}
 
-				intersection.uv = uvIntersection( intersectionPoint,  vA, vB, vC,  uvA, uvB, uvC );
+		intersectionPointWorld.Copy( point )
+		intersectionPointWorld.ApplyMatrix4( m.MatrixWorld )
 
-			}
-
-			intersection.face = new THREE.Face3( a, b, c, THREE.Triangle.normal( vA, vB, vC ) );
-			intersection.faceIndex = a;
+		distance := raycaster.Ray.Origin.DistanceTo( intersectionPointWorld )
 
+		if distance < raycaster.Near || distance > raycaster.Far {
+			return nil
 		}
 
-		return intersection;
-
+		return &core.Intersection{
+			Distance: distance,
+			Point: intersectionPointWorld.Clone(),
+			Object: m.Object3D,
+		}
 	}
 
-	return function raycast( raycaster, intersects ) {
+	return func(raycaster *core.Raycaster, intersects []*core.Intersection) ([]*core.Intersection) {
 
-		var geometry = this.geometry;
-		var material = this.material;
+		geometry := m.Geometry
+		material := m.Material
 
-		if ( material === undefined ) return;
+		if material == nil {
+			return intersects
+		}
 
 		// Checking boundingSphere distance to ray
 
-		if ( geometry.boundingSphere === null ) geometry.computeBoundingSphere();
-
-		var matrixWorld = this.matrixWorld;
+		if geometry.BoundingSphere == nil {
+			geometry.ComputeBoundingSphere()
+		}
 
-		sphere.copy( geometry.boundingSphere );
-		sphere.applyMatrix4( matrixWorld );
+		sphere.Copy( geometry.BoundingSphere )
+		sphere.ApplyMatrix4( m.MatrixWorld )
 
-		if ( raycaster.ray.isIntersectionSphere( sphere ) === false ) return;
+		if !raycaster.Ray.IsIntersectionSphere( sphere ) {
+			return intersects
+		}
 
 		// Check boundingBox before continuing
 
-		inverseMatrix.getInverse( matrixWorld );
-		ray.copy( raycaster.ray ).applyMatrix4( inverseMatrix );
-
-		if ( geometry.boundingBox !== null ) {
-
-			if ( ray.isIntersectionBox( geometry.boundingBox ) === false ) return;
+		inverseMatrix.GetInverse( m.MatrixWorld, false )
+		ray.Copy( raycaster.Ray ).ApplyMatrix4( inverseMatrix )
 
+		if geometry.BoundingBox != nil {
+			if !ray.IsIntersectionBox( geometry.BoundingBox ) {
+				return intersects
+			}
 		}
 
-		var uvs, intersection;
-
-		if ( geometry instanceof THREE.BufferGeometry ) {
+		multiMaterial, isMultiMaterial := material.Self.(*materials.MultiMaterial)
 
-			var a, b, c;
-			var index = geometry.index;
-			var attributes = geometry.attributes;
-			var positions = attributes.position.array;
-
-			if ( attributes.uv !== undefined ){
+		vertices := geometry.Vertices
+		var uvs [][]*math3d.Vector2
+		if len(geometry.FaceVertexUvs) > 0 {
+			uvs = geometry.FaceVertexUvs[ 0 ]
+		}
 
-				uvs = attributes.uv.array;
+		for f, face := range geometry.Faces {
 
+			faceMaterial := material
+			if isMultiMaterial {
+				faceMaterial = multiMaterial.GetMaterial( face.MaterialIndex )
+				if faceMaterial == nil {
+					continue
+				}
 			}
 
-			if ( index !== null ) {
-
-				var indices = index.array;
-
-				for ( var i = 0, l = indices.length; i < l; i += 3 ) {
+			fvA := vertices[ face.A ]
+			fvB := vertices[ face.B ]
+			fvC := vertices[ face.C ]
 
-					a = indices[ i ];
-					b = indices[ i + 1 ];
-					c = indices[ i + 2 ];
+			if basicMaterial, ok := faceMaterial.Self.(*materials.MeshBasicMaterial); ok && basicMaterial.MorphTargets {
 
-					intersection = checkBufferGeometryIntersection( this, raycaster, ray, positions, uvs, a, b, c );
+				vA.Set( 0, 0, 0 )
+				vB.Set( 0, 0, 0 )
+				vC.Set( 0, 0, 0 )
 
-					if ( intersection ) {
-
-						intersection.faceIndex = Math.floor( i / 3 ); // triangle number in indices buffer semantics
-						intersects.push( intersection );
+				for t, morphTarget := range geometry.MorphTargets {
 
+					if t >= len(m.MorphTargetInfluences) {
+						break
 					}
 
-				}
-
-			} else {
-
-
-				for ( var i = 0, l = positions.length; i < l; i += 9 ) {
-
-					a = i / 3;
-					b = a + 1;
-					c = a + 2;
-
-					intersection = checkBufferGeometryIntersection( this, raycaster, ray, positions, uvs, a, b, c );
-
-					if ( intersection ) {
-
-						intersection.index = a; // triangle number in positions buffer semantics
-						intersects.push( intersection );
+					influence := m.MorphTargetInfluences[ t ]
 
+					if influence == 0 {
+						continue
 					}
 
-				}
-
-			}
-
-		} else if ( geometry instanceof THREE.Geometry ) {
-
-			var fvA, fvB, fvC;
-			var isFaceMaterial = material instanceof THREE.MeshFaceMaterial;
-			var materials = isFaceMaterial === true ? material.materials : null;
-
-			var vertices = geometry.vertices;
-			var faces = geometry.faces;
-			var faceVertexUvs = geometry.faceVertexUvs[ 0 ];
-			if ( faceVertexUvs.length > 0 ) uvs = faceVertexUvs;
-
-			for ( var f = 0, fl = faces.length; f < fl; f ++ ) {
-
-				var face = faces[ f ];
-				var faceMaterial = isFaceMaterial === true ? materials[ face.materialIndex ] : material;
-
-				if ( faceMaterial === undefined ) continue;
-
-				fvA = vertices[ face.a ];
-				fvB = vertices[ face.b ];
-				fvC = vertices[ face.c ];
-
-				if ( faceMaterial.morphTargets === true ) {
-
-					var morphTargets = geometry.morphTargets;
-					var morphInfluences = this.morphTargetInfluences;
-
-					vA.set( 0, 0, 0 );
-					vB.set( 0, 0, 0 );
-					vC.set( 0, 0, 0 );
-
-					for ( var t = 0, tl = morphTargets.length; t < tl; t ++ ) {
-
-						var influence = morphInfluences[ t ];
-
-						if ( influence === 0 ) continue;
-
-						var targets = morphTargets[ t ].vertices;
-
-						vA.addScaledVector( tempA.subVectors( targets[ face.a ], fvA ), influence );
-						vB.addScaledVector( tempB.subVectors( targets[ face.b ], fvB ), influence );
-						vC.addScaledVector( tempC.subVectors( targets[ face.c ], fvC ), influence );
+					targets := morphTarget.Vertices
 
+					if face.A >= len(targets) || face.B >= len(targets) || face.C >= len(targets) {
+						continue
 					}
 
-					vA.add( fvA );
-					vB.add( fvB );
-					vC.add( fvC );
-
-					fvA = vA;
-					fvB = vB;
-					fvC = vC;
-
+					vA.AddScaledVector( *tempA.SubVectors( *targets[ face.A ], *fvA ), influence )
+					vB.AddScaledVector( *tempB.SubVectors( *targets[ face.B ], *fvB ), influence )
+					vC.AddScaledVector( *tempC.SubVectors( *targets[ face.C ], *fvC ), influence )
 				}
 
-				intersection = checkIntersection( this, raycaster, ray, fvA, fvB, fvC, intersectionPoint );
-
-				if ( intersection ) {
+				vA.Add( fvA )
+				vB.Add( fvB )
+				vC.Add( fvC )
 
-					if ( uvs ) {
-
-						var uvs_f = uvs[ f ];
-						uvA.copy( uvs_f[ 0 ] );
-						uvB.copy( uvs_f[ 1 ] );
-						uvC.copy( uvs_f[ 2 ] );
+				fvA = vA
+				fvB = vB
+				fvC = vC
+			}
 
-						intersection.uv = uvIntersection( intersectionPoint, fvA, fvB, fvC, uvA, uvB, uvC );
+			intersection := checkIntersection( raycaster, faceMaterial, fvA, fvB, fvC, intersectionPoint )
 
-					}
+			if intersection != nil {
 
-					intersection.face = face;
-					intersection.faceIndex = f;
-					intersects.push( intersection );
+				if f < len(uvs) && len(uvs[ f ]) == 3 {
+					uvA.Copy( uvs[ f ][ 0 ] )
+					uvB.Copy( uvs[ f ][ 1 ] )
+					uvC.Copy( uvs[ f ][ 2 ] )
 
+					intersection.Uv = uvIntersection( intersectionPoint, fvA, fvB, fvC, uvA, uvB, uvC )
 				}
 
+				intersection.Face = face
+				intersection.FaceIndex = f
+				intersects = append(intersects, intersection)
 			}
-
 		}
 
-	};
+		return intersects
+	}
+}
 
-}() );
-*/
+func (m *Mesh) toJSONObject(meta *core.JSONMeta) (map[string]interface{}) {
+	object := m.Object3D.BaseToJSONObject( meta )
+
+	if m.Material != nil {
+		if _, ok := meta.Materials[ m.Material.Uuid ]; !ok {
+			meta.Materials[ m.Material.Uuid ] = m.Material.ToJSON( meta )
+		}
+		object["material"] = m.Material.Uuid
+	}
+
+	return object
+}
 
 func (m *Mesh) Clone() {
 	return NewMesh(m.Geometry, m.Material).Copy(m)
@@ -0,0 +1,116 @@
+package objects
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// Points is a point, sized by its PointsMaterial, at every vertex of its geometry.
+// WebGLRenderer draws them with its points plugin, as squares facing the camera.
+type Points struct {
+	*core.Object3D
+	Material *materials.Material
+}
+
+func NewDefaultPoints() (*Points) {
+	return NewPoints(core.NewGeometry(), materials.NewPointsMaterial(map[string]interface{}{ "color": rand.Intn(0xffffff) }).Material)
+}
+
+func NewPoints(geometry *core.Geometry, material *materials.Material) (*Points) {
+	o := core.NewObject3D()
+	o.Geometry = geometry
+	p := &Points{
+		Object3D: o,
+		Material: material,
+	}
+	p.Type = "Points"
+	p.Self = p
+	p.ToJSONObject = p.toJSONObject
+	p.Raycast = p.buildRaycast()
+	return p
+}
+
+// buildRaycast builds the Raycast of p, which hits every vertex within
+// raycaster.PointsThreshold of the ray.
+func (p *Points) buildRaycast() (func(*core.Raycaster, []*core.Intersection) ([]*core.Intersection)) {
+
+	inverseMatrix := math3d.NewMatrix4()
+	ray := math3d.NewDefaultRay()
+	sphere := math3d.NewDefaultSphere()
+
+	return func(raycaster *core.Raycaster, intersects []*core.Intersection) ([]*core.Intersection) {
+
+		geometry := p.Geometry
+		threshold := raycaster.PointsThreshold
+
+		// Checking boundingSphere distance to ray
+
+		if geometry.BoundingSphere == nil {
+			geometry.ComputeBoundingSphere()
+		}
+
+		sphere.Copy( geometry.BoundingSphere )
+		sphere.ApplyMatrix4( p.MatrixWorld )
+		sphere.Radius += threshold
+
+		if !raycaster.Ray.IsIntersectionSphere( sphere ) {
+			return intersects
+		}
+
+		inverseMatrix.GetInverse( p.MatrixWorld, false )
+		ray.Copy( raycaster.Ray ).ApplyMatrix4( inverseMatrix )
+
+		localThreshold := threshold / ( ( p.Scale.X + p.Scale.Y + p.Scale.Z ) / 3 )
+		localThresholdSq := localThreshold * localThreshold
+
+		for index, point := range geometry.Vertices {
+
+			rayPointDistanceSq := ray.DistanceSqToPoint( point )
+
+			if rayPointDistanceSq < localThresholdSq {
+
+				intersectPoint := ray.ClosestPointToPoint( point, nil )
+				intersectPoint.ApplyMatrix4( p.MatrixWorld )
+
+				distance := raycaster.Ray.Origin.DistanceTo( intersectPoint )
+
+				if distance < raycaster.Near || distance > raycaster.Far {
+					continue
+				}
+
+				intersects = append(intersects, &core.Intersection{
+					Distance: distance,
+					DistanceToRay: math.Sqrt( rayPointDistanceSq ),
+					Point: intersectPoint,
+					Index: index,
+					Object: p.Object3D,
+				})
+			}
+		}
+
+		return intersects
+	}
+}
+
+func (p *Points) toJSONObject(meta *core.JSONMeta) (map[string]interface{}) {
+	object := p.Object3D.BaseToJSONObject( meta )
+
+	if p.Material != nil {
+		if _, ok := meta.Materials[ p.Material.Uuid ]; !ok {
+			meta.Materials[ p.Material.Uuid ] = p.Material.ToJSON( meta )
+		}
+		object["material"] = p.Material.Uuid
+	}
+
+	return object
+}
+
+func (p *Points) Clone() (*Points) {
+	points := NewPoints(p.Geometry, p.Material)
+	points.Copy(p.Object3D, true)
+	return points
+}
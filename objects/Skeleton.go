@@ -0,0 +1,133 @@
+package objects
+
+import (
+	"fmt"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**
+ * @author mikael emtinger / http://gomo.se/
+ * @author alteredq / http://alteredqualia.com/
+ * @author michael guerrero / http://realitymeltdown.com
+ * @author ikerr / http://verold.com
+ */
+
+// Skeleton is the set of bones deforming a SkinnedMesh. BoneInverses holds the
+// inverse of the world matrix of each bone in the bind pose, the pose the mesh
+// was modelled in.
+type Skeleton struct {
+	Bones []*Bone
+	BoneInverses []*math3d.Matrix4
+
+	// BoneMatrices holds, for each bone, how far it has moved from the bind
+	// pose as 16 floats in column-major order. It is set by Update.
+	BoneMatrices []float64
+
+	identityMatrix *math3d.Matrix4
+
+	Update func()
+}
+
+// NewSkeleton returns a skeleton of bones. If boneInverses is nil they are
+// calculated from the current world matrices of bones.
+func NewSkeleton(bones []*Bone, boneInverses []*math3d.Matrix4) (*Skeleton) {
+	s := &Skeleton{
+		Bones: make([]*Bone, len(bones)),
+		BoneMatrices: make([]float64, 16 * len(bones)),
+		identityMatrix: math3d.NewMatrix4(),
+	}
+	copy(s.Bones, bones)
+	s.Update = s.buildUpdate()
+
+	// use the supplied bone inverses or calculate the inverses
+
+	if boneInverses == nil {
+		s.CalculateInverses()
+	} else if len(boneInverses) == len(s.Bones) {
+		s.BoneInverses = make([]*math3d.Matrix4, len(boneInverses))
+		copy(s.BoneInverses, boneInverses)
+	} else {
+		fmt.Println("THREE.Skeleton boneInverses is the wrong length.")
+
+		s.BoneInverses = make([]*math3d.Matrix4, len(s.Bones))
+		for b := range s.BoneInverses {
+			s.BoneInverses[ b ] = math3d.NewMatrix4()
+		}
+	}
+
+	return s
+}
+
+// CalculateInverses makes the current pose of the bones the bind pose.
+func (s *Skeleton) CalculateInverses() {
+	s.BoneInverses = make([]*math3d.Matrix4, len(s.Bones))
+
+	for b, bone := range s.Bones {
+		inverse := math3d.NewMatrix4()
+
+		if bone != nil {
+			inverse.GetInverse( bone.MatrixWorld, false )
+		}
+
+		s.BoneInverses[ b ] = inverse
+	}
+}
+
+// Pose moves the bones back to the bind pose.
+func (s *Skeleton) Pose() {
+
+	// recover the bind-time world matrices
+
+	for b, bone := range s.Bones {
+		if bone != nil {
+			bone.MatrixWorld.GetInverse( s.BoneInverses[ b ], false )
+		}
+	}
+
+	// compute the local matrices, positions, rotations and scales
+
+	for _, bone := range s.Bones {
+		if bone == nil {
+			continue
+		}
+
+		if bone.Parent != nil {
+			bone.Matrix.GetInverse( bone.Parent.MatrixWorld, false )
+			bone.Matrix.MultiplyMatrices( bone.Matrix, bone.MatrixWorld )
+		} else {
+			bone.Matrix.Copy( bone.MatrixWorld )
+		}
+
+		bone.Matrix.Decompose( bone.Position, bone.Quaternion, bone.Scale )
+	}
+}
+
+// buildUpdate builds the Update of s, which sets BoneMatrices from the world
+// matrices of the bones. The bones' world matrices must be up to date.
+func (s *Skeleton) buildUpdate() (func()) {
+
+	offsetMatrix := math3d.NewMatrix4()
+
+	return func() {
+
+		// flatten bone matrices to array
+
+		for b, bone := range s.Bones {
+
+			// compute the offset between the current and the original transform
+
+			matrix := s.identityMatrix
+			if bone != nil {
+				matrix = bone.MatrixWorld
+			}
+
+			offsetMatrix.MultiplyMatrices( matrix, s.BoneInverses[ b ] )
+			copy(s.BoneMatrices[ b * 16 : ( b + 1 ) * 16 ], offsetMatrix.Elements[:])
+		}
+	}
+}
+
+// Clone returns a skeleton of the same bones and bind pose.
+func (s *Skeleton) Clone() (*Skeleton) {
+	return NewSkeleton( s.Bones, s.BoneInverses )
+}
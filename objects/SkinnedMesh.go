@@ -0,0 +1,253 @@
+package objects
+
+import (
+	"fmt"
+	"math"
+
+	three "github.com/uzudil/three.go"
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**
+ * @author mikael emtinger / http://gomo.se/
+ * @author alteredq / http://alteredqualia.com/
+ * @author ikerr / http://verold.com
+ */
+
+// SkinnedMesh is a mesh deformed by the bones of a Skeleton. Each vertex
+// follows up to four bones, given by Geometry.SkinIndices, blended by
+// Geometry.SkinWeights.
+type SkinnedMesh struct {
+	*Mesh
+
+	// BindMode is three.AttachedMode if the skeleton moves with the mesh, or
+	// three.DetachedMode if it is placed in the world independently of it.
+	BindMode string
+	BindMatrix *math3d.Matrix4
+	BindMatrixInverse *math3d.Matrix4
+	Skeleton *Skeleton
+
+	// BoneTransform moves target, the position of vertex index in the bind
+	// pose, to where the current pose of the skeleton puts it.
+	BoneTransform func(int, *math3d.Vector3) (*math3d.Vector3)
+}
+
+// NewSkinnedMesh returns a mesh bound to a skeleton built from geometry.Bones.
+// Root bones are added as children of the mesh.
+func NewSkinnedMesh(geometry *core.Geometry, material *materials.Material) (*SkinnedMesh) {
+	m := &SkinnedMesh{
+		Mesh: NewMesh(geometry, material),
+		BindMode: three.AttachedMode,
+		BindMatrix: math3d.NewMatrix4(),
+		BindMatrixInverse: math3d.NewMatrix4(),
+	}
+	m.Type = "SkinnedMesh"
+	m.Self = m
+
+	// init bones
+
+	bones := make([]*Bone, 0)
+
+	if geometry != nil {
+		for _, gbone := range geometry.Bones {
+			bone := NewBone( m )
+			bone.Name = gbone.Name
+			bone.Position.Copy( gbone.Position )
+			bone.Quaternion.Copy( gbone.Quaternion )
+			bone.Scale.Copy( gbone.Scale )
+			bones = append(bones, bone)
+		}
+
+		for b, gbone := range geometry.Bones {
+			if gbone.Parent >= 0 && gbone.Parent < len(bones) {
+				bones[ gbone.Parent ].Add( bones[ b ].Object3D )
+			} else {
+				m.Add( bones[ b ].Object3D )
+			}
+		}
+	}
+
+	m.BoneTransform = m.buildBoneTransform()
+	m.Raycast = m.buildRaycast()
+	m.ToJSONObject = m.toJSONObject
+
+	m.NormalizeSkinWeights()
+	m.UpdateMatrixWorld( true )
+	m.Bind( NewSkeleton( bones, nil ), m.MatrixWorld )
+
+	return m
+}
+
+// Bind makes skeleton deform m, with the mesh placed by bindMatrix in the bind
+// pose. If bindMatrix is nil the current pose is the bind pose.
+func (m *SkinnedMesh) Bind(skeleton *Skeleton, bindMatrix *math3d.Matrix4) {
+	m.Skeleton = skeleton
+
+	if bindMatrix == nil {
+		m.UpdateMatrixWorld( true )
+		m.Skeleton.CalculateInverses()
+		bindMatrix = m.MatrixWorld
+	}
+
+	m.BindMatrix.Copy( bindMatrix )
+	m.BindMatrixInverse.GetInverse( bindMatrix, false )
+}
+
+// Pose moves the skeleton back to the bind pose.
+func (m *SkinnedMesh) Pose() {
+	m.Skeleton.Pose()
+}
+
+// NormalizeSkinWeights scales the skin weights of each vertex to add up to 1.
+func (m *SkinnedMesh) NormalizeSkinWeights() {
+	if m.Geometry == nil {
+		return
+	}
+
+	for _, sw := range m.Geometry.SkinWeights {
+		scale := 1.0 / sw.LengthManhattan()
+
+		if !math.IsInf( scale, 0 ) {
+			sw.MultiplyScalar( scale )
+		} else {
+			sw.Set( 1, 0, 0, 0 ) // do something reasonable
+		}
+	}
+}
+
+func (m *SkinnedMesh) UpdateMatrixWorld(force bool) {
+	m.Object3D.UpdateMatrixWorld( true )
+	m.UpdateBindMatrixInverse()
+}
+
+// UpdateBindMatrixInverse updates BindMatrixInverse for BindMode. The renderer
+// calls it every frame, after the world matrices of the scene are updated.
+func (m *SkinnedMesh) UpdateBindMatrixInverse() {
+	switch m.BindMode {
+	case three.AttachedMode:
+		m.BindMatrixInverse.GetInverse( m.MatrixWorld, false )
+	case three.DetachedMode:
+		m.BindMatrixInverse.GetInverse( m.BindMatrix, false )
+	default:
+		fmt.Println("THREE.SkinnedMesh unrecognized bindMode: " + m.BindMode)
+	}
+}
+
+func (m *SkinnedMesh) buildBoneTransform() (func(int, *math3d.Vector3) (*math3d.Vector3)) {
+
+	basePosition := math3d.NewEmptyVector3()
+	vector := math3d.NewEmptyVector3()
+	matrix := math3d.NewMatrix4()
+
+	return func(index int, target *math3d.Vector3) (*math3d.Vector3) {
+
+		geometry := m.Geometry
+		skeleton := m.Skeleton
+
+		if index >= len(geometry.SkinIndices) || index >= len(geometry.SkinWeights) {
+			return target
+		}
+
+		skinIndex := geometry.SkinIndices[ index ]
+		skinWeight := geometry.SkinWeights[ index ]
+
+		basePosition.Copy( target ).ApplyMatrix4( m.BindMatrix )
+		target.Set( 0, 0, 0 )
+
+		for i := 0; i < 4; i ++ {
+			weight := skinWeight.GetComponent( i )
+			boneIndex := int( skinIndex.GetComponent( i ) )
+
+			if weight == 0 || boneIndex < 0 || boneIndex >= len(skeleton.Bones) {
+				continue
+			}
+
+			boneMatrix := skeleton.identityMatrix
+			if bone := skeleton.Bones[ boneIndex ]; bone != nil {
+				boneMatrix = bone.MatrixWorld
+			}
+
+			matrix.MultiplyMatrices( boneMatrix, skeleton.BoneInverses[ boneIndex ] )
+			target.AddScaledVector( *vector.Copy( basePosition ).ApplyMatrix4( matrix ), weight )
+		}
+
+		return target.ApplyMatrix4( m.BindMatrixInverse )
+	}
+}
+
+// SkinVertices stores the vertices of the geometry, as posed by the skeleton,
+// in target and returns it. target is grown as needed, so it can be nil.
+func (m *SkinnedMesh) SkinVertices(target []*math3d.Vector3) ([]*math3d.Vector3) {
+	vertices := m.Geometry.Vertices
+
+	for len(target) < len(vertices) {
+		target = append(target, math3d.NewEmptyVector3())
+	}
+	target = target[ :len(vertices) ]
+
+	for i, vertex := range vertices {
+		m.BoneTransform( i, target[ i ].Copy( vertex ) )
+	}
+
+	return target
+}
+
+// buildRaycast builds the Raycast of m, which tests the faces of the mesh in
+// the current pose of the skeleton.
+func (m *SkinnedMesh) buildRaycast() (func(*core.Raycaster, []*core.Intersection) ([]*core.Intersection)) {
+
+	// the mesh as posed, in the same place as m
+	skinnedGeometry := core.NewGeometry()
+	skinnedMesh := NewMesh(skinnedGeometry, nil)
+	skinnedIntersects := make([]*core.Intersection, 0)
+
+	return func(raycaster *core.Raycaster, intersects []*core.Intersection) ([]*core.Intersection) {
+
+		if m.Material == nil {
+			return intersects
+		}
+
+		skinnedGeometry.Vertices = m.SkinVertices( skinnedGeometry.Vertices )
+		skinnedGeometry.Faces = m.Geometry.Faces
+		skinnedGeometry.FaceVertexUvs = m.Geometry.FaceVertexUvs
+		skinnedGeometry.ComputeBoundingSphere()
+
+		skinnedMesh.Material = m.Material
+		skinnedMesh.MatrixWorld.Copy( m.MatrixWorld )
+
+		skinnedIntersects = skinnedMesh.Raycast( raycaster, skinnedIntersects[ :0 ] )
+
+		for _, intersect := range skinnedIntersects {
+			intersect.Object = m.Object3D
+			intersects = append(intersects, intersect)
+		}
+
+		return intersects
+	}
+}
+
+func (m *SkinnedMesh) toJSONObject(meta *core.JSONMeta) (map[string]interface{}) {
+	object := m.Mesh.toJSONObject( meta )
+
+	object["bindMode"] = m.BindMode
+	object["bindMatrix"] = m.BindMatrix.ToArray()
+
+	bones := make([]string, 0, len(m.Skeleton.Bones))
+	boneInverses := make([]float64, 0, len(m.Skeleton.BoneInverses) * 16)
+
+	for b, bone := range m.Skeleton.Bones {
+		uuid := ""
+		if bone != nil {
+			uuid = bone.Uuid
+		}
+		bones = append(bones, uuid)
+		boneInverses = append(boneInverses, m.Skeleton.BoneInverses[ b ].ToArray()...)
+	}
+
+	object["bones"] = bones
+	object["boneInverses"] = boneInverses
+
+	return object
+}
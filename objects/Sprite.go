@@ -0,0 +1,106 @@
+package objects
+
+import (
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+)
+
+// spriteGeometry is the unit quad shared by all sprites, centered on the origin.
+var spriteGeometry = newSpriteGeometry()
+
+func newSpriteGeometry() (*core.Geometry) {
+	geometry := core.NewGeometry()
+
+	geometry.Vertices = append(geometry.Vertices,
+		math3d.NewVector3( - 0.5, - 0.5, 0 ),
+		math3d.NewVector3( 0.5, - 0.5, 0 ),
+		math3d.NewVector3( 0.5, 0.5, 0 ),
+		math3d.NewVector3( - 0.5, 0.5, 0 ),
+	)
+
+	geometry.Faces = append(geometry.Faces, core.NewDefaultFace3( 0, 1, 2 ), core.NewDefaultFace3( 0, 2, 3 ))
+
+	geometry.FaceVertexUvs[ 0 ] = append(geometry.FaceVertexUvs[ 0 ],
+		[]*math3d.Vector2{ math3d.NewVector2( 0, 0 ), math3d.NewVector2( 1, 0 ), math3d.NewVector2( 1, 1 ) },
+		[]*math3d.Vector2{ math3d.NewVector2( 0, 0 ), math3d.NewVector2( 1, 1 ), math3d.NewVector2( 0, 1 ) },
+	)
+
+	geometry.ComputeFaceNormals()
+
+	return geometry
+}
+
+// Sprite is a quad that always faces the camera. Its Scale sets its size in
+// world units and its SpriteMaterial its color, map and rotation.
+// Only projection and picking are provided: WebGLRenderer collects sprites in
+// its Sprites list and Raycast hits them, but the sprite plugin that would
+// draw them is not ported yet.
+type Sprite struct {
+	*core.Object3D
+	Material *materials.Material
+}
+
+func NewDefaultSprite() (*Sprite) {
+	return NewSprite(materials.NewSpriteMaterial(nil).Material)
+}
+
+func NewSprite(material *materials.Material) (*Sprite) {
+	o := core.NewObject3D()
+	o.Geometry = spriteGeometry
+	s := &Sprite{
+		Object3D: o,
+		Material: material,
+	}
+	s.Type = "Sprite"
+	s.Self = s
+	s.ToJSONObject = s.toJSONObject
+	s.Raycast = s.buildRaycast()
+	return s
+}
+
+// buildRaycast builds the Raycast of s, which treats the sprite as a sphere
+// around its position.
+func (s *Sprite) buildRaycast() (func(*core.Raycaster, []*core.Intersection) ([]*core.Intersection)) {
+
+	matrixPosition := math3d.NewEmptyVector3()
+
+	return func(raycaster *core.Raycaster, intersects []*core.Intersection) ([]*core.Intersection) {
+
+		matrixPosition.SetFromMatrixPosition( s.MatrixWorld )
+
+		distanceSq := raycaster.Ray.DistanceSqToPoint( matrixPosition )
+		guessSizeSq := s.Scale.X * s.Scale.Y
+
+		if distanceSq > guessSizeSq {
+			return intersects
+		}
+
+		return append(intersects, &core.Intersection{
+			Distance: math.Sqrt( distanceSq ),
+			Point: matrixPosition.Clone(),
+			Object: s.Object3D,
+		})
+	}
+}
+
+func (s *Sprite) toJSONObject(meta *core.JSONMeta) (map[string]interface{}) {
+	object := s.Object3D.BaseToJSONObject( meta )
+
+	if s.Material != nil {
+		if _, ok := meta.Materials[ s.Material.Uuid ]; !ok {
+			meta.Materials[ s.Material.Uuid ] = s.Material.ToJSON( meta )
+		}
+		object["material"] = s.Material.Uuid
+	}
+
+	return object
+}
+
+func (s *Sprite) Clone() (*Sprite) {
+	sprite := NewSprite(s.Material)
+	sprite.Copy(s.Object3D, true)
+	return sprite
+}
@@ -0,0 +1,321 @@
+package renderers
+
+import (
+	"fmt"
+	"strings"
+
+	three "github.com/uzudil/three.go"
+	"github.com/uzudil/three.go/cameras"
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/go-gl/gl/v3.3-core/gl"
+)
+
+// PointsPlugin draws the Points found by projectObject, after the opaque and
+// transparent passes, like the sprite plugin. Every vertex is a square of
+// PointsMaterial.Size pixels, or of Size world units seen from the camera
+// when SizeAttenuation is set. PointsMaterial.Map is not supported.
+type PointsPlugin struct {
+	renderer *WebGLRenderer
+
+	program uint32
+	vertexArray uint32
+	failed bool
+
+	uniforms struct {
+		projectionMatrix, modelViewMatrix int32
+		size, scale, sizeAttenuation int32
+		diffuse, opacity, useVertexColors int32
+	}
+
+	attributes struct {
+		position, color uint32
+	}
+
+	// the vertex buffers of each geometry drawn so far
+	buffers map[*core.Geometry]*pointsBuffers
+
+	modelViewMatrix *math3d.Matrix4
+	rgb []float64
+}
+
+type pointsBuffers struct {
+	position, color uint32
+	count int
+	hasColors bool
+}
+
+func NewPointsPlugin(renderer *WebGLRenderer) (*PointsPlugin) {
+	return &PointsPlugin{
+		renderer: renderer,
+		buffers: make(map[*core.Geometry]*pointsBuffers),
+		modelViewMatrix: math3d.NewMatrix4(),
+		rgb: make([]float64, 3),
+	}
+}
+
+const pointsVertexShader = `#version 330 core
+
+uniform mat4 projectionMatrix;
+uniform mat4 modelViewMatrix;
+uniform float size;
+uniform float scale;
+uniform bool sizeAttenuation;
+uniform bool useVertexColors;
+uniform vec3 diffuse;
+
+in vec3 position;
+in vec3 color;
+
+out vec3 vColor;
+
+void main() {
+	vColor = useVertexColors ? diffuse * color : diffuse;
+
+	vec4 mvPosition = modelViewMatrix * vec4( position, 1.0 );
+
+	gl_PointSize = sizeAttenuation ? size * ( scale / - mvPosition.z ) : size;
+	gl_Position = projectionMatrix * mvPosition;
+}
+` + "\x00"
+
+const pointsFragmentShader = `#version 330 core
+
+uniform float opacity;
+
+in vec3 vColor;
+
+out vec4 fragColor;
+
+void main() {
+	fragColor = vec4( vColor, opacity );
+}
+` + "\x00"
+
+func (p *PointsPlugin) init() error {
+
+	vertexShader, err := compilePointsShader( pointsVertexShader, gl.VERTEX_SHADER )
+	if err != nil {
+		return err
+	}
+	fragmentShader, err := compilePointsShader( pointsFragmentShader, gl.FRAGMENT_SHADER )
+	if err != nil {
+		return err
+	}
+
+	program := gl.CreateProgram()
+	gl.AttachShader( program, vertexShader )
+	gl.AttachShader( program, fragmentShader )
+	gl.LinkProgram( program )
+
+	gl.DeleteShader( vertexShader )
+	gl.DeleteShader( fragmentShader )
+
+	var status int32
+	gl.GetProgramiv( program, gl.LINK_STATUS, &status )
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramiv( program, gl.INFO_LOG_LENGTH, &logLength )
+		log := strings.Repeat( "\x00", int(logLength + 1) )
+		gl.GetProgramInfoLog( program, logLength, nil, gl.Str( log ) )
+		gl.DeleteProgram( program )
+		return fmt.Errorf("could not link the points program: %s", log)
+	}
+
+	p.program = program
+
+	p.uniforms.projectionMatrix = gl.GetUniformLocation( program, gl.Str( "projectionMatrix\x00" ) )
+	p.uniforms.modelViewMatrix = gl.GetUniformLocation( program, gl.Str( "modelViewMatrix\x00" ) )
+	p.uniforms.size = gl.GetUniformLocation( program, gl.Str( "size\x00" ) )
+	p.uniforms.scale = gl.GetUniformLocation( program, gl.Str( "scale\x00" ) )
+	p.uniforms.sizeAttenuation = gl.GetUniformLocation( program, gl.Str( "sizeAttenuation\x00" ) )
+	p.uniforms.diffuse = gl.GetUniformLocation( program, gl.Str( "diffuse\x00" ) )
+	p.uniforms.opacity = gl.GetUniformLocation( program, gl.Str( "opacity\x00" ) )
+	p.uniforms.useVertexColors = gl.GetUniformLocation( program, gl.Str( "useVertexColors\x00" ) )
+
+	p.attributes.position = uint32( gl.GetAttribLocation( program, gl.Str( "position\x00" ) ) )
+	p.attributes.color = uint32( gl.GetAttribLocation( program, gl.Str( "color\x00" ) ) )
+
+	gl.GenVertexArrays( 1, &p.vertexArray )
+
+	return nil
+}
+
+func compilePointsShader(source string, shaderType uint32) (uint32, error) {
+	shader := gl.CreateShader( shaderType )
+
+	sources, free := gl.Strs( source )
+	gl.ShaderSource( shader, 1, sources, nil )
+	free()
+	gl.CompileShader( shader )
+
+	var status int32
+	gl.GetShaderiv( shader, gl.COMPILE_STATUS, &status )
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetShaderiv( shader, gl.INFO_LOG_LENGTH, &logLength )
+		log := strings.Repeat( "\x00", int(logLength + 1) )
+		gl.GetShaderInfoLog( shader, logLength, nil, gl.Str( log ) )
+		gl.DeleteShader( shader )
+		return 0, fmt.Errorf("could not compile the points shader: %s", log)
+	}
+
+	return shader, nil
+}
+
+// Render draws renderer.Points as seen from camera.
+func (p *PointsPlugin) Render(camera *cameras.Camera) {
+
+	points := p.renderer.Points
+
+	if len(points) == 0 || p.failed {
+		return
+	}
+
+	if p.program == 0 {
+		if err := p.init(); err != nil {
+			fmt.Println("THREE.PointsPlugin:", err)
+			p.failed = true
+			return
+		}
+	}
+
+	gl.UseProgram( p.program )
+	gl.BindVertexArray( p.vertexArray )
+
+	gl.Enable( gl.PROGRAM_POINT_SIZE )
+	gl.Enable( gl.BLEND )
+	gl.BlendFunc( gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA )
+
+	setPointsMatrix( p.uniforms.projectionMatrix, camera.ProjectionMatrix )
+	gl.Uniform1f( p.uniforms.scale, float32( p.renderer.Height * p.renderer.PixelRatio ) / 2 )
+
+	gl.EnableVertexAttribArray( p.attributes.position )
+
+	for _, object := range points {
+
+		material, ok := object.Material.Self.(*materials.PointsMaterial)
+		if !ok || !material.Visible {
+			continue
+		}
+
+		useVertexColors := material.VertexColors == three.VertexColors
+
+		buffers := p.updateBuffers( object.Geometry, useVertexColors )
+		if buffers.count == 0 {
+			continue
+		}
+
+		p.modelViewMatrix.MultiplyMatrices( camera.MatrixWorldInverse, object.MatrixWorld )
+		setPointsMatrix( p.uniforms.modelViewMatrix, p.modelViewMatrix )
+
+		material.Color.ToArray( p.rgb, 0 )
+		gl.Uniform3f( p.uniforms.diffuse, float32( p.rgb[ 0 ] ), float32( p.rgb[ 1 ] ), float32( p.rgb[ 2 ] ) )
+		gl.Uniform1f( p.uniforms.opacity, float32( material.Opacity ) )
+		gl.Uniform1f( p.uniforms.size, float32( material.Size ) )
+		gl.Uniform1i( p.uniforms.sizeAttenuation, pointsBool( material.SizeAttenuation ) )
+		gl.Uniform1i( p.uniforms.useVertexColors, pointsBool( useVertexColors && buffers.hasColors ) )
+
+		if material.DepthTest {
+			gl.Enable( gl.DEPTH_TEST )
+		} else {
+			gl.Disable( gl.DEPTH_TEST )
+		}
+		gl.DepthMask( material.DepthWrite )
+
+		gl.BindBuffer( gl.ARRAY_BUFFER, buffers.position )
+		gl.VertexAttribPointer( p.attributes.position, 3, gl.FLOAT, false, 0, gl.PtrOffset( 0 ) )
+
+		if useVertexColors && buffers.hasColors {
+			gl.BindBuffer( gl.ARRAY_BUFFER, buffers.color )
+			gl.EnableVertexAttribArray( p.attributes.color )
+			gl.VertexAttribPointer( p.attributes.color, 3, gl.FLOAT, false, 0, gl.PtrOffset( 0 ) )
+		} else {
+			gl.DisableVertexAttribArray( p.attributes.color )
+		}
+
+		gl.DrawArrays( gl.POINTS, 0, int32( buffers.count ) )
+
+		p.renderer.infoRender["calls"] ++
+		p.renderer.infoRender["points"] += buffers.count
+	}
+
+	gl.DisableVertexAttribArray( p.attributes.position )
+	gl.DisableVertexAttribArray( p.attributes.color )
+	gl.BindBuffer( gl.ARRAY_BUFFER, 0 )
+	gl.BindVertexArray( 0 )
+
+	gl.DepthMask( true )
+	gl.Enable( gl.DEPTH_TEST )
+}
+
+// updateBuffers uploads the vertices, and colors if wanted, of geometry when
+// they are new or have changed. The buffers are freed when geometry is disposed.
+func (p *PointsPlugin) updateBuffers(geometry *core.Geometry, useVertexColors bool) (*pointsBuffers) {
+
+	buffers, ok := p.buffers[ geometry ]
+	if !ok {
+		buffers = &pointsBuffers{}
+		gl.GenBuffers( 1, &buffers.position )
+		gl.GenBuffers( 1, &buffers.color )
+		p.buffers[ geometry ] = buffers
+
+		geometry.AddEventListener( "dispose", func(core.Event) {
+			gl.DeleteBuffers( 1, &buffers.position )
+			gl.DeleteBuffers( 1, &buffers.color )
+			delete(p.buffers, geometry)
+		})
+	}
+
+	hasColors := useVertexColors && len(geometry.Colors) == len(geometry.Vertices)
+
+	upToDate := ok && buffers.count == len(geometry.Vertices) && !geometry.VerticesNeedUpdate &&
+		buffers.hasColors == hasColors && !( hasColors && geometry.ColorsNeedUpdate )
+	if upToDate {
+		return buffers
+	}
+
+	buffers.count = len(geometry.Vertices)
+	if buffers.count == 0 {
+		return buffers
+	}
+
+	positions := make([]float32, 0, buffers.count * 3)
+	for _, vertex := range geometry.Vertices {
+		positions = append(positions, float32( vertex.X ), float32( vertex.Y ), float32( vertex.Z ))
+	}
+	gl.BindBuffer( gl.ARRAY_BUFFER, buffers.position )
+	gl.BufferData( gl.ARRAY_BUFFER, len(positions) * 4, gl.Ptr( positions ), gl.DYNAMIC_DRAW )
+
+	buffers.hasColors = hasColors
+	if hasColors {
+		colors := make([]float32, 0, buffers.count * 3)
+		for _, color := range geometry.Colors {
+			color.ToArray( p.rgb, 0 )
+			colors = append(colors, float32( p.rgb[ 0 ] ), float32( p.rgb[ 1 ] ), float32( p.rgb[ 2 ] ))
+		}
+		gl.BindBuffer( gl.ARRAY_BUFFER, buffers.color )
+		gl.BufferData( gl.ARRAY_BUFFER, len(colors) * 4, gl.Ptr( colors ), gl.DYNAMIC_DRAW )
+		geometry.ColorsNeedUpdate = false
+	}
+
+	geometry.VerticesNeedUpdate = false
+
+	return buffers
+}
+
+func setPointsMatrix(location int32, matrix *math3d.Matrix4) {
+	var elements [16]float32
+	for i, element := range matrix.Elements {
+		elements[ i ] = float32( element )
+	}
+	gl.UniformMatrix4fv( location, 1, false, &elements[ 0 ] )
+}
+
+func pointsBool(value bool) int32 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -2,10 +2,14 @@ package renderers
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	three "github.com/uzudil/three.go"
 	math3d "github.com/uzudil/three.go/math"
 	"github.com/uzudil/three.go/core"
 	"github.com/uzudil/three.go/cameras"
+	"github.com/uzudil/three.go/materials"
+	"github.com/uzudil/three.go/objects"
 	"github.com/go-gl/glfw/v3.1/glfw"
 	"github.com/go-gl/gl/v3.3-core/gl"
 )
@@ -23,12 +27,15 @@ type WebGLRenderer struct {
 	ClearColor math3d.Color
 	ClearAlpha int
 	Lights []*math3d.Vector3
-	OpaqueObjects []*core.Object3D
+	OpaqueObjects []*RenderItem
 	OpaqueObjectsLastIndex int
-	TransparentObjects []*core.Object3D
+	TransparentObjects []*RenderItem
 	TransparentObjectsLastIndex int
 	MorphInfluences []float64
-	Sprites []*core.Object3D
+	// Sprites are the sprites found by the last projection; nothing draws them yet.
+	Sprites []*objects.Sprite
+	// Points are the points found by the last projection, drawn by the points plugin.
+	Points []*objects.Points
 	LensFlares []*core.Object3D
 
 	// clearing
@@ -61,6 +68,10 @@ type WebGLRenderer struct {
 
 	// frustum
 	frustum *math3d.Frustum
+	sphere *math3d.Sphere
+
+	// plugins
+	pointsPlugin *PointsPlugin
 
 	// camera matrices cache
 	projScreenMatrix math3d.Matrix4
@@ -133,12 +144,13 @@ func NewWebGLRenderer(parameters map[string]interface{}) (*WebGLRenderer) {
 		ClearColor: math3d.NewColor(0.0, 0.0, 0.0),
 		ClearAlpha: 0,
 		Lights: make([]*math3d.Vector3, 0),
-		OpaqueObjects: make([]*core.Object3D, 0),
+		OpaqueObjects: make([]*RenderItem, 0),
 		OpaqueObjectsLastIndex: - 1,
-		TransparentObjects: make([]*core.Object3D, 0),
+		TransparentObjects: make([]*RenderItem, 0),
 		TransparentObjectsLastIndex: - 1,
 		MorphInfluences: make([]float64, 8),
-		Sprites: make([]*core.Object3D, 0),
+		Sprites: make([]*objects.Sprite, 0),
+		Points: make([]*objects.Points, 0),
 		LensFlares: make([]*core.Object3D, 0),
 
 		// clearing
@@ -180,6 +192,7 @@ func NewWebGLRenderer(parameters map[string]interface{}) (*WebGLRenderer) {
 
 		// frustum
 		frustum: math3d.NewDefaultFrustum(),
+		sphere: math3d.NewDefaultSphere(),
 
 		// camera matrices cache
 		projScreenMatrix: math3d.NewMatrix4(),
@@ -265,6 +278,8 @@ func NewWebGLRenderer(parameters map[string]interface{}) (*WebGLRenderer) {
 
 	renderer.info.programs = renderer.programCache.programs
 
+	renderer.pointsPlugin = NewPointsPlugin( renderer )
+
 //	var bufferRenderer = new THREE.WebGLBufferRenderer( _gl, extensions, _infoRender );
 //	var indexedBufferRenderer = new THREE.WebGLIndexedBufferRenderer( _gl, extensions, _infoRender );
 
@@ -294,6 +309,12 @@ func (r *WebGLRenderer) glClearColor( r, g, b, a float64) {
 	gl.ClearColor(r, g, b, a)
 }
 
+// SupportsInstancedArrays reports whether instanced drawing, used for
+// InstancedMesh, is available. It is part of the OpenGL 3.3 core profile.
+func (r *WebGLRenderer) SupportsInstancedArrays() bool {
+	return true
+}
+
 func (r *WebGLRenderer) setDefaultGLState() {
 	r.state.Init()
 
@@ -1168,6 +1189,7 @@ func (r *WebGLRenderer) setDefaultGLState() {
 		transparentObjectsLastIndex = - 1;
 
 		sprites.length = 0;
+		r.Points = r.Points[ :0 ]
 		lensFlares.length = 0;
 
 		projectObject( scene, camera );
@@ -1226,6 +1248,7 @@ func (r *WebGLRenderer) setDefaultGLState() {
 		// custom render plugins (post pass)
 
 		spritePlugin.render( scene, camera );
+		r.pointsPlugin.Render( camera )
 		lensFlarePlugin.render( scene, camera, _currentWidth, _currentHeight );
 
 		// Generate mipmap if we're using any kind of mipmap filtering
@@ -1252,148 +1275,236 @@ func (r *WebGLRenderer) setDefaultGLState() {
 
 	};
 
-	function pushRenderItem( object, geometry, material, z, group ) {
-
-		var array, index;
-
-		// allocate the next position in the appropriate array
-
-		if ( material.transparent ) {
-
-			array = transparentObjects;
-			index = ++ transparentObjectsLastIndex;
-
-		} else {
-
-			array = opaqueObjects;
-			index = ++ opaqueObjectsLastIndex;
-
-		}
-
-		// recycle existing render item or grow the array
-
-		var renderItem = array[ index ];
-
-		if ( renderItem !== undefined ) {
-
-			renderItem.id = object.id;
-			renderItem.object = object;
-			renderItem.geometry = geometry;
-			renderItem.material = material;
-			renderItem.z = _vector3.z;
-			renderItem.group = group;
-
-		} else {
+// RenderItem is an object queued for drawing with its material, Z is its depth
+// for sorting. Group is the part of the geometry to draw, nil for all of it.
+type RenderItem struct {
+	Id int
+	Object *core.Object3D
+	Geometry *core.Geometry
+	Material *materials.Material
+	Z float64
+	Group *core.GeometryGroup
+
+	// the strongest morph targets of a mesh, see updateMorphInfluences
+	MorphInfluences []float64
+	MorphTargets []*core.MorphTarget
+	MorphNormals []*core.MorphNormals
+}
 
-			renderItem = {
-				id: object.id,
-				object: object,
-				geometry: geometry,
-				material: material,
-				z: _vector3.z,
-				group: group
-			};
+func (r *WebGLRenderer) pushRenderItem(object *core.Object3D, geometry *core.Geometry, material *materials.Material, z float64, group *core.GeometryGroup) (*RenderItem) {
 
-			// assert( index === array.length );
-			array.push( renderItem );
+	var array *[]*RenderItem
+	var index int
 
-		}
+	// allocate the next position in the appropriate array
 
+	if material.Transparent {
+		r.TransparentObjectsLastIndex ++
+		array = &r.TransparentObjects
+		index = r.TransparentObjectsLastIndex
+	} else {
+		r.OpaqueObjectsLastIndex ++
+		array = &r.OpaqueObjects
+		index = r.OpaqueObjectsLastIndex
 	}
 
-	function projectObject( object, camera ) {
-
-		if ( object.visible === false ) return;
+	// recycle existing render item or grow the array
 
-		if ( ( object.channels.mask & camera.channels.mask ) !== 0 ) {
-
-			if ( object instanceof THREE.Light ) {
+	if index < len(*array) {
+		renderItem := (*array)[ index ]
+		renderItem.Id = object.Id
+		renderItem.Object = object
+		renderItem.Geometry = geometry
+		renderItem.Material = material
+		renderItem.Z = z
+		renderItem.Group = group
+		return renderItem
+	}
 
-				lights.push( object );
+	renderItem := &RenderItem{
+		Id: object.Id,
+		Object: object,
+		Geometry: geometry,
+		Material: material,
+		Z: z,
+		Group: group,
+	}
+	*array = append(*array, renderItem)
+	return renderItem
+}
 
-			} else if ( object instanceof THREE.Sprite ) {
+func (r *WebGLRenderer) projectObject(object *core.Object3D, camera *cameras.Camera) {
 
-				sprites.push( object );
+	if !object.Visible {
+		return
+	}
 
-			} else if ( object instanceof THREE.LensFlare ) {
+	if object.Channels.Test( camera.Channels ) {
 
-				lensFlares.push( object );
+		// TODO: lights, lens flares and immediate render objects
 
-			} else if ( object instanceof THREE.ImmediateRenderObject ) {
+		var material *materials.Material
+		var boundingSphere *math3d.Sphere // culled against instead of the geometry's, in world space
+		var morphMesh *objects.Mesh // set for meshes that may have morph targets
 
-				if ( _this.sortObjects === true ) {
+		switch o := object.Self.(type) {
+		case *objects.LOD:
+			// pick the level to show before its children are projected
+			if o.AutoUpdate {
+				o.Update( camera.Object3D )
+			}
+		case *objects.Sprite:
+			// collected for the sprite plugin, which is not ported yet, so
+			// sprites are not drawn
+			r.Sprites = append(r.Sprites, o)
+		case *objects.Mesh:
+			material = o.Material
+			morphMesh = o
+		case *objects.SkinnedMesh:
+			material = o.Material
+			morphMesh = o.Mesh
+			o.UpdateBindMatrixInverse()
+			o.Skeleton.Update()
+		case *objects.Line:
+			material = o.Material
+		case *objects.LineSegments:
+			material = o.Material
+		case *objects.LineLoop:
+			material = o.Material
+		case *objects.InstancedMesh:
+			material = o.Material
+			if o.BoundingSphere == nil {
+				o.ComputeBoundingSphere()
+			}
+			boundingSphere = r.sphere.Copy( o.BoundingSphere ).ApplyMatrix4( object.MatrixWorld )
+		case *objects.Points:
+			// collected for the points plugin, which draws them after the
+			// transparent pass
+			geometry := object.Geometry
+			if geometry.BoundingSphere == nil {
+				geometry.ComputeBoundingSphere()
+			}
+			r.sphere.Copy( geometry.BoundingSphere ).ApplyMatrix4( object.MatrixWorld )
+			if o.Material.Visible && ( !object.FrustumCulled || r.frustum.IntersectsSphere( r.sphere ) ) {
+				r.Points = append(r.Points, o)
+			}
+		}
 
-					_vector3.setFromMatrixPosition( object.matrixWorld );
-					_vector3.applyProjection( _projScreenMatrix );
+		if material != nil {
 
+			inFrustum := !object.FrustumCulled
+			if !inFrustum {
+				if boundingSphere != nil {
+					inFrustum = r.frustum.IntersectsSphere( boundingSphere )
+				} else {
+					inFrustum = r.frustum.IntersectsObject( object )
 				}
+			}
 
-				pushRenderItem( object, null, object.material, _vector3.z, null );
-
-			} else if ( object instanceof THREE.Mesh || object instanceof THREE.Line || object instanceof THREE.Points ) {
-
-				if ( object instanceof THREE.SkinnedMesh ) {
-
-					object.skeleton.update();
+			if inFrustum && material.Visible {
 
+				if r.SortObjects {
+					r.vector3.SetFromMatrixPosition( object.MatrixWorld )
+					r.vector3.ApplyProjection( &r.projScreenMatrix )
 				}
 
-				if ( object.frustumCulled === false || _frustum.intersectsObject( object ) === true ) {
+				if multi, ok := material.Self.(*materials.MultiMaterial); ok {
 
-					var material = object.material;
+					geometry := object.Geometry
 
-					if ( material.visible === true ) {
-
-						if ( _this.sortObjects === true ) {
+					if geometry.GroupsNeedUpdate {
+						geometry.ComputeGroups()
+					}
 
-							_vector3.setFromMatrixPosition( object.matrixWorld );
-							_vector3.applyProjection( _projScreenMatrix );
+					for _, group := range geometry.Groups {
+						groupMaterial := multi.GetMaterial( group.MaterialIndex )
 
+						if groupMaterial != nil && groupMaterial.Visible {
+							renderItem := r.pushRenderItem( object, geometry, groupMaterial, r.vector3.Z, group )
+							r.updateMorphInfluences( renderItem, morphMesh )
 						}
+					}
 
-						var geometry = objects.update( object );
-
-						if ( material instanceof THREE.MeshFaceMaterial ) {
-
-							var groups = geometry.groups;
-							var materials = material.materials;
+				} else {
 
-							for ( var i = 0, l = groups.length; i < l; i ++ ) {
+					renderItem := r.pushRenderItem( object, object.Geometry, material, r.vector3.Z, nil )
+					r.updateMorphInfluences( renderItem, morphMesh )
+				}
+			}
+		}
+	}
 
-								var group = groups[ i ];
-								var groupMaterial = materials[ group.materialIndex ];
+	for _, child := range object.Children {
+		r.projectObject( child, camera )
+	}
+}
 
-								if ( groupMaterial.visible === true ) {
+// morphInfluence is the influence of morph target Index of a mesh.
+type morphInfluence struct {
+	Influence float64
+	Index int
+}
 
-									pushRenderItem( object, geometry, groupMaterial, _vector3.z, group );
+type byInfluence []morphInfluence
 
-								}
+func (a byInfluence) Len() int { return len(a) }
+func (a byInfluence) Swap(i, j int) { a[ i ], a[ j ] = a[ j ], a[ i ] }
+func (a byInfluence) Less(i, j int) bool { return math.Abs( a[ i ].Influence ) > math.Abs( a[ j ].Influence ) }
 
-							}
+// updateMorphInfluences sets the morph fields of renderItem for mesh, which
+// may be nil: MorphInfluences holds the strongest influences, at most
+// MaxMorphTargets of them, and MorphTargets the morph target bound to each of
+// those slots. Morph normals are bound to the first MaxMorphNormals slots
+// only. Slots without an active target are nil, and all are empty unless the
+// item's material has MorphTargets set.
+func (r *WebGLRenderer) updateMorphInfluences(renderItem *RenderItem, mesh *objects.Mesh) {
 
-						} else {
+	if len(renderItem.MorphInfluences) != r.MaxMorphTargets {
+		renderItem.MorphInfluences = make([]float64, r.MaxMorphTargets)
+		renderItem.MorphTargets = make([]*core.MorphTarget, r.MaxMorphTargets)
+		renderItem.MorphNormals = make([]*core.MorphNormals, r.MaxMorphTargets)
+	}
 
-							pushRenderItem( object, geometry, material, _vector3.z, null );
+	for i := range renderItem.MorphInfluences {
+		renderItem.MorphInfluences[ i ] = 0
+		renderItem.MorphTargets[ i ] = nil
+		renderItem.MorphNormals[ i ] = nil
+	}
 
-						}
+	if mesh == nil {
+		return
+	}
 
-					}
+	material, ok := renderItem.Material.Self.(*materials.MeshBasicMaterial)
+	if !ok || !material.MorphTargets {
+		return
+	}
 
-				}
+	geometry := mesh.Geometry
 
-			}
+	activeInfluences := make([]morphInfluence, 0, len(mesh.MorphTargetInfluences))
 
+	for i, influence := range mesh.MorphTargetInfluences {
+		if influence != 0 && i < len(geometry.MorphTargets) {
+			activeInfluences = append(activeInfluences, morphInfluence{ Influence: influence, Index: i })
 		}
+	}
 
-		var children = object.children;
+	sort.Stable( byInfluence( activeInfluences ) )
 
-		for ( var i = 0, l = children.length; i < l; i ++ ) {
+	if len(activeInfluences) > r.MaxMorphTargets {
+		activeInfluences = activeInfluences[ :r.MaxMorphTargets ]
+	}
 
-			projectObject( children[ i ], camera );
+	for i, influence := range activeInfluences {
+		renderItem.MorphInfluences[ i ] = influence.Influence
+		renderItem.MorphTargets[ i ] = geometry.MorphTargets[ influence.Index ]
 
+		if i < r.MaxMorphNormals && influence.Index < len(geometry.MorphNormals) {
+			renderItem.MorphNormals[ i ] = geometry.MorphNormals[ influence.Index ]
 		}
-
 	}
+}
 
 	function renderObjects( renderList, camera, lights, fog, overrideMaterial ) {
 
@@ -3549,13 +3660,6 @@ func (r *WebGLRenderer) setDefaultGLState() {
 
 	};
 
-	this.supportsInstancedArrays = function () {
-
-		console.warn( 'THREE.WebGLRenderer: .supportsInstancedArrays() is now .extensions.get( \'ANGLE_instanced_arrays\' ).' );
-		return extensions.get( 'ANGLE_instanced_arrays' );
-
-	};
-
 	//
 
 	this.initMaterial = function () {
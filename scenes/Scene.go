@@ -12,6 +12,7 @@ func NewScene() (*Scene) {
 		true,
 	}
 	scene.Type = "Scene"
+	scene.Self = scene
 //	this.fog = null;
 //	this.overrideMaterial = null;
 	return scene
@@ -0,0 +1,45 @@
+// Package snapshot saves and loads whole scene graphs in a compact binary format.
+//
+// A snapshot is a header followed by a sequence of chunks:
+//
+//	header := magic "3GSN" | version uint16 | reserved uint16
+//	chunk  := tag [4]byte | length uint64 | payload [length]byte
+//
+// All numbers are little endian. Materials come first, then geometries, then
+// the nodes of the object hierarchy in depth first order (so a parent is always
+// decoded before its children), and finally an "END " chunk. Every chunk carries
+// its length, so a reader can skip the chunks it is not interested in, e.g. to
+// load only the hierarchy of a large scene without its geometry.
+//
+// Geometry chunks store vertex data as float32. Material and node chunks store
+// the same properties as the three.js JSON object format, so every type that
+// supports ToJSON and the ObjectLoader can be stored in a snapshot.
+package snapshot
+
+import "fmt"
+
+var Magic = [4]byte{ '3', 'G', 'S', 'N' }
+
+const Version uint16 = 1
+
+var (
+	MaterialChunk = [4]byte{ 'M', 'A', 'T', 'L' }
+	GeometryChunk = [4]byte{ 'G', 'E', 'O', 'M' }
+	NodeChunk = [4]byte{ 'N', 'O', 'D', 'E' }
+	EndChunk = [4]byte{ 'E', 'N', 'D', ' ' }
+)
+
+// face flags
+const (
+	faceHasVertexNormals = 1 << iota
+	faceHasVertexColors
+)
+
+type ChunkHeader struct {
+	Tag [4]byte
+	Length uint64
+}
+
+func (h ChunkHeader) String() string {
+	return fmt.Sprintf("%s (%d bytes)", string(h.Tag[:]), h.Length)
+}
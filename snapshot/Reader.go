@@ -0,0 +1,496 @@
+package snapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/loaders"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/scenes"
+)
+
+// Reader walks the chunks of a snapshot. Call Next to advance to a chunk,
+// then either one of the Read methods matching its tag or Skip.
+type Reader struct {
+	r *bufio.Reader
+	Version uint16
+	header ChunkHeader
+	remaining uint64
+	err error
+	scratch [8]byte
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{
+		r: bufio.NewReader(r),
+		remaining: 8,
+	}
+
+	var magic [4]byte
+	reader.read(magic[:])
+	reader.Version = reader.uint16()
+	reader.uint16() // reserved
+
+	if reader.err != nil {
+		return nil, reader.err
+	}
+	if magic != Magic {
+		return nil, fmt.Errorf("snapshot: bad magic %q", magic[:])
+	}
+	if reader.Version > Version {
+		return nil, fmt.Errorf("snapshot: unsupported version %d", reader.Version)
+	}
+	return reader, nil
+}
+
+// Next skips whatever is left of the current chunk and reads the next chunk header.
+// It returns io.EOF once the end chunk is reached.
+func (reader *Reader) Next() (ChunkHeader, error) {
+	if err := reader.Skip(); err != nil {
+		return ChunkHeader{}, err
+	}
+
+	var header ChunkHeader
+	reader.remaining = 12
+	reader.read(header.Tag[:])
+	header.Length = reader.uint64()
+	if reader.err != nil {
+		return ChunkHeader{}, reader.unexpected()
+	}
+
+	reader.header = header
+	reader.remaining = header.Length
+
+	if header.Tag == EndChunk {
+		return header, io.EOF
+	}
+	return header, nil
+}
+
+// Skip discards the unread rest of the current chunk.
+func (reader *Reader) Skip() error {
+	if reader.err != nil {
+		return reader.err
+	}
+	if reader.remaining > 0 {
+		_, reader.err = io.CopyN(ioutil.Discard, reader.r, int64(reader.remaining))
+		reader.remaining = 0
+	}
+	return reader.unexpected()
+}
+
+func (reader *Reader) ReadMaterial() (*materials.Material, error) {
+	data, err := reader.readJSON(MaterialChunk)
+	if err != nil {
+		return nil, err
+	}
+	return loaders.NewMaterialLoader().Parse(data), nil
+}
+
+// ReadNode returns the index of the node's parent in chunk order (-1 for the
+// root) and the node's properties in the three.js JSON object format.
+func (reader *Reader) ReadNode() (int32, map[string]interface{}, error) {
+	if err := reader.expect(NodeChunk); err != nil {
+		return 0, nil, err
+	}
+	parent := reader.int32()
+	if reader.err != nil {
+		return 0, nil, reader.unexpected()
+	}
+	data, err := reader.readJSON(NodeChunk)
+	return parent, data, err
+}
+
+func (reader *Reader) ReadGeometry() (*core.Geometry, error) {
+	if err := reader.expect(GeometryChunk); err != nil {
+		return nil, err
+	}
+
+	geometry := core.NewGeometry()
+	geometry.Uuid = reader.string()
+	geometry.Name = reader.string()
+	geometry.Type = reader.string()
+
+	if parameters := reader.bytes(); len(parameters) > 0 {
+		if err := json.Unmarshal(parameters, &geometry.Parameters); err != nil {
+			return nil, err
+		}
+	}
+
+	geometry.Vertices = reader.vector3s()
+
+	color := make([]float64, 3)
+	readColor := func() (*math3d.Color) {
+		color[ 0 ] = reader.float32()
+		color[ 1 ] = reader.float32()
+		color[ 2 ] = reader.float32()
+		return math3d.NewDefaultColor().FromArray( color, 0 )
+	}
+
+	faceCount := reader.count(41)
+	geometry.Faces = make([]*core.Face3, faceCount)
+	for i := range geometry.Faces {
+		face := core.NewDefaultFace3( int(reader.uint32()), int(reader.uint32()), int(reader.uint32()) )
+		face.MaterialIndex = int(reader.int32())
+		flags := reader.uint8()
+
+		face.Normal.Set( reader.float32(), reader.float32(), reader.float32() )
+		face.Color.Copy( readColor() )
+
+		if flags & faceHasVertexNormals != 0 {
+			for j := 0; j < 3; j ++ {
+				face.VertexNormals = append(face.VertexNormals, math3d.NewVector3( reader.float32(), reader.float32(), reader.float32() ))
+			}
+		}
+
+		if flags & faceHasVertexColors != 0 {
+			for j := 0; j < 3; j ++ {
+				face.VertexColors = append(face.VertexColors, readColor())
+			}
+		}
+
+		geometry.Faces[ i ] = face
+	}
+
+	layerCount := reader.count(4)
+	geometry.FaceVertexUvs = make([][][]*math3d.Vector2, layerCount)
+	for i := range geometry.FaceVertexUvs {
+		layer := make([][]*math3d.Vector2, reader.count(1))
+		for j := range layer {
+			if reader.uint8() == 0 {
+				continue
+			}
+			layer[ j ] = []*math3d.Vector2{
+				math3d.NewVector2( reader.float32(), reader.float32() ),
+				math3d.NewVector2( reader.float32(), reader.float32() ),
+				math3d.NewVector2( reader.float32(), reader.float32() ),
+			}
+		}
+		geometry.FaceVertexUvs[ i ] = layer
+	}
+
+	geometry.MorphTargets = make([]*core.MorphTarget, reader.count(8))
+	for i := range geometry.MorphTargets {
+		geometry.MorphTargets[ i ] = &core.MorphTarget{
+			Name: reader.string(),
+			Vertices: reader.vector3s(),
+		}
+	}
+
+	geometry.MorphNormals = make([]*core.MorphNormals, reader.count(8))
+	for i := range geometry.MorphNormals {
+		morphNormals := &core.MorphNormals{
+			FaceNormals: reader.vector3s(),
+		}
+		morphNormals.VertexNormals = make([][3]*math3d.Vector3, reader.count(36))
+		for j := range morphNormals.VertexNormals {
+			for k := 0; k < 3; k ++ {
+				morphNormals.VertexNormals[ j ][ k ] = math3d.NewVector3( reader.float32(), reader.float32(), reader.float32() )
+			}
+		}
+		geometry.MorphNormals[ i ] = morphNormals
+	}
+
+	geometry.SkinIndices = reader.vector4s()
+	geometry.SkinWeights = reader.vector4s()
+
+	if reader.err != nil {
+		return nil, reader.unexpected()
+	}
+	return geometry, nil
+}
+
+func (reader *Reader) vector3s() ([]*math3d.Vector3) {
+	vectors := make([]*math3d.Vector3, reader.count(12))
+	for i := range vectors {
+		vectors[ i ] = math3d.NewVector3( reader.float32(), reader.float32(), reader.float32() )
+	}
+	return vectors
+}
+
+func (reader *Reader) vector4s() ([]*math3d.Vector4) {
+	vectors := make([]*math3d.Vector4, reader.count(16))
+	for i := range vectors {
+		vectors[ i ] = math3d.NewVector4( reader.float32(), reader.float32(), reader.float32(), reader.float32() )
+	}
+	return vectors
+}
+
+func (reader *Reader) expect(tag [4]byte) error {
+	if reader.header.Tag != tag {
+		return fmt.Errorf("snapshot: expected %s chunk, found %s", string(tag[:]), string(reader.header.Tag[:]))
+	}
+	return nil
+}
+
+func (reader *Reader) readJSON(tag [4]byte) (map[string]interface{}, error) {
+	if err := reader.expect(tag); err != nil {
+		return nil, err
+	}
+	if reader.err != nil {
+		return nil, reader.err
+	}
+	if reader.remaining > math.MaxInt64 {
+		reader.err = fmt.Errorf("snapshot: corrupt %s chunk", string(reader.header.Tag[:]))
+		return nil, reader.err
+	}
+
+	// decode straight from the stream rather than allocating the length the
+	// chunk claims, which may be corrupt
+	limited := &io.LimitedReader{ R: reader.r, N: int64(reader.remaining) }
+	var value map[string]interface{}
+	err := json.NewDecoder(limited).Decode(&value)
+	reader.remaining = uint64(limited.N)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// count reads an element count and checks it against the bytes left in the
+// chunk, so a corrupt count can't trigger a huge allocation.
+func (reader *Reader) count(minElementSize uint64) int {
+	count := uint64(reader.uint32())
+	if reader.err == nil && count * minElementSize > reader.remaining {
+		reader.err = fmt.Errorf("snapshot: corrupt %s chunk", string(reader.header.Tag[:]))
+		return 0
+	}
+	return int(count)
+}
+
+func (reader *Reader) unexpected() error {
+	if reader.err == io.EOF {
+		reader.err = io.ErrUnexpectedEOF
+	}
+	return reader.err
+}
+
+func (reader *Reader) read(data []byte) {
+	if reader.err != nil {
+		return
+	}
+	if uint64(len(data)) > reader.remaining {
+		reader.err = fmt.Errorf("snapshot: read past the end of the %s chunk", string(reader.header.Tag[:]))
+		return
+	}
+	_, reader.err = io.ReadFull(reader.r, data)
+	reader.remaining -= uint64(len(data))
+}
+
+func (reader *Reader) uint8() uint8 {
+	reader.read(reader.scratch[:1])
+	return reader.scratch[ 0 ]
+}
+
+func (reader *Reader) uint16() uint16 {
+	reader.read(reader.scratch[:2])
+	return binary.LittleEndian.Uint16(reader.scratch[:2])
+}
+
+func (reader *Reader) uint32() uint32 {
+	reader.read(reader.scratch[:4])
+	return binary.LittleEndian.Uint32(reader.scratch[:4])
+}
+
+func (reader *Reader) int32() int32 {
+	return int32(reader.uint32())
+}
+
+func (reader *Reader) uint64() uint64 {
+	reader.read(reader.scratch[:8])
+	return binary.LittleEndian.Uint64(reader.scratch[:8])
+}
+
+func (reader *Reader) float32() float64 {
+	return float64(math.Float32frombits(reader.uint32()))
+}
+
+func (reader *Reader) bytes() []byte {
+	length := reader.count(1)
+	data := make([]byte, length)
+	reader.read(data)
+	return data
+}
+
+func (reader *Reader) string() string {
+	return string(reader.bytes())
+}
+
+// Decoder rebuilds the object hierarchy of a snapshot one chunk at a time.
+type Decoder struct {
+	Reader *Reader
+	Geometries map[string]*core.Geometry
+	Materials map[string]*materials.Material
+
+	// SkipGeometry and SkipMaterials leave the respective chunks undecoded;
+	// objects referencing them are created without geometry or material.
+	SkipGeometry, SkipMaterials bool
+
+	nodes []interface{}
+	objectLoader *loaders.ObjectLoader
+
+	// nodes referring to other nodes by uuid, e.g. a LOD to its levels, linked
+	// once the whole hierarchy is decoded
+	links []link
+	linked bool
+}
+
+type link struct {
+	object interface{}
+	data map[string]interface{}
+}
+
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Decoder{
+		Reader: reader,
+		Geometries: make(map[string]*core.Geometry),
+		Materials: make(map[string]*materials.Material),
+		nodes: make([]interface{}, 0),
+		objectLoader: loaders.NewObjectLoader(),
+		links: make([]link, 0),
+	}, nil
+}
+
+// Step decodes the next chunk. It returns io.EOF once the whole snapshot has
+// been decoded. LOD levels and the bones of skinned meshes are only set up
+// then, as they refer to nodes decoded after them.
+func (decoder *Decoder) Step() error {
+	header, err := decoder.Reader.Next()
+	if err == io.EOF {
+		decoder.link()
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	switch header.Tag {
+	case MaterialChunk:
+		if decoder.SkipMaterials {
+			return nil
+		}
+		material, err := decoder.Reader.ReadMaterial()
+		if err != nil {
+			return err
+		}
+		decoder.Materials[ material.Uuid ] = material
+
+	case GeometryChunk:
+		if decoder.SkipGeometry {
+			return nil
+		}
+		geometry, err := decoder.Reader.ReadGeometry()
+		if err != nil {
+			return err
+		}
+		decoder.Geometries[ geometry.Uuid ] = geometry
+
+	case NodeChunk:
+		parent, data, err := decoder.Reader.ReadNode()
+		if err != nil {
+			return err
+		}
+		if decoder.SkipGeometry {
+			delete(data, "geometry")
+		}
+		if decoder.SkipMaterials {
+			delete(data, "material")
+		}
+		// the children of the node follow it, so leave the references to them for later
+		nodeData := data
+		if _, hasLevels := data["levels"]; hasLevels {
+			nodeData = withoutKey( data, "levels" )
+		} else if _, hasBones := data["bones"]; hasBones {
+			nodeData = withoutKey( data, "bones" )
+		}
+		object := decoder.objectLoader.ParseObject( nodeData, decoder.Geometries, decoder.Materials )
+		if len(nodeData) != len(data) {
+			decoder.links = append(decoder.links, link{ object: object, data: data })
+		}
+		if parent >= 0 {
+			if int(parent) >= len(decoder.nodes) {
+				return fmt.Errorf("snapshot: node %d references unknown parent %d", len(decoder.nodes), parent)
+			}
+			loaders.GetObject3D( decoder.nodes[ parent ] ).Add( loaders.GetObject3D( object ) )
+		}
+		decoder.nodes = append(decoder.nodes, object)
+
+	default:
+		// unknown chunks from newer writers are skipped by the next call to Next
+	}
+
+	return nil
+}
+
+// link resolves the references between nodes, once the hierarchy is complete.
+func (decoder *Decoder) link() {
+	if decoder.linked || len(decoder.nodes) == 0 {
+		return
+	}
+	decoder.linked = true
+
+	root := loaders.GetObject3D( decoder.nodes[ 0 ] )
+	for _, l := range decoder.links {
+		decoder.objectLoader.LinkObject( l.object, l.data, root )
+	}
+}
+
+func withoutKey(data map[string]interface{}, key string) (map[string]interface{}) {
+	copied := make(map[string]interface{}, len(data))
+	for k, value := range data {
+		if k != key {
+			copied[ k ] = value
+		}
+	}
+	return copied
+}
+
+// Root returns the root object decoded so far, or nil before the first node chunk.
+func (decoder *Decoder) Root() (interface{}) {
+	if len(decoder.nodes) == 0 {
+		return nil
+	}
+	return decoder.nodes[ 0 ]
+}
+
+// Decode runs Step until the snapshot is fully decoded and returns the root object.
+func (decoder *Decoder) Decode() (interface{}, error) {
+	for {
+		if err := decoder.Step(); err == io.EOF {
+			return decoder.Root(), nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ReadScene decodes a snapshot written by Writer.WriteScene.
+func ReadScene(r io.Reader) (*scenes.Scene, error) {
+	decoder, err := NewDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+	root, err := decoder.Decode()
+	if err != nil {
+		return nil, err
+	}
+	scene, ok := root.(*scenes.Scene)
+	if !ok {
+		return nil, fmt.Errorf("snapshot: root object is %T, not a scene", root)
+	}
+	return scene, nil
+}
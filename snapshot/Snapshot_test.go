@@ -0,0 +1,197 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/uzudil/three.go/core"
+	"github.com/uzudil/three.go/materials"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/objects"
+	"github.com/uzudil/three.go/scenes"
+)
+
+func triangle() (*core.Geometry) {
+	geometry := core.NewGeometry()
+	geometry.Vertices = append(geometry.Vertices,
+		math3d.NewVector3( 0, 0, 0 ),
+		math3d.NewVector3( 1, 0, 0 ),
+		math3d.NewVector3( 0, 1, 0 ),
+	)
+	geometry.Faces = append(geometry.Faces, core.NewDefaultFace3( 0, 1, 2 ))
+	geometry.ComputeFaceNormals()
+	return geometry
+}
+
+func roundTrip(t *testing.T, scene *scenes.Scene) (*scenes.Scene) {
+	var buffer bytes.Buffer
+	if err := NewWriter(&buffer).WriteScene(scene); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadScene(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loaded
+}
+
+func TestRoundTripMesh(t *testing.T) {
+	material := materials.NewMeshBasicMaterial(nil)
+	material.Color.SetHex( 0x00ff00 )
+
+	mesh := objects.NewMesh( triangle(), material.Material )
+	mesh.Name = "triangle"
+	mesh.Position.Set( 1, 2, 3 )
+
+	scene := scenes.NewScene()
+	scene.Add( mesh.Object3D )
+
+	loaded := roundTrip(t, scene)
+
+	if len(loaded.Children) != 1 {
+		t.Fatalf("got %d children, want 1", len(loaded.Children))
+	}
+	loadedMesh, ok := loaded.Children[ 0 ].Self.(*objects.Mesh)
+	if !ok {
+		t.Fatalf("got %T, want *objects.Mesh", loaded.Children[ 0 ].Self)
+	}
+	if loadedMesh.Name != "triangle" || !loadedMesh.Position.Equals( mesh.Position ) {
+		t.Errorf("got %q at %v, want %q at %v", loadedMesh.Name, loadedMesh.Position, mesh.Name, mesh.Position)
+	}
+	if len(loadedMesh.Geometry.Vertices) != 3 || len(loadedMesh.Geometry.Faces) != 1 {
+		t.Errorf("got %d vertices and %d faces, want 3 and 1", len(loadedMesh.Geometry.Vertices), len(loadedMesh.Geometry.Faces))
+	}
+	if basic, ok := loadedMesh.Material.Self.(*materials.MeshBasicMaterial); !ok || basic.Color.GetHex() != 0x00ff00 {
+		t.Errorf("got material %T, want a green *materials.MeshBasicMaterial", loadedMesh.Material.Self)
+	}
+}
+
+func TestRoundTripLOD(t *testing.T) {
+	material := materials.NewMeshBasicMaterial(nil)
+
+	near := objects.NewMesh( triangle(), material.Material )
+	far := objects.NewMesh( triangle(), material.Material )
+
+	lod := objects.NewLOD()
+	lod.AddLevel( near.Object3D, 0 )
+	lod.AddLevel( far.Object3D, 50 )
+
+	scene := scenes.NewScene()
+	scene.Add( lod.Object3D )
+
+	loaded := roundTrip(t, scene)
+
+	loadedLOD, ok := loaded.Children[ 0 ].Self.(*objects.LOD)
+	if !ok {
+		t.Fatalf("got %T, want *objects.LOD", loaded.Children[ 0 ].Self)
+	}
+	if len(loadedLOD.Levels) != 2 {
+		t.Fatalf("got %d levels, want 2", len(loadedLOD.Levels))
+	}
+	if loadedLOD.Levels[ 0 ].Object.Uuid != near.Uuid || loadedLOD.Levels[ 1 ].Object.Uuid != far.Uuid {
+		t.Error("levels are not the near and far meshes")
+	}
+	if loadedLOD.Levels[ 1 ].Distance != 50 {
+		t.Errorf("far level at %v, want 50", loadedLOD.Levels[ 1 ].Distance)
+	}
+	if len(loadedLOD.Children) != 2 {
+		t.Errorf("got %d children, want 2", len(loadedLOD.Children))
+	}
+}
+
+func TestRoundTripSkinnedMesh(t *testing.T) {
+	geometry := triangle()
+	geometry.Bones = append(geometry.Bones,
+		&core.GeometryBone{ Name: "root", Parent: -1, Position: math3d.NewEmptyVector3(), Quaternion: math3d.NewEmptyQuaternion(), Scale: math3d.NewVector3( 1, 1, 1 ) },
+		&core.GeometryBone{ Name: "tip", Parent: 0, Position: math3d.NewVector3( 0, 1, 0 ), Quaternion: math3d.NewEmptyQuaternion(), Scale: math3d.NewVector3( 1, 1, 1 ) },
+	)
+	for i := range geometry.Vertices {
+		geometry.SkinIndices = append(geometry.SkinIndices, math3d.NewVector4( float64( i % 2 ), 0, 0, 0 ))
+		geometry.SkinWeights = append(geometry.SkinWeights, math3d.NewVector4( 1, 0, 0, 0 ))
+	}
+
+	mesh := objects.NewSkinnedMesh( geometry, materials.NewMeshBasicMaterial(nil).Material )
+
+	scene := scenes.NewScene()
+	scene.Add( mesh.Object3D )
+
+	loaded := roundTrip(t, scene)
+
+	loadedMesh, ok := loaded.Children[ 0 ].Self.(*objects.SkinnedMesh)
+	if !ok {
+		t.Fatalf("got %T, want *objects.SkinnedMesh", loaded.Children[ 0 ].Self)
+	}
+
+	bones := loadedMesh.Skeleton.Bones
+	if len(bones) != 2 {
+		t.Fatalf("got %d bones, want 2", len(bones))
+	}
+	for b, bone := range bones {
+		if bone == nil {
+			t.Fatalf("bone %d is missing", b)
+		}
+		if bone.Uuid != mesh.Skeleton.Bones[ b ].Uuid {
+			t.Errorf("bone %d is %s, want %s", b, bone.Name, mesh.Skeleton.Bones[ b ].Name)
+		}
+		if bone.Skin != loadedMesh {
+			t.Errorf("bone %d is not bound to the mesh", b)
+		}
+	}
+	if bones[ 1 ].Parent != bones[ 0 ].Object3D {
+		t.Error("tip bone is not a child of the root bone")
+	}
+	if len(loadedMesh.Geometry.SkinIndices) != 3 || len(loadedMesh.Geometry.SkinWeights) != 3 {
+		t.Errorf("got %d skin indices and %d skin weights, want 3 and 3", len(loadedMesh.Geometry.SkinIndices), len(loadedMesh.Geometry.SkinWeights))
+	}
+	if len(loadedMesh.Skeleton.BoneInverses) != 2 {
+		t.Errorf("got %d bone inverses, want 2", len(loadedMesh.Skeleton.BoneInverses))
+	}
+}
+
+func TestRoundTripMorphTargets(t *testing.T) {
+	geometry := triangle()
+	geometry.MorphTargets = append(geometry.MorphTargets, &core.MorphTarget{
+		Name: "raised",
+		Vertices: []*math3d.Vector3{
+			math3d.NewVector3( 0, 0, 1 ),
+			math3d.NewVector3( 1, 0, 1 ),
+			math3d.NewVector3( 0, 1, 1 ),
+		},
+	})
+	geometry.ComputeMorphNormals()
+
+	scene := scenes.NewScene()
+	scene.Add( objects.NewMesh( geometry, materials.NewMeshBasicMaterial(nil).Material ).Object3D )
+
+	loaded := roundTrip(t, scene).Children[ 0 ].Geometry
+
+	if len(loaded.MorphTargets) != 1 || len(loaded.MorphNormals) != 1 {
+		t.Fatalf("got %d morph targets and %d morph normals, want 1 and 1", len(loaded.MorphTargets), len(loaded.MorphNormals))
+	}
+	morphTarget := loaded.MorphTargets[ 0 ]
+	if morphTarget.Name != "raised" || len(morphTarget.Vertices) != 3 || !morphTarget.Vertices[ 2 ].Equals( geometry.MorphTargets[ 0 ].Vertices[ 2 ] ) {
+		t.Errorf("got morph target %q with %d vertices, want %q with 3", morphTarget.Name, len(morphTarget.Vertices), "raised")
+	}
+	morphNormals := loaded.MorphNormals[ 0 ]
+	if len(morphNormals.FaceNormals) != 1 || len(morphNormals.VertexNormals) != 1 {
+		t.Fatalf("got %d face normals and %d vertex normals, want 1 and 1", len(morphNormals.FaceNormals), len(morphNormals.VertexNormals))
+	}
+	if !morphNormals.VertexNormals[ 0 ][ 1 ].Equals( geometry.MorphNormals[ 0 ].VertexNormals[ 0 ][ 1 ] ) {
+		t.Errorf("got vertex normal %v, want %v", morphNormals.VertexNormals[ 0 ][ 1 ], geometry.MorphNormals[ 0 ].VertexNormals[ 0 ][ 1 ])
+	}
+}
+
+func TestReadRejectsHugeChunk(t *testing.T) {
+	var buffer bytes.Buffer
+	buffer.Write(Magic[:])
+	binary.Write(&buffer, binary.LittleEndian, Version)
+	binary.Write(&buffer, binary.LittleEndian, uint16(0))
+	buffer.Write(MaterialChunk[:])
+	binary.Write(&buffer, binary.LittleEndian, uint64(1) << 62)
+	buffer.WriteString(`{ "type": "MeshBasicMaterial"`)
+
+	if _, err := ReadScene(&buffer); err == nil {
+		t.Fatal("no error")
+	}
+}
@@ -0,0 +1,324 @@
+package snapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/uzudil/three.go/core"
+	math3d "github.com/uzudil/three.go/math"
+	"github.com/uzudil/three.go/scenes"
+)
+
+type Writer struct {
+	w *bufio.Writer
+	err error
+	scratch [8]byte
+}
+
+func NewWriter(w io.Writer) (*Writer) {
+	return &Writer{
+		w: bufio.NewWriter(w),
+	}
+}
+
+// WriteScene writes a complete snapshot of scene and flushes the underlying writer.
+func (writer *Writer) WriteScene(scene *scenes.Scene) error {
+	return writer.WriteObject(scene.Object3D)
+}
+
+// WriteObject writes a complete snapshot of the hierarchy below object.
+func (writer *Writer) WriteObject(object *core.Object3D) error {
+
+	writer.write(Magic[:])
+	writer.uint16(Version)
+	writer.uint16(0)
+
+	// geometries are written in binary below, mark them as known so the
+	// json serialization of the nodes only references them by uuid
+	meta := core.NewJSONMeta()
+	geometries := make([]*core.Geometry, 0)
+	collectGeometries(object, meta, &geometries)
+
+	root := object.ToJSONObject(meta)
+
+	for _, material := range meta.Materials {
+		delete(material, "metadata")
+		writer.jsonChunk(MaterialChunk, material)
+	}
+
+	for _, geometry := range geometries {
+		writer.geometryChunk(geometry)
+	}
+
+	writer.nodeChunks(root, -1, new(int32))
+
+	writer.chunkHeader(EndChunk, 0)
+
+	if writer.err != nil {
+		return writer.err
+	}
+	return writer.w.Flush()
+}
+
+func collectGeometries(object *core.Object3D, meta *core.JSONMeta, geometries *[]*core.Geometry) {
+	if object.Geometry != nil {
+		if _, ok := meta.Geometries[ object.Geometry.Uuid ]; !ok {
+			meta.Geometries[ object.Geometry.Uuid ] = map[string]interface{}{}
+			*geometries = append(*geometries, object.Geometry)
+		}
+	}
+	for _, child := range object.Children {
+		collectGeometries(child, meta, geometries)
+	}
+}
+
+// nodeChunks writes object and its descendants, one chunk per node.
+func (writer *Writer) nodeChunks(object map[string]interface{}, parent int32, count *int32) {
+	index := *count
+	*count++
+
+	children, _ := object["children"].([]interface{})
+	delete(object, "children")
+
+	data, err := json.Marshal(object)
+	if err != nil && writer.err == nil {
+		writer.err = err
+	}
+
+	writer.chunkHeader(NodeChunk, uint64(4 + len(data)))
+	writer.int32(parent)
+	writer.write(data)
+
+	for _, child := range children {
+		writer.nodeChunks(child.(map[string]interface{}), index, count)
+	}
+}
+
+func (writer *Writer) jsonChunk(tag [4]byte, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil && writer.err == nil {
+		writer.err = err
+	}
+	writer.chunkHeader(tag, uint64(len(data)))
+	writer.write(data)
+}
+
+func (writer *Writer) geometryChunk(geometry *core.Geometry) {
+
+	parameters := []byte{}
+	if geometry.Parameters != nil {
+		var err error
+		parameters, err = json.Marshal(geometry.Parameters)
+		if err != nil && writer.err == nil {
+			writer.err = err
+		}
+	}
+
+	writer.chunkHeader(GeometryChunk, geometryChunkLength(geometry, parameters))
+
+	writer.string(geometry.Uuid)
+	writer.string(geometry.Name)
+	writer.string(geometry.Type)
+	writer.bytes(parameters)
+
+	writer.vector3s(geometry.Vertices)
+
+	color := make([]float64, 3)
+
+	writer.uint32(uint32(len(geometry.Faces)))
+	for _, face := range geometry.Faces {
+		flags := uint8(0)
+		if len(face.VertexNormals) == 3 {
+			flags |= faceHasVertexNormals
+		}
+		if len(face.VertexColors) == 3 {
+			flags |= faceHasVertexColors
+		}
+
+		writer.uint32(uint32(face.A))
+		writer.uint32(uint32(face.B))
+		writer.uint32(uint32(face.C))
+		writer.int32(int32(face.MaterialIndex))
+		writer.uint8(flags)
+
+		writer.float32(face.Normal.X)
+		writer.float32(face.Normal.Y)
+		writer.float32(face.Normal.Z)
+
+		face.Color.ToArray(color, 0)
+		writer.float32(color[ 0 ])
+		writer.float32(color[ 1 ])
+		writer.float32(color[ 2 ])
+
+		if flags & faceHasVertexNormals != 0 {
+			for _, normal := range face.VertexNormals {
+				writer.float32(normal.X)
+				writer.float32(normal.Y)
+				writer.float32(normal.Z)
+			}
+		}
+
+		if flags & faceHasVertexColors != 0 {
+			for _, vertexColor := range face.VertexColors {
+				vertexColor.ToArray(color, 0)
+				writer.float32(color[ 0 ])
+				writer.float32(color[ 1 ])
+				writer.float32(color[ 2 ])
+			}
+		}
+	}
+
+	writer.uint32(uint32(len(geometry.FaceVertexUvs)))
+	for _, layer := range geometry.FaceVertexUvs {
+		writer.uint32(uint32(len(layer)))
+		for _, uvs := range layer {
+			if len(uvs) != 3 {
+				writer.uint8(0)
+				continue
+			}
+			writer.uint8(1)
+			for _, uv := range uvs {
+				writer.float32(uv.X)
+				writer.float32(uv.Y)
+			}
+		}
+	}
+
+	writer.uint32(uint32(len(geometry.MorphTargets)))
+	for _, morphTarget := range geometry.MorphTargets {
+		writer.string(morphTarget.Name)
+		writer.vector3s(morphTarget.Vertices)
+	}
+
+	writer.uint32(uint32(len(geometry.MorphNormals)))
+	for _, morphNormals := range geometry.MorphNormals {
+		writer.vector3s(morphNormals.FaceNormals)
+		writer.uint32(uint32(len(morphNormals.VertexNormals)))
+		for _, normals := range morphNormals.VertexNormals {
+			for _, normal := range normals {
+				writer.float32(normal.X)
+				writer.float32(normal.Y)
+				writer.float32(normal.Z)
+			}
+		}
+	}
+
+	writer.vector4s(geometry.SkinIndices)
+	writer.vector4s(geometry.SkinWeights)
+}
+
+func (writer *Writer) vector3s(vectors []*math3d.Vector3) {
+	writer.uint32(uint32(len(vectors)))
+	for _, v := range vectors {
+		writer.float32(v.X)
+		writer.float32(v.Y)
+		writer.float32(v.Z)
+	}
+}
+
+func (writer *Writer) vector4s(vectors []*math3d.Vector4) {
+	writer.uint32(uint32(len(vectors)))
+	for _, v := range vectors {
+		writer.float32(v.X)
+		writer.float32(v.Y)
+		writer.float32(v.Z)
+		writer.float32(v.W)
+	}
+}
+
+// geometryChunkLength computes the payload size up front, so large
+// geometries can be streamed without being buffered first.
+func geometryChunkLength(geometry *core.Geometry, parameters []byte) uint64 {
+	length := uint64(4 + len(geometry.Uuid) + 4 + len(geometry.Name) + 4 + len(geometry.Type) + 4 + len(parameters))
+
+	length += 4 + uint64(len(geometry.Vertices)) * 12
+
+	length += 4
+	for _, face := range geometry.Faces {
+		length += 3 * 4 + 4 + 1 + 12 + 12
+		if len(face.VertexNormals) == 3 {
+			length += 36
+		}
+		if len(face.VertexColors) == 3 {
+			length += 36
+		}
+	}
+
+	length += 4
+	for _, layer := range geometry.FaceVertexUvs {
+		length += 4
+		for _, uvs := range layer {
+			length += 1
+			if len(uvs) == 3 {
+				length += 24
+			}
+		}
+	}
+
+	length += 4
+	for _, morphTarget := range geometry.MorphTargets {
+		length += 4 + uint64(len(morphTarget.Name)) + 4 + uint64(len(morphTarget.Vertices)) * 12
+	}
+
+	length += 4
+	for _, morphNormals := range geometry.MorphNormals {
+		length += 4 + uint64(len(morphNormals.FaceNormals)) * 12 + 4 + uint64(len(morphNormals.VertexNormals)) * 36
+	}
+
+	length += 4 + uint64(len(geometry.SkinIndices)) * 16
+	length += 4 + uint64(len(geometry.SkinWeights)) * 16
+
+	return length
+}
+
+func (writer *Writer) chunkHeader(tag [4]byte, length uint64) {
+	writer.write(tag[:])
+	writer.uint64(length)
+}
+
+func (writer *Writer) write(data []byte) {
+	if writer.err != nil {
+		return
+	}
+	_, writer.err = writer.w.Write(data)
+}
+
+func (writer *Writer) uint8(value uint8) {
+	writer.scratch[ 0 ] = value
+	writer.write(writer.scratch[:1])
+}
+
+func (writer *Writer) uint16(value uint16) {
+	binary.LittleEndian.PutUint16(writer.scratch[:2], value)
+	writer.write(writer.scratch[:2])
+}
+
+func (writer *Writer) uint32(value uint32) {
+	binary.LittleEndian.PutUint32(writer.scratch[:4], value)
+	writer.write(writer.scratch[:4])
+}
+
+func (writer *Writer) int32(value int32) {
+	writer.uint32(uint32(value))
+}
+
+func (writer *Writer) uint64(value uint64) {
+	binary.LittleEndian.PutUint64(writer.scratch[:8], value)
+	writer.write(writer.scratch[:8])
+}
+
+func (writer *Writer) float32(value float64) {
+	writer.uint32(math.Float32bits(float32(value)))
+}
+
+func (writer *Writer) bytes(data []byte) {
+	writer.uint32(uint32(len(data)))
+	writer.write(data)
+}
+
+func (writer *Writer) string(value string) {
+	writer.bytes([]byte(value))
+}
@@ -183,6 +183,17 @@ var LoopOnce int = 2200
 var LoopRepeat int = 2201
 var LoopPingPong int = 2202
 
+// Interpolation modes for KeyframeTrack
+
+var InterpolateDiscrete int = 2300
+var InterpolateLinear int = 2301
+var InterpolateSmooth int = 2302
+
+// Bind modes for SkinnedMesh
+
+var AttachedMode string = "attached"
+var DetachedMode string = "detached"
+
 // credit: http://stackoverflow.com/questions/26744873/converting-map-to-struct
 func SetField(obj interface{}, name string, value interface{}) error {
 	structValue := reflect.ValueOf(obj).Elem()
@@ -0,0 +1,230 @@
+package tween
+
+import (
+	"math"
+)
+
+/**
+ * Easing equations by Robert Penner, as in tween.js
+ *
+ * @author sole / http://soledadpenades.com
+ * @author mrdoob / http://mrdoob.com
+ */
+
+// EasingFunction maps the elapsed part of a tween, from 0 to 1, to how far the
+// values have moved from the start to the end values. Elastic and Back easings
+// overshoot past 0 and 1.
+type EasingFunction func(k float64) float64
+
+func Linear(k float64) float64 {
+	return k
+}
+
+func QuadraticIn(k float64) float64 {
+	return k * k
+}
+
+func QuadraticOut(k float64) float64 {
+	return k * ( 2 - k )
+}
+
+func QuadraticInOut(k float64) float64 {
+	k *= 2
+	if k < 1 {
+		return 0.5 * k * k
+	}
+	k --
+	return - 0.5 * ( k * ( k - 2 ) - 1 )
+}
+
+func CubicIn(k float64) float64 {
+	return k * k * k
+}
+
+func CubicOut(k float64) float64 {
+	k --
+	return k * k * k + 1
+}
+
+func CubicInOut(k float64) float64 {
+	k *= 2
+	if k < 1 {
+		return 0.5 * k * k * k
+	}
+	k -= 2
+	return 0.5 * ( k * k * k + 2 )
+}
+
+func QuarticIn(k float64) float64 {
+	return k * k * k * k
+}
+
+func QuarticOut(k float64) float64 {
+	k --
+	return 1 - k * k * k * k
+}
+
+func QuarticInOut(k float64) float64 {
+	k *= 2
+	if k < 1 {
+		return 0.5 * k * k * k * k
+	}
+	k -= 2
+	return - 0.5 * ( k * k * k * k - 2 )
+}
+
+func QuinticIn(k float64) float64 {
+	return k * k * k * k * k
+}
+
+func QuinticOut(k float64) float64 {
+	k --
+	return k * k * k * k * k + 1
+}
+
+func QuinticInOut(k float64) float64 {
+	k *= 2
+	if k < 1 {
+		return 0.5 * k * k * k * k * k
+	}
+	k -= 2
+	return 0.5 * ( k * k * k * k * k + 2 )
+}
+
+func SinusoidalIn(k float64) float64 {
+	return 1 - math.Cos( k * math.Pi / 2 )
+}
+
+func SinusoidalOut(k float64) float64 {
+	return math.Sin( k * math.Pi / 2 )
+}
+
+func SinusoidalInOut(k float64) float64 {
+	return 0.5 * ( 1 - math.Cos( math.Pi * k ) )
+}
+
+func ExponentialIn(k float64) float64 {
+	if k == 0 {
+		return 0
+	}
+	return math.Pow( 1024, k - 1 )
+}
+
+func ExponentialOut(k float64) float64 {
+	if k == 1 {
+		return 1
+	}
+	return 1 - math.Pow( 2, - 10 * k )
+}
+
+func ExponentialInOut(k float64) float64 {
+	if k == 0 {
+		return 0
+	}
+	if k == 1 {
+		return 1
+	}
+	k *= 2
+	if k < 1 {
+		return 0.5 * math.Pow( 1024, k - 1 )
+	}
+	return 0.5 * ( - math.Pow( 2, - 10 * ( k - 1 ) ) + 2 )
+}
+
+func CircularIn(k float64) float64 {
+	return 1 - math.Sqrt( 1 - k * k )
+}
+
+func CircularOut(k float64) float64 {
+	k --
+	return math.Sqrt( 1 - k * k )
+}
+
+func CircularInOut(k float64) float64 {
+	k *= 2
+	if k < 1 {
+		return - 0.5 * ( math.Sqrt( 1 - k * k ) - 1 )
+	}
+	k -= 2
+	return 0.5 * ( math.Sqrt( 1 - k * k ) + 1 )
+}
+
+func ElasticIn(k float64) float64 {
+	if k == 0 {
+		return 0
+	}
+	if k == 1 {
+		return 1
+	}
+	return - math.Pow( 2, 10 * ( k - 1 ) ) * math.Sin( ( k - 1.1 ) * 5 * math.Pi )
+}
+
+func ElasticOut(k float64) float64 {
+	if k == 0 {
+		return 0
+	}
+	if k == 1 {
+		return 1
+	}
+	return math.Pow( 2, - 10 * k ) * math.Sin( ( k - 0.1 ) * 5 * math.Pi ) + 1
+}
+
+func ElasticInOut(k float64) float64 {
+	if k == 0 {
+		return 0
+	}
+	if k == 1 {
+		return 1
+	}
+	k *= 2
+	if k < 1 {
+		return - 0.5 * math.Pow( 2, 10 * ( k - 1 ) ) * math.Sin( ( k - 1.1 ) * 5 * math.Pi )
+	}
+	return 0.5 * math.Pow( 2, - 10 * ( k - 1 ) ) * math.Sin( ( k - 1.1 ) * 5 * math.Pi ) + 1
+}
+
+func BackIn(k float64) float64 {
+	s := 1.70158
+	return k * k * ( ( s + 1 ) * k - s )
+}
+
+func BackOut(k float64) float64 {
+	s := 1.70158
+	k --
+	return k * k * ( ( s + 1 ) * k + s ) + 1
+}
+
+func BackInOut(k float64) float64 {
+	s := 1.70158 * 1.525
+	k *= 2
+	if k < 1 {
+		return 0.5 * ( k * k * ( ( s + 1 ) * k - s ) )
+	}
+	k -= 2
+	return 0.5 * ( k * k * ( ( s + 1 ) * k + s ) + 2 )
+}
+
+func BounceIn(k float64) float64 {
+	return 1 - BounceOut( 1 - k )
+}
+
+func BounceOut(k float64) float64 {
+	if k < ( 1 / 2.75 ) {
+		return 7.5625 * k * k
+	} else if k < ( 2 / 2.75 ) {
+		k -= 1.5 / 2.75
+		return 7.5625 * k * k + 0.75
+	} else if k < ( 2.5 / 2.75 ) {
+		k -= 2.25 / 2.75
+		return 7.5625 * k * k + 0.9375
+	}
+	k -= 2.625 / 2.75
+	return 7.5625 * k * k + 0.984375
+}
+
+func BounceInOut(k float64) float64 {
+	if k < 0.5 {
+		return BounceIn( k * 2 ) * 0.5
+	}
+	return BounceOut( k * 2 - 1 ) * 0.5 + 0.5
+}
@@ -0,0 +1,58 @@
+package tween
+
+import (
+	"math"
+	"testing"
+)
+
+var easings = map[string]EasingFunction{
+	"Linear": Linear,
+	"QuadraticIn": QuadraticIn, "QuadraticOut": QuadraticOut, "QuadraticInOut": QuadraticInOut,
+	"CubicIn": CubicIn, "CubicOut": CubicOut, "CubicInOut": CubicInOut,
+	"QuarticIn": QuarticIn, "QuarticOut": QuarticOut, "QuarticInOut": QuarticInOut,
+	"QuinticIn": QuinticIn, "QuinticOut": QuinticOut, "QuinticInOut": QuinticInOut,
+	"SinusoidalIn": SinusoidalIn, "SinusoidalOut": SinusoidalOut, "SinusoidalInOut": SinusoidalInOut,
+	"ExponentialIn": ExponentialIn, "ExponentialOut": ExponentialOut, "ExponentialInOut": ExponentialInOut,
+	"CircularIn": CircularIn, "CircularOut": CircularOut, "CircularInOut": CircularInOut,
+	"ElasticIn": ElasticIn, "ElasticOut": ElasticOut, "ElasticInOut": ElasticInOut,
+	"BackIn": BackIn, "BackOut": BackOut, "BackInOut": BackInOut,
+	"BounceIn": BounceIn, "BounceOut": BounceOut, "BounceInOut": BounceInOut,
+}
+
+func TestEasingEndpoints(t *testing.T) {
+	for name, easing := range easings {
+		if k := easing( 0 ); math.Abs( k ) > 1e-9 {
+			t.Errorf("%s( 0 ) = %v, want 0", name, k)
+		}
+		if k := easing( 1 ); math.Abs( k - 1 ) > 1e-9 {
+			t.Errorf("%s( 1 ) = %v, want 1", name, k)
+		}
+	}
+}
+
+func TestEasingInOutMidpoint(t *testing.T) {
+	for name, easing := range easings {
+		if name != "Linear" && name[ len(name) - 5: ] != "InOut" {
+			continue
+		}
+		if k := easing( 0.5 ); math.Abs( k - 0.5 ) > 1e-9 {
+			t.Errorf("%s( 0.5 ) = %v, want 0.5", name, k)
+		}
+	}
+}
+
+func TestEasingInIsMirroredOut(t *testing.T) {
+	pairs := [][2]EasingFunction{
+		{ QuadraticIn, QuadraticOut },
+		{ CubicIn, CubicOut },
+		{ SinusoidalIn, SinusoidalOut },
+		{ BounceIn, BounceOut },
+	}
+	for i, pair := range pairs {
+		for _, k := range []float64{ 0.1, 0.3, 0.7 } {
+			if in, out := pair[ 0 ]( k ), 1 - pair[ 1 ]( 1 - k ); math.Abs( in - out ) > 1e-9 {
+				t.Errorf("pair %d at %v: in %v, mirrored out %v", i, k, in, out)
+			}
+		}
+	}
+}
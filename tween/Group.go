@@ -0,0 +1,82 @@
+package tween
+
+// Group runs tweens. Call Update once a frame with the time passed, e.g.
+// from core.Clock.GetDelta.
+type Group struct {
+	// Time is the time of the group in seconds, the sum of all Update deltas.
+	Time float64
+
+	tweens []*Tween
+}
+
+var defaultGroup = NewGroup()
+
+func NewGroup() (*Group) {
+	return &Group{
+		tweens: make([]*Tween, 0),
+	}
+}
+
+// DefaultGroup returns the group tweens run in when created without one.
+func DefaultGroup() (*Group) {
+	return defaultGroup
+}
+
+// Update advances the default group by deltaTime seconds.
+func Update(deltaTime float64) {
+	defaultGroup.Update( deltaTime )
+}
+
+// Update advances the group by deltaTime seconds and updates its playing
+// tweens. Tweens that complete are removed.
+func (g *Group) Update(deltaTime float64) {
+	g.Time += deltaTime
+
+	// on a copy as callbacks may start and stop tweens
+
+	for _, tween := range append([]*Tween{}, g.tweens...) {
+		if g.indexOf( tween ) == -1 {
+			continue
+		}
+
+		tween.update( g.Time )
+
+		if !tween.playing {
+			g.remove( tween )
+		}
+	}
+}
+
+// GetAll returns the tweens playing in the group.
+func (g *Group) GetAll() ([]*Tween) {
+	return append([]*Tween{}, g.tweens...)
+}
+
+// RemoveAll stops all tweens of the group, without calling OnStop.
+func (g *Group) RemoveAll() {
+	for _, tween := range g.tweens {
+		tween.playing = false
+	}
+	g.tweens = g.tweens[ :0 ]
+}
+
+func (g *Group) indexOf(tween *Tween) int {
+	for i, t := range g.tweens {
+		if t == tween {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Group) add(tween *Tween) {
+	if g.indexOf( tween ) == -1 {
+		g.tweens = append(g.tweens, tween)
+	}
+}
+
+func (g *Group) remove(tween *Tween) {
+	if i := g.indexOf( tween ); i != -1 {
+		g.tweens = append(g.tweens[ :i ], g.tweens[ i + 1: ]...)
+	}
+}
@@ -0,0 +1,266 @@
+package tween
+
+import (
+	"math"
+
+	math3d "github.com/uzudil/three.go/math"
+)
+
+/**
+ * Tween.js - Licensed under the MIT license
+ * https://github.com/tweenjs/tween.js
+ *
+ * @author sole / http://soledadpenades.com
+ * @author mrdoob / http://mrdoob.com
+ */
+
+// Tween moves values, e.g. the position and quaternion of a camera, to end
+// values over Duration seconds:
+//
+//	tween.NewTween( 2, nil ).
+//		ToVector3( camera.Position, target ).
+//		ToQuaternion( camera.Quaternion, orientation ).
+//		Start()
+//
+// The tween starts from the values the targets have when it begins to play,
+// after Delay, and runs while its Group is updated.
+type Tween struct {
+	group *Group
+
+	// Duration is the length of one run in seconds.
+	Duration float64
+
+	// Delay is the seconds to wait before each run.
+	Delay float64
+
+	// Repeat is how many times the tween runs again after the first run,
+	// +Inf for no end. With Yoyo every other run goes from the end values
+	// back to the start values.
+	Repeat float64
+	Yoyo bool
+
+	// Easing shapes the motion, Linear by default.
+	Easing EasingFunction
+
+	// OnStart is called when the tween begins to play, after Delay.
+	OnStart func(tween *Tween)
+
+	// OnUpdate is called each update after the values are set, with how far
+	// they have moved, after Easing.
+	OnUpdate func(tween *Tween, k float64)
+
+	// OnRepeat is called when the tween starts another run.
+	OnRepeat func(tween *Tween)
+
+	// OnComplete is called when the last run has finished, before the chained
+	// tweens are started.
+	OnComplete func(tween *Tween)
+
+	// OnStop is called when the tween is stopped with Stop.
+	OnStop func(tween *Tween)
+
+	properties []*property
+	chainedTweens []*Tween
+
+	playing bool
+	started bool
+	reversed bool
+	startTime float64
+	repeatsLeft float64
+}
+
+// property is a value the tween moves.
+type property struct {
+	// begin reads the start value from the target.
+	begin func()
+
+	// set sets the target to the value at k, the start value at 0 and the end
+	// value at 1.
+	set func(k float64)
+}
+
+// NewTween returns a tween that runs in group, or in the default group if it
+// is nil. Add the values to move with the To methods, then Start it.
+func NewTween(duration float64, group *Group) (*Tween) {
+	if group == nil {
+		group = defaultGroup
+	}
+
+	return &Tween{
+		group: group,
+		Duration: duration,
+		Easing: Linear,
+		properties: make([]*property, 0),
+		chainedTweens: make([]*Tween, 0),
+	}
+}
+
+// ToVector3 moves target to a copy of to.
+func (t *Tween) ToVector3(target, to *math3d.Vector3) (*Tween) {
+	start := target.Clone()
+	end := to.Clone()
+
+	t.properties = append(t.properties, &property{
+		begin: func() { start.Copy( target ) },
+		set: func(k float64) { target.Copy( start ).Lerp( end, k ) },
+	})
+
+	return t
+}
+
+// ToQuaternion rotates target to a copy of to, by spherical interpolation.
+func (t *Tween) ToQuaternion(target, to *math3d.Quaternion) (*Tween) {
+	start := target.Clone()
+	end := to.Clone()
+
+	t.properties = append(t.properties, &property{
+		begin: func() { start.Copy( target ) },
+		set: func(k float64) { math3d.SlerpQuaternions( start, end, target, k ) },
+	})
+
+	return t
+}
+
+// ToColor moves target to a copy of to.
+func (t *Tween) ToColor(target, to *math3d.Color) (*Tween) {
+	start := target.Clone()
+	end := to.Clone()
+
+	t.properties = append(t.properties, &property{
+		begin: func() { start.Copy( target ) },
+		set: func(k float64) { target.Copy( start ).Lerp( end, k ) },
+	})
+
+	return t
+}
+
+// ToFloat moves the value target points to, e.g. &material.Opacity, to to.
+func (t *Tween) ToFloat(target *float64, to float64) (*Tween) {
+	start := *target
+
+	t.properties = append(t.properties, &property{
+		begin: func() { start = *target },
+		set: func(k float64) { *target = start + ( to - start ) * k },
+	})
+
+	return t
+}
+
+// Chain sets the tweens to start when this one completes, replacing those set
+// before.
+func (t *Tween) Chain(tweens ...*Tween) (*Tween) {
+	t.chainedTweens = append([]*Tween{}, tweens...)
+	return t
+}
+
+// Start plays the tween from the current time of its group. A tween that is
+// playing is restarted.
+func (t *Tween) Start() (*Tween) {
+	t.playing = true
+	t.started = false
+	t.reversed = false
+	t.startTime = t.group.Time + t.Delay
+	t.repeatsLeft = t.Repeat
+
+	t.group.add( t )
+
+	return t
+}
+
+// Stop stops the tween where it is, without starting the chained tweens.
+func (t *Tween) Stop() (*Tween) {
+	if !t.playing {
+		return t
+	}
+
+	t.playing = false
+	t.group.remove( t )
+
+	if t.OnStop != nil {
+		t.OnStop( t )
+	}
+
+	return t
+}
+
+// StopChainedTweens stops the tweens set with Chain.
+func (t *Tween) StopChainedTweens() (*Tween) {
+	for _, tween := range t.chainedTweens {
+		tween.Stop()
+	}
+	return t
+}
+
+// IsPlaying reports whether the tween has been started and has neither
+// completed nor been stopped.
+func (t *Tween) IsPlaying() bool {
+	return t.playing
+}
+
+// update sets the values for time, and completes the tween at its end.
+func (t *Tween) update(time float64) {
+	if time < t.startTime {
+		return
+	}
+
+	if !t.started {
+		t.started = true
+
+		for _, p := range t.properties {
+			p.begin()
+		}
+
+		if t.OnStart != nil {
+			t.OnStart( t )
+		}
+	}
+
+	elapsed := 1.0
+	if t.Duration > 0 {
+		elapsed = math.Min( ( time - t.startTime ) / t.Duration, 1 )
+	}
+
+	k := t.Easing( elapsed )
+	if t.reversed {
+		k = 1 - k
+	}
+
+	for _, p := range t.properties {
+		p.set( k )
+	}
+
+	if t.OnUpdate != nil {
+		t.OnUpdate( t, k )
+	}
+
+	if elapsed < 1 || !t.playing {
+		return
+	}
+
+	if t.repeatsLeft > 0 {
+		t.repeatsLeft --
+
+		if t.Yoyo {
+			t.reversed = !t.reversed
+		}
+
+		// keep the time past the end of this run, so repeats don't drift
+		t.startTime += t.Duration + t.Delay
+
+		if t.OnRepeat != nil {
+			t.OnRepeat( t )
+		}
+
+		return
+	}
+
+	t.playing = false
+
+	if t.OnComplete != nil {
+		t.OnComplete( t )
+	}
+
+	for _, tween := range t.chainedTweens {
+		tween.Start()
+	}
+}
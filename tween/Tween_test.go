@@ -0,0 +1,127 @@
+package tween
+
+import (
+	"testing"
+)
+
+func TestTweenToFloat(t *testing.T) {
+	group := NewGroup()
+	value := 10.0
+
+	completed := false
+	tween := NewTween( 2, group ).ToFloat( &value, 20 )
+	tween.OnComplete = func(*Tween) { completed = true }
+	tween.Start()
+
+	group.Update( 1 )
+	if value != 15 {
+		t.Errorf("got %v halfway, want 15", value)
+	}
+
+	group.Update( 2 )
+	if value != 20 || !completed || tween.IsPlaying() {
+		t.Errorf("got %v completed %v playing %v at the end, want 20, true and false", value, completed, tween.IsPlaying())
+	}
+	if len(group.GetAll()) != 0 {
+		t.Error("completed tween is still in the group")
+	}
+}
+
+func TestTweenDelayStartsFromCurrentValue(t *testing.T) {
+	group := NewGroup()
+	value := 0.0
+
+	NewTween( 1, group ).ToFloat( &value, 10 ).Start()
+	tween := NewTween( 1, group ).ToFloat( &value, 10 )
+	tween.Delay = 1
+	tween.Start()
+
+	// the first tween moves value before the second one starts
+	group.Update( 0.5 )
+	if value != 5 {
+		t.Fatalf("got %v, want 5", value)
+	}
+
+	group.Update( 1 )
+	if value != 10 {
+		t.Errorf("got %v at the start of the delayed tween, want 10", value)
+	}
+}
+
+func TestTweenYoyo(t *testing.T) {
+	group := NewGroup()
+	value := 0.0
+
+	repeats := 0
+	tween := NewTween( 1, group ).ToFloat( &value, 10 )
+	tween.Repeat = 1
+	tween.Yoyo = true
+	tween.OnRepeat = func(*Tween) { repeats ++ }
+	tween.Start()
+
+	group.Update( 1 )
+	if value != 10 || repeats != 1 {
+		t.Fatalf("got %v after %d repeats, want 10 after 1", value, repeats)
+	}
+
+	group.Update( 0.25 )
+	if value != 7.5 {
+		t.Errorf("got %v on the way back, want 7.5", value)
+	}
+
+	group.Update( 1 )
+	if value != 0 || tween.IsPlaying() {
+		t.Errorf("got %v playing %v at the end, want 0 and false", value, tween.IsPlaying())
+	}
+}
+
+func TestTweenChain(t *testing.T) {
+	group := NewGroup()
+	a, b := 0.0, 0.0
+
+	second := NewTween( 1, group ).ToFloat( &b, 1 )
+	first := NewTween( 1, group ).ToFloat( &a, 1 ).Chain( second ).Start()
+
+	if second.IsPlaying() {
+		t.Fatal("chained tween started early")
+	}
+
+	group.Update( 1 )
+	if a != 1 || !second.IsPlaying() {
+		t.Fatalf("got a %v, second playing %v, want 1 and true", a, second.IsPlaying())
+	}
+
+	group.Update( 0.5 )
+	if b != 0.5 {
+		t.Errorf("got b %v, want 0.5", b)
+	}
+
+	first.Start()
+	first.Stop()
+	if first.IsPlaying() || !second.IsPlaying() {
+		t.Error("stopping a tween should not stop its chained tweens")
+	}
+	second.Stop()
+	if len(group.GetAll()) != 0 {
+		t.Errorf("got %d tweens in the group, want 0", len(group.GetAll()))
+	}
+}
+
+func TestGroupRemoveAll(t *testing.T) {
+	group := NewGroup()
+	value := 0.0
+
+	tween := NewTween( 1, group ).ToFloat( &value, 10 ).Start()
+	group.RemoveAll()
+
+	if tween.IsPlaying() {
+		t.Error("removed tween is still playing")
+	}
+
+	// a removed tween can be started again
+	tween.Start()
+	group.Update( 0.5 )
+	if value != 5 {
+		t.Errorf("got %v after restarting, want 5", value)
+	}
+}